@@ -56,4 +56,14 @@ func main() {
 	}
 
 	fmt.Printf("instType=%s instId=%s instIdCode=%d source=%d bytes=%d prefix=%x\n", instType, instId, instIdCode, source, len(data), prefix)
+
+	snap, err := okx.DecodeSBESnapshotDepth(data)
+	if err != nil {
+		log.Printf("decode failed: %v", err)
+		return
+	}
+	fmt.Printf("seqId=%d tsUs=%d asks=%d bids=%d\n", snap.SeqId, snap.TsUs, len(snap.Asks), len(snap.Bids))
+	if len(snap.Asks) > 0 && len(snap.Bids) > 0 {
+		fmt.Printf("bestAsk=%s@%s bestBid=%s@%s\n", snap.Asks[0].Sz, snap.Asks[0].Px, snap.Bids[0].Sz, snap.Bids[0].Px)
+	}
 }
@@ -26,7 +26,7 @@ var errEmptyAccountActivateOption = errors.New("okx: empty activate option respo
 // Do 开通期权交易（POST /api/v5/account/activate-option）。
 func (s *AccountActivateOptionService) Do(ctx context.Context) (*AccountActivateOptionAck, error) {
 	var data []AccountActivateOptionAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/activate-option", nil, nil, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/activate-option", nil, nil, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
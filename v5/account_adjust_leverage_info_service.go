@@ -102,7 +102,7 @@ func (s *AccountAdjustLeverageInfoService) Do(ctx context.Context) (*AccountAdju
 	}
 
 	var data []AccountAdjustLeverageInfo
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/adjust-leverage-info", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/adjust-leverage-info", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
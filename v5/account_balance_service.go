@@ -57,7 +57,7 @@ func (s *AccountBalanceService) Do(ctx context.Context) (*AccountBalance, error)
 	}
 
 	var data []AccountBalance
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/balance", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/balance", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -91,7 +91,7 @@ func (s *AccountBillsArchiveService) Limit(limit int) *AccountBillsArchiveServic
 // Do 查询交易账户账单流水（GET /api/v5/account/bills-archive）。
 func (s *AccountBillsArchiveService) Do(ctx context.Context) ([]AccountBill, error) {
 	var data []AccountBill
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/bills-archive", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/bills-archive", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
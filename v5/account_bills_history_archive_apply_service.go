@@ -53,7 +53,7 @@ func (s *AccountBillsHistoryArchiveApplyService) Do(ctx context.Context) (*Accou
 	}
 
 	var data []AccountBillsHistoryArchiveApplyAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/bills-history-archive", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityRead, http.MethodPost, "/api/v5/account/bills-history-archive", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
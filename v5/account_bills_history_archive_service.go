@@ -55,7 +55,7 @@ func (s *AccountBillsHistoryArchiveService) Do(ctx context.Context) (*AccountBil
 	q.Set("quarter", s.quarter)
 
 	var data []AccountBillsHistoryArchive
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/bills-history-archive", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/bills-history-archive", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -203,7 +203,7 @@ func (s *AccountBillsService) Limit(limit int) *AccountBillsService {
 // Do 查询交易账户账单流水（GET /api/v5/account/bills）。
 func (s *AccountBillsService) Do(ctx context.Context) ([]AccountBill, error) {
 	var data []AccountBill
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/bills", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/bills", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
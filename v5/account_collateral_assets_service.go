@@ -52,7 +52,7 @@ func (s *AccountCollateralAssetsService) Do(ctx context.Context) ([]AccountColla
 	}
 
 	var data []AccountCollateralAsset
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/collateral-assets", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/collateral-assets", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
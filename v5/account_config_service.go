@@ -68,7 +68,7 @@ var errEmptyAccountConfig = errors.New("okx: empty account config response")
 // Do 查看账户配置（GET /api/v5/account/config）。
 func (s *AccountConfigService) Do(ctx context.Context) (*AccountConfig, error) {
 	var data []AccountConfig
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/config", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/config", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
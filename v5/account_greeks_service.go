@@ -47,7 +47,7 @@ func (s *AccountGreeksService) Do(ctx context.Context) ([]AccountGreeks, error)
 	}
 
 	var data []AccountGreeks
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/greeks", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/greeks", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -85,7 +85,7 @@ func (s *AccountInstrumentsService) Do(ctx context.Context) ([]AccountInstrument
 	}
 
 	var data []AccountInstrument
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/instruments", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/instruments", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -115,7 +115,7 @@ func (s *AccountInterestAccruedService) Do(ctx context.Context) ([]AccountIntere
 	}
 
 	var data []AccountInterestAccrued
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/interest-accrued", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/interest-accrued", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -85,7 +85,7 @@ func (s *AccountInterestLimitsService) Do(ctx context.Context) ([]AccountInteres
 	}
 
 	var data []AccountInterestLimits
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/interest-limits", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/interest-limits", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -38,7 +38,7 @@ func (s *AccountInterestRateService) Do(ctx context.Context) ([]AccountInterestR
 	}
 
 	var data []AccountInterestRate
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/interest-rate", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/interest-rate", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
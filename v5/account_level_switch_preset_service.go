@@ -74,7 +74,7 @@ func (s *AccountLevelSwitchPresetService) Do(ctx context.Context) (*AccountLevel
 	}
 
 	var raw json.RawMessage
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/account-level-switch-preset", nil, s.r, true, nil, &raw)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/account-level-switch-preset", nil, s.r, true, nil, &raw)
 	if err != nil {
 		return nil, err
 	}
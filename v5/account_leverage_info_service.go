@@ -65,7 +65,7 @@ func (s *AccountLeverageInfoService) Do(ctx context.Context) ([]AccountLeverageI
 	}
 
 	var data []AccountLeverageInfo
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/leverage-info", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/leverage-info", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -93,7 +93,7 @@ func (s *AccountMaxAvailSizeService) Do(ctx context.Context) ([]AccountMaxAvailS
 	}
 
 	var data []AccountMaxAvailSize
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/max-avail-size", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/max-avail-size", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
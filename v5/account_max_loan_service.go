@@ -87,7 +87,7 @@ func (s *AccountMaxLoanService) Do(ctx context.Context) ([]AccountMaxLoan, error
 	}
 
 	var data []AccountMaxLoan
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/max-loan", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/max-loan", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -93,7 +93,7 @@ func (s *AccountMaxSizeService) Do(ctx context.Context) ([]AccountMaxSize, error
 	}
 
 	var data []AccountMaxSize
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/max-size", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/max-size", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -41,7 +41,7 @@ func (s *AccountMaxWithdrawalService) Do(ctx context.Context) ([]AccountMaxWithd
 	}
 
 	var data []AccountMaxWithdrawal
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/max-withdrawal", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/max-withdrawal", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
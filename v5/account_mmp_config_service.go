@@ -43,7 +43,7 @@ func (s *AccountMMPConfigService) Do(ctx context.Context) ([]AccountMMPConfig, e
 	}
 
 	var data []AccountMMPConfig
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/mmp-config", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/mmp-config", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -51,7 +51,7 @@ func (s *AccountMMPResetService) Do(ctx context.Context) (*AccountMMPResetAck, e
 	}
 
 	var data []AccountMMPResetAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/mmp-reset", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/mmp-reset", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +122,7 @@ func (s *AccountMovePositionsHistoryService) Do(ctx context.Context) ([]AccountM
 	}
 
 	var data []AccountMovePositionsHistoryItem
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/move-positions-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/move-positions-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
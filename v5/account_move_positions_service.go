@@ -139,7 +139,7 @@ func (s *AccountMovePositionsService) Do(ctx context.Context) (*AccountMovePosit
 	}
 
 	var data []AccountMovePositionsAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/move-positions", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/move-positions", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +96,7 @@ func (s *AccountPositionBuilderGraphService) Do(ctx context.Context) ([]AccountP
 	}
 
 	var data []AccountPositionBuilderGraphResult
-	if err := s.c.do(ctx, http.MethodPost, "/api/v5/account/position-builder-graph", nil, s.req, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodPost, "/api/v5/account/position-builder-graph", nil, s.req, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -156,7 +156,7 @@ func (s *AccountPositionBuilderService) Do(ctx context.Context) (*AccountPositio
 	}
 
 	var data []AccountPositionBuilderResult
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/position-builder", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityRead, http.MethodPost, "/api/v5/account/position-builder", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +77,7 @@ func (s *AccountPositionMarginBalanceService) Do(ctx context.Context) (*AccountP
 	}
 
 	var data []AccountPositionMarginBalanceAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/position/margin-balance", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/position/margin-balance", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
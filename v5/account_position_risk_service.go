@@ -70,7 +70,7 @@ func (s *AccountPositionRiskService) Do(ctx context.Context) (*AccountPositionRi
 	}
 
 	var data []AccountPositionRisk
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/account-position-risk", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/account-position-risk", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
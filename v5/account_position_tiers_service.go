@@ -60,7 +60,7 @@ func (s *AccountPositionTiersService) Do(ctx context.Context) ([]AccountPosition
 	q.Set("instFamily", s.instFamily)
 
 	var data []AccountPositionTier
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/position-tiers", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/position-tiers", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
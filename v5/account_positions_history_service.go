@@ -141,7 +141,7 @@ func (s *AccountPositionsHistoryService) Do(ctx context.Context) ([]AccountPosit
 	}
 
 	var data []AccountPositionsHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/positions-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/positions-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
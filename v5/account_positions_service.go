@@ -80,7 +80,7 @@ func (s *AccountPositionsService) Do(ctx context.Context) ([]AccountPosition, er
 	}
 
 	var data []AccountPosition
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/positions", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/positions", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
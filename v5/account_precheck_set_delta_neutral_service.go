@@ -53,7 +53,7 @@ func (s *AccountPrecheckSetDeltaNeutralService) Do(ctx context.Context) (*Accoun
 	q.Set("stgyType", s.stgyType)
 
 	var data []AccountPrecheckSetDeltaNeutralResult
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/precheck-set-delta-neutral", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/precheck-set-delta-neutral", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
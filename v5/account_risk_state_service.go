@@ -29,7 +29,7 @@ var errEmptyAccountRiskState = errors.New("okx: empty account risk state respons
 // Do 查看账户特定风险状态（GET /api/v5/account/risk-state）。
 func (s *AccountRiskStateService) Do(ctx context.Context) (*AccountRiskState, error) {
 	var data []AccountRiskState
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/risk-state", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/risk-state", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
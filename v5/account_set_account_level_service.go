@@ -52,7 +52,7 @@ func (s *AccountSetAccountLevelService) Do(ctx context.Context) (*AccountSetAcco
 	}
 
 	var data []AccountSetAccountLevelAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-account-level", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/set-account-level", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
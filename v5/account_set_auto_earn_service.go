@@ -82,7 +82,7 @@ func (s *AccountSetAutoEarnService) Do(ctx context.Context) (*AccountSetAutoEarn
 	}
 
 	var data []AccountSetAutoEarnAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-auto-earn", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/account/set-auto-earn", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +54,7 @@ func validateAccountSetAutoLoanAck(ack *accountSetAutoLoanAckRaw, req accountSet
 // Do 设置自动借币（POST /api/v5/account/set-auto-loan）。
 func (s *AccountSetAutoLoanService) Do(ctx context.Context) (*AccountSetAutoLoanAck, error) {
 	var data []accountSetAutoLoanAckRaw
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-auto-loan", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/account/set-auto-loan", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
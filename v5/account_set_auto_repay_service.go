@@ -59,7 +59,7 @@ func (s *AccountSetAutoRepayService) Do(ctx context.Context) (*AccountSetAutoRep
 	}
 
 	var data []accountSetAutoRepayAckRaw
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-auto-repay", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/account/set-auto-repay", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
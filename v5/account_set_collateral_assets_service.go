@@ -80,7 +80,7 @@ func (s *AccountSetCollateralAssetsService) Do(ctx context.Context) (*AccountSet
 	}
 
 	var data []AccountSetCollateralAssetsAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-collateral-assets", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/account/set-collateral-assets", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
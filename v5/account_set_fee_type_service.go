@@ -52,7 +52,7 @@ func (s *AccountSetFeeTypeService) Do(ctx context.Context) (*AccountSetFeeTypeAc
 	}
 
 	var data []AccountSetFeeTypeAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-fee-type", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/set-fee-type", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
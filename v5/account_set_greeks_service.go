@@ -52,7 +52,7 @@ func (s *AccountSetGreeksService) Do(ctx context.Context) (*AccountSetGreeksAck,
 	}
 
 	var data []AccountSetGreeksAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-greeks", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/set-greeks", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
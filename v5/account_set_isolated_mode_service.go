@@ -59,7 +59,7 @@ func (s *AccountSetIsolatedModeService) Do(ctx context.Context) (*AccountSetIsol
 	}
 
 	var data []AccountSetIsolatedModeAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-isolated-mode", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/set-isolated-mode", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +104,7 @@ func (s *AccountSetLeverageService) Do(ctx context.Context) (*AccountSetLeverage
 	}
 
 	var data []AccountSetLeverageAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-leverage", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/set-leverage", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +86,7 @@ func (s *AccountSetMMPConfigService) Do(ctx context.Context) (*AccountSetMMPConf
 	}
 
 	var data []AccountSetMMPConfigAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/mmp-config", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/mmp-config", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
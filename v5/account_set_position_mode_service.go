@@ -52,7 +52,7 @@ func (s *AccountSetPositionModeService) Do(ctx context.Context) (*AccountSetPosi
 	req := accountSetPositionModeRequest{PosMode: s.posMode}
 
 	var data []AccountSetPositionModeAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-position-mode", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/set-position-mode", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +63,7 @@ func (s *AccountSetRiskOffsetAmtService) Do(ctx context.Context) (*AccountSetRis
 	}
 
 	var data []AccountSetRiskOffsetAmtAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-riskOffset-amt", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/set-riskOffset-amt", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
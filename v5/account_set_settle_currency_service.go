@@ -52,7 +52,7 @@ func (s *AccountSetSettleCurrencyService) Do(ctx context.Context) (*AccountSetSe
 	}
 
 	var data []AccountSetSettleCurrencyAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-settle-currency", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/set-settle-currency", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -67,7 +67,7 @@ func (s *AccountSetTradingConfigService) Do(ctx context.Context) (*AccountSetTra
 	}
 
 	var data []AccountSetTradingConfigAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/set-trading-config", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/account/set-trading-config", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
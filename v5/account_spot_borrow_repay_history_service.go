@@ -85,7 +85,7 @@ func (s *AccountSpotBorrowRepayHistoryService) Do(ctx context.Context) ([]Accoun
 	}
 
 	var data []AccountSpotBorrowRepayHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/spot-borrow-repay-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/spot-borrow-repay-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
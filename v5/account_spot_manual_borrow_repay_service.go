@@ -60,7 +60,7 @@ func (s *AccountSpotManualBorrowRepayService) Do(ctx context.Context) (*AccountS
 	}
 
 	var data []AccountSpotManualBorrowRepayAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/account/spot-manual-borrow-repay", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/account/spot-manual-borrow-repay", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
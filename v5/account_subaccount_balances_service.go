@@ -39,7 +39,7 @@ func (s *AccountSubaccountBalancesService) Do(ctx context.Context) (*AccountBala
 	q.Set("subAcct", s.subAcct)
 
 	var data []AccountBalance
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/subaccount/balances", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/subaccount/balances", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
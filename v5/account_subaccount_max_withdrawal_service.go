@@ -47,7 +47,7 @@ func (s *AccountSubaccountMaxWithdrawalService) Do(ctx context.Context) ([]Accou
 	}
 
 	var data []AccountMaxWithdrawal
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/subaccount/max-withdrawal", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/subaccount/max-withdrawal", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
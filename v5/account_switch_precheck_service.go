@@ -91,7 +91,7 @@ func (s *AccountSwitchPrecheckService) Do(ctx context.Context) (*AccountSwitchPr
 	q.Set("acctLv", s.acctLv)
 
 	var data []AccountSwitchPrecheckResult
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodGet, "/api/v5/account/set-account-switch-precheck", q, nil, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/set-account-switch-precheck", q, nil, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +111,7 @@ func (s *AccountTradeFeeService) Do(ctx context.Context) ([]AccountTradeFee, err
 	}
 
 	var data []AccountTradeFee
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/account/trade-fee", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/account/trade-fee", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -57,7 +57,7 @@ func (s *AffiliateInviteeDetailService) Do(ctx context.Context) (*AffiliateInvit
 	q.Set("uid", s.uid)
 
 	var data []AffiliateInviteeDetail
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/affiliate/invitee/detail", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/affiliate/invitee/detail", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
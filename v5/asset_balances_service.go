@@ -41,7 +41,7 @@ func (s *AssetBalancesService) Do(ctx context.Context) ([]AssetBalance, error) {
 	}
 
 	var data []AssetBalance
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/balances", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/balances", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -104,7 +104,7 @@ func (s *AssetBillsHistoryService) PagingType(pagingType string) *AssetBillsHist
 // Do 获取资金流水全历史（GET /api/v5/asset/bills-history）。
 func (s *AssetBillsHistoryService) Do(ctx context.Context) ([]AssetBill, error) {
 	var data []AssetBill
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/bills-history", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/bills-history", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -106,7 +106,7 @@ func (s *AssetBillsService) Limit(limit int) *AssetBillsService {
 // Do 获取资金流水（GET /api/v5/asset/bills）。
 func (s *AssetBillsService) Do(ctx context.Context) ([]AssetBill, error) {
 	var data []AssetBill
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/bills", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/bills", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
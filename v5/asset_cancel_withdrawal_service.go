@@ -43,7 +43,7 @@ func (s *AssetCancelWithdrawalService) Do(ctx context.Context) (*AssetCancelWith
 	req := assetCancelWithdrawalRequest{WdId: s.wdId}
 
 	var data []AssetCancelWithdrawalAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/asset/cancel-withdrawal", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityWithdraw, http.MethodPost, "/api/v5/asset/cancel-withdrawal", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
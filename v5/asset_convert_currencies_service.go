@@ -25,7 +25,7 @@ func (c *Client) NewAssetConvertCurrenciesService() *AssetConvertCurrenciesServi
 // Do 获取闪兑币种列表（GET /api/v5/asset/convert/currencies）。
 func (s *AssetConvertCurrenciesService) Do(ctx context.Context) ([]AssetConvertCurrency, error) {
 	var data []AssetConvertCurrency
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/convert/currencies", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/convert/currencies", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -56,7 +56,7 @@ func (s *AssetConvertCurrencyPairService) Do(ctx context.Context) ([]AssetConver
 	q.Set("toCcy", s.toCcy)
 
 	var data []AssetConvertCurrencyPair
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/convert/currency-pair", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/convert/currency-pair", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
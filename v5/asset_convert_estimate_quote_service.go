@@ -103,7 +103,7 @@ func (s *AssetConvertEstimateQuoteService) Do(ctx context.Context) (*AssetConver
 	}
 
 	var data []AssetConvertQuote
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/asset/convert/estimate-quote", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityRead, http.MethodPost, "/api/v5/asset/convert/estimate-quote", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
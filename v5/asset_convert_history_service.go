@@ -83,7 +83,7 @@ func (s *AssetConvertHistoryService) Tag(tag string) *AssetConvertHistoryService
 // Do 获取闪兑交易历史（GET /api/v5/asset/convert/history）。
 func (s *AssetConvertHistoryService) Do(ctx context.Context) ([]AssetConvertTrade, error) {
 	var data []AssetConvertTrade
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/convert/history", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/convert/history", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
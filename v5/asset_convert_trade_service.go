@@ -106,7 +106,7 @@ func (s *AssetConvertTradeService) Do(ctx context.Context) (*AssetConvertTrade,
 	}
 
 	var data []AssetConvertTrade
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/asset/convert/trade", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/asset/convert/trade", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
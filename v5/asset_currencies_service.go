@@ -71,7 +71,7 @@ func (s *AssetCurrenciesService) Do(ctx context.Context) ([]AssetCurrency, error
 	}
 
 	var data []AssetCurrency
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/currencies", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/currencies", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -53,7 +53,7 @@ func (s *AssetDepositAddressService) Do(ctx context.Context) ([]AssetDepositAddr
 	q.Set("ccy", s.ccy)
 
 	var data []AssetDepositAddress
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/deposit-address", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/deposit-address", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
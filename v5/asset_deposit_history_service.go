@@ -143,7 +143,7 @@ func (s *AssetDepositHistoryService) Limit(limit int) *AssetDepositHistoryServic
 // Do 获取充值记录（GET /api/v5/asset/deposit-history）。
 func (s *AssetDepositHistoryService) Do(ctx context.Context) ([]AssetDeposit, error) {
 	var data []AssetDeposit
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/deposit-history", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/deposit-history", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
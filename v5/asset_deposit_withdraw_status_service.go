@@ -91,7 +91,7 @@ func (s *AssetDepositWithdrawStatusService) Do(ctx context.Context) ([]AssetDepo
 	}
 
 	var data []AssetDepositWithdrawStatus
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/deposit-withdraw-status", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/deposit-withdraw-status", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -24,7 +24,7 @@ func (c *Client) NewAssetExchangeListService() *AssetExchangeListService {
 // Do 获取交易所列表（GET /api/v5/asset/exchange-list）。
 func (s *AssetExchangeListService) Do(ctx context.Context) ([]AssetExchange, error) {
 	var data []AssetExchange
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/exchange-list", nil, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/exchange-list", nil, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -39,7 +39,7 @@ func (s *AssetMonthlyStatementApplyService) Do(ctx context.Context) (*AssetMonth
 	req := assetMonthlyStatementApplyRequest{Month: s.month}
 
 	var data []AssetMonthlyStatementApplyAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/asset/monthly-statement", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityRead, http.MethodPost, "/api/v5/asset/monthly-statement", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +43,7 @@ func (s *AssetMonthlyStatementService) Do(ctx context.Context) ([]AssetMonthlySt
 	q.Set("month", s.month)
 
 	var data []AssetMonthlyStatement
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/monthly-statement", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/monthly-statement", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
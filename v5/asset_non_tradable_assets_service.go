@@ -54,7 +54,7 @@ func (s *AssetNonTradableAssetsService) Do(ctx context.Context) ([]AssetNonTrada
 	}
 
 	var data []AssetNonTradableAsset
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/non-tradable-assets", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/non-tradable-assets", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
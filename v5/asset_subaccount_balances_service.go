@@ -46,7 +46,7 @@ func (s *AssetSubaccountBalancesService) Do(ctx context.Context) ([]AssetBalance
 	}
 
 	var data []AssetBalance
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/subaccount/balances", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/subaccount/balances", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
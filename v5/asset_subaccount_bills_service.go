@@ -109,7 +109,7 @@ func (s *AssetSubaccountBillsService) Limit(limit int) *AssetSubaccountBillsServ
 // Do 查询子账户转账记录（GET /api/v5/asset/subaccount/bills）。
 func (s *AssetSubaccountBillsService) Do(ctx context.Context) ([]AssetSubaccountBill, error) {
 	var data []AssetSubaccountBill
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/subaccount/bills", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/subaccount/bills", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
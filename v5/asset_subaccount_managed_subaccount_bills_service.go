@@ -61,7 +61,7 @@ func (s *AssetSubaccountManagedSubaccountBillsService) Limit(limit int) *AssetSu
 // Do 查询托管子账户转账记录（GET /api/v5/asset/subaccount/managed-subaccount-bills）。
 func (s *AssetSubaccountManagedSubaccountBillsService) Do(ctx context.Context) ([]AssetSubaccountBill, error) {
 	var data []AssetSubaccountBill
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/subaccount/managed-subaccount-bills", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/subaccount/managed-subaccount-bills", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -92,7 +92,7 @@ func (s *AssetSubaccountTransferService) Do(ctx context.Context) (*AssetSubaccou
 	}
 
 	var data []AssetSubaccountTransferAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/asset/subaccount/transfer", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTransfer, http.MethodPost, "/api/v5/asset/subaccount/transfer", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
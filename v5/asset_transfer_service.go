@@ -98,7 +98,7 @@ func (s *AssetTransferService) Do(ctx context.Context) (*AssetTransferAck, error
 	}
 
 	var data []AssetTransferAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/asset/transfer", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTransfer, http.MethodPost, "/api/v5/asset/transfer", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +74,7 @@ func (s *AssetTransferStateService) Do(ctx context.Context) ([]AssetTransferStat
 	}
 
 	var data []AssetTransferState
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/transfer-state", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/transfer-state", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -51,7 +51,7 @@ func (s *AssetValuationService) Do(ctx context.Context) (*AssetValuation, error)
 	}
 
 	var data []AssetValuation
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/asset-valuation", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/asset-valuation", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -154,7 +154,7 @@ func (s *AssetWithdrawalHistoryService) Limit(limit int) *AssetWithdrawalHistory
 // Do 获取提币记录（GET /api/v5/asset/withdrawal-history）。
 func (s *AssetWithdrawalHistoryService) Do(ctx context.Context) ([]AssetWithdrawal, error) {
 	var data []AssetWithdrawal
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/asset/withdrawal-history", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/asset/withdrawal-history", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
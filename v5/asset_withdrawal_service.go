@@ -144,7 +144,7 @@ func (s *AssetWithdrawalService) Do(ctx context.Context) (*AssetWithdrawalAck, e
 	}
 
 	var data []AssetWithdrawalAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/asset/withdrawal", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityWithdraw, http.MethodPost, "/api/v5/asset/withdrawal", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,431 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CandleBarKind 表示 CandleBuilder 的成柱方式。
+type CandleBarKind uint8
+
+const (
+	// CandleBarTime 按固定时长成柱（按 Unix 纪元对齐，即 UTC）。
+	CandleBarTime CandleBarKind = iota
+	// CandleBarVolume 按累计成交量（sz）成柱。
+	CandleBarVolume
+	// CandleBarTick 按成交笔数成柱。
+	CandleBarTick
+)
+
+// CandleBar 描述 CandleBuilder 的成柱规则（用 CandleTimeBar / CandleVolumeBar / CandleTickBar 构造）。
+type CandleBar struct {
+	Kind CandleBarKind
+	// Interval 为时间柱时长。
+	Interval time.Duration
+	// Volume 为成交量柱的阈值（十进制字符串，单位与 MarketTrade.Sz 相同）。
+	Volume string
+	// Ticks 为成交笔数柱的笔数。
+	Ticks int
+}
+
+// CandleTimeBar 返回时长为 d 的时间柱（如 5*time.Second、7*time.Minute）。
+func CandleTimeBar(d time.Duration) CandleBar {
+	return CandleBar{Kind: CandleBarTime, Interval: d}
+}
+
+// CandleVolumeBar 返回累计成交量达到 volume 即收柱的成交量柱。
+func CandleVolumeBar(volume string) CandleBar {
+	return CandleBar{Kind: CandleBarVolume, Volume: volume}
+}
+
+// CandleTickBar 返回每 n 笔成交收柱的笔数柱。
+func CandleTickBar(n int) CandleBar {
+	return CandleBar{Kind: CandleBarTick, Ticks: n}
+}
+
+// CandleUpdate 为 CandleBuilder 输出的一根 K 线（Candle.Confirm 为 "0" 表示未完结的增量更新，"1" 表示已收柱）。
+type CandleUpdate struct {
+	InstId string
+	Candle Candle
+}
+
+// CandleBuilderOption 用于配置 CandleBuilder。
+type CandleBuilderOption func(*CandleBuilder)
+
+// WithCandleBuilderUpdates 设置是否在每笔成交后输出未完结的增量更新（默认输出；关闭后仅输出收柱）。
+func WithCandleBuilderUpdates(enabled bool) CandleBuilderOption {
+	return func(b *CandleBuilder) {
+		b.updates = enabled
+	}
+}
+
+// CandleBuilder 由 trades / trades-all 成交流合成任意周期的 K 线（时间柱 / 成交量柱 / 笔数柱），按 instId 独立维护。
+//
+// 用法：ws.OnTradesAll(b.OnTrade)（或 OnTrades）。
+//
+// 说明：
+// - Vol 为 sz 累计；VolCcyQuote 为 px×sz 累计（现货为计价币成交额，合约需自行乘 ctVal）；VolCcy 不填；
+// - 时间柱在下一笔跨柱成交到达时收柱；行情清淡时可定期调用 Flush 按时间收柱；无成交的周期不输出空柱；
+// - 早于当前柱（或 Seed 截止时间）的成交会被忽略；
+// - 成交量柱由跨越阈值的那笔成交收柱（不拆分成交，最终 Vol 可能略大于阈值）；trades 频道的聚合成交按 Count 计笔数；
+// - handler 在调用 OnTrade / Flush 的 goroutine 中、锁外执行。
+type CandleBuilder struct {
+	bar     CandleBar
+	volume  *big.Rat
+	handler func(CandleUpdate)
+	updates bool
+	now     func() time.Time
+
+	mu     sync.Mutex
+	states map[string]*candleState
+	// cutoff 为 Seed 覆盖到的时间或最近收柱的结束时间（毫秒）：早于该时间的成交已计入已输出的 K 线。
+	cutoff map[string]int64
+}
+
+type candleState struct {
+	start, end int64 // 时间柱区间 [start, end)
+	ticks      int
+
+	open, high, low, close string
+	vol, quote             *big.Rat
+	volScale, quoteScale   int
+}
+
+// NewCandleBuilder 创建 CandleBuilder；handler 接收增量更新与收柱。
+func NewCandleBuilder(bar CandleBar, handler func(CandleUpdate), opts ...CandleBuilderOption) (*CandleBuilder, error) {
+	if handler == nil {
+		return nil, errors.New("okx: candle builder requires handler")
+	}
+	b := &CandleBuilder{
+		bar:     bar,
+		handler: handler,
+		updates: true,
+		now:     time.Now,
+		states:  make(map[string]*candleState),
+		cutoff:  make(map[string]int64),
+	}
+	switch bar.Kind {
+	case CandleBarTime:
+		if bar.Interval < time.Millisecond || bar.Interval%time.Millisecond != 0 {
+			return nil, fmt.Errorf("okx: candle builder invalid interval %s", bar.Interval)
+		}
+	case CandleBarVolume:
+		v, err := parseDecimal(bar.Volume)
+		if err != nil || v.Sign() <= 0 {
+			return nil, fmt.Errorf("okx: candle builder invalid volume %q", bar.Volume)
+		}
+		b.volume = v
+	case CandleBarTick:
+		if bar.Ticks <= 0 {
+			return nil, fmt.Errorf("okx: candle builder invalid ticks %d", bar.Ticks)
+		}
+	default:
+		return nil, fmt.Errorf("okx: candle builder unknown bar kind %d", bar.Kind)
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b, nil
+}
+
+// OnTrade 处理一笔成交（可直接作为 OnTrades / OnTradesAll 的 handler）。
+func (b *CandleBuilder) OnTrade(t MarketTrade) {
+	px, err1 := parseDecimal(t.Px)
+	sz, err2 := parseDecimal(t.Sz)
+	if t.InstId == "" || err1 != nil || err2 != nil {
+		return
+	}
+	ticks := 1
+	if n, err := strconv.Atoi(t.Count); err == nil && n > 0 {
+		ticks = n
+	}
+
+	var out []CandleUpdate
+	b.mu.Lock()
+	if t.TS < b.cutoff[t.InstId] {
+		b.mu.Unlock()
+		return
+	}
+	s := b.states[t.InstId]
+	if s != nil && b.bar.Kind == CandleBarTime {
+		if t.TS < s.start {
+			b.mu.Unlock()
+			return
+		}
+		if t.TS >= s.end {
+			out = append(out, b.closeTimeBarLocked(t.InstId, s))
+			s = nil
+		}
+	}
+	if s == nil {
+		s = b.newState(t.TS)
+		s.open, s.high, s.low = t.Px, t.Px, t.Px
+		b.states[t.InstId] = s
+	}
+	s.add(t.Px, t.Sz, px, sz, ticks)
+
+	if b.closedLocked(s) {
+		out = append(out, CandleUpdate{InstId: t.InstId, Candle: s.candle("1")})
+		delete(b.states, t.InstId)
+	} else if b.updates {
+		out = append(out, CandleUpdate{InstId: t.InstId, Candle: s.candle("0")})
+	}
+	b.mu.Unlock()
+
+	b.emit(out)
+}
+
+// Flush 将结束时间不晚于 now 的时间柱收柱输出（行情清淡时定期调用；成交量柱 / 笔数柱无效果）。
+//
+// 收柱后迟到的、属于该柱的成交会被忽略，不会重新打开该柱。
+func (b *CandleBuilder) Flush(now time.Time) {
+	if b.bar.Kind != CandleBarTime {
+		return
+	}
+	ms := now.UnixMilli()
+	var out []CandleUpdate
+	b.mu.Lock()
+	for instId, s := range b.states {
+		if s.end <= ms {
+			out = append(out, b.closeTimeBarLocked(instId, s))
+		}
+	}
+	b.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].InstId < out[j].InstId })
+	b.emit(out)
+}
+
+// Current 返回 instId 当前未完结的 K 线。
+func (b *CandleBuilder) Current(instId string) (Candle, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := b.states[instId]
+	if s == nil {
+		return Candle{}, false
+	}
+	return s.candle("0"), true
+}
+
+// Seed 用周期为 src 的历史 K 线（如 MarketCandlesService 返回，任意顺序）初始化 instId 的时间柱，返回合成后的已收柱历史（升序）。
+//
+// src 须整除柱时长且源 K 线按 src 对齐（日线等请使用 utc 版本）；未完结（Confirm="0"）的源 K 线仅使用最新的一根，并入当前柱；
+// 最后一根未完整的柱作为当前柱继续由成交更新；截止时间为最后一根源 K 线的结束时间（未完结时不晚于调用时刻），早于截止时间的成交会被忽略，避免重复计量。
+// 须在开始喂入成交之前调用，仅支持时间柱。
+func (b *CandleBuilder) Seed(instId string, candles []Candle, src time.Duration) ([]Candle, error) {
+	if b.bar.Kind != CandleBarTime {
+		return nil, errors.New("okx: candle builder seed requires time bar")
+	}
+	if instId == "" {
+		return nil, errors.New("okx: candle builder seed requires instId")
+	}
+	if src < time.Millisecond || b.bar.Interval%src != 0 {
+		return nil, fmt.Errorf("okx: candle builder seed interval %s does not divide %s", src, b.bar.Interval)
+	}
+	srcMs := src.Milliseconds()
+
+	sorted := make([]Candle, 0, len(candles))
+	var live *Candle
+	for i, c := range candles {
+		if c.TS%srcMs != 0 {
+			return nil, fmt.Errorf("okx: candle builder seed candle ts=%d not aligned to %s", c.TS, src)
+		}
+		if c.Confirm == "0" {
+			if live == nil || c.TS > live.TS {
+				live = &candles[i]
+			}
+			continue
+		}
+		sorted = append(sorted, c)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].TS < sorted[j].TS })
+	// 未完结的源 K 线须晚于所有已完结的源 K 线，否则忽略。
+	if live != nil && (len(sorted) == 0 || live.TS > sorted[len(sorted)-1].TS) {
+		sorted = append(sorted, *live)
+	} else {
+		live = nil
+	}
+
+	var history []Candle
+	var s *candleState
+	for _, c := range sorted {
+		if s != nil && c.TS >= s.end {
+			history = append(history, s.candle("1"))
+			s = nil
+		}
+		if s == nil {
+			s = b.newState(c.TS)
+			s.open, s.high, s.low = c.Open, c.High, c.Low
+		}
+		if err := s.merge(c); err != nil {
+			return nil, err
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.states, instId)
+	if len(sorted) == 0 {
+		return history, nil
+	}
+	cutoff := sorted[len(sorted)-1].TS + srcMs
+	if live != nil {
+		// 未完结源 K 线已包含截至拉取时刻的成交：截止到当前时间，之后的成交继续计入。
+		if now := b.now().UnixMilli(); now < cutoff {
+			cutoff = now
+		}
+	}
+	b.cutoff[instId] = cutoff
+	if s.end <= cutoff {
+		history = append(history, s.candle("1"))
+	} else {
+		b.states[instId] = s
+	}
+	return history, nil
+}
+
+// SeedFromREST 通过 MarketCandlesService 拉取 instId 最近 limit 根 bar 周期的 K 线并调用 Seed（bar 如 "1m"、"1Hutc"）。
+func (b *CandleBuilder) SeedFromREST(ctx context.Context, c *Client, instId, bar string, limit int) ([]Candle, error) {
+	if c == nil {
+		return nil, errors.New("okx: candle builder seed requires client")
+	}
+	src, err := candleBarDuration(bar)
+	if err != nil {
+		return nil, err
+	}
+	svc := c.NewMarketCandlesService().InstId(instId).Bar(bar)
+	if limit > 0 {
+		svc = svc.Limit(limit)
+	}
+	candles, err := svc.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.Seed(instId, candles, src)
+}
+
+// candleBarDuration 解析 OKX 固定时长的 bar（s/m/H/D/W，可带 utc 后缀；月线不定长，不支持）。
+func candleBarDuration(bar string) (time.Duration, error) {
+	s := strings.TrimSuffix(bar, "utc")
+	if len(s) < 2 {
+		return 0, fmt.Errorf("okx: candle builder unsupported bar %q", bar)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("okx: candle builder unsupported bar %q", bar)
+	}
+	var unit time.Duration
+	switch s[len(s)-1] {
+	case 's':
+		unit = time.Second
+	case 'm':
+		unit = time.Minute
+	case 'H':
+		unit = time.Hour
+	case 'D':
+		unit = 24 * time.Hour
+	case 'W':
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("okx: candle builder unsupported bar %q", bar)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+func (b *CandleBuilder) newState(ts int64) *candleState {
+	s := &candleState{start: ts, vol: new(big.Rat), quote: new(big.Rat)}
+	if b.bar.Kind == CandleBarTime {
+		iv := b.bar.Interval.Milliseconds()
+		s.start = ts - ((ts%iv)+iv)%iv
+		s.end = s.start + iv
+	}
+	return s
+}
+
+// closeTimeBarLocked 收柱 instId 的时间柱并将截止时间推进到柱结束时间，避免迟到成交重开已输出的柱。
+func (b *CandleBuilder) closeTimeBarLocked(instId string, s *candleState) CandleUpdate {
+	delete(b.states, instId)
+	if s.end > b.cutoff[instId] {
+		b.cutoff[instId] = s.end
+	}
+	return CandleUpdate{InstId: instId, Candle: s.candle("1")}
+}
+
+func (b *CandleBuilder) closedLocked(s *candleState) bool {
+	switch b.bar.Kind {
+	case CandleBarVolume:
+		return s.vol.Cmp(b.volume) >= 0
+	case CandleBarTick:
+		return s.ticks >= b.bar.Ticks
+	default:
+		return false
+	}
+}
+
+func (b *CandleBuilder) emit(out []CandleUpdate) {
+	for _, u := range out {
+		b.handler(u)
+	}
+}
+
+func (s *candleState) add(pxStr, szStr string, px, sz *big.Rat, ticks int) {
+	if compareDecimalString(pxStr, s.high) > 0 {
+		s.high = pxStr
+	}
+	if compareDecimalString(pxStr, s.low) < 0 {
+		s.low = pxStr
+	}
+	s.close = pxStr
+	s.ticks += ticks
+	s.vol.Add(s.vol, sz)
+	s.quote.Add(s.quote, new(big.Rat).Mul(px, sz))
+	s.volScale = max(s.volScale, decimalScale(szStr))
+	s.quoteScale = max(s.quoteScale, decimalScale(pxStr)+decimalScale(szStr))
+}
+
+// merge 将一根源 K 线合并进当前柱（Seed 使用）。
+func (s *candleState) merge(c Candle) error {
+	if compareDecimalString(c.High, s.high) > 0 {
+		s.high = c.High
+	}
+	if compareDecimalString(c.Low, s.low) < 0 {
+		s.low = c.Low
+	}
+	s.close = c.Close
+	if c.Vol != "" {
+		v, err := parseDecimal(c.Vol)
+		if err != nil {
+			return err
+		}
+		s.vol.Add(s.vol, v)
+		s.volScale = max(s.volScale, decimalScale(c.Vol))
+	}
+	if c.VolCcyQuote != "" {
+		q, err := parseDecimal(c.VolCcyQuote)
+		if err != nil {
+			return err
+		}
+		s.quote.Add(s.quote, q)
+		s.quoteScale = max(s.quoteScale, decimalScale(c.VolCcyQuote))
+	}
+	return nil
+}
+
+func (s *candleState) candle(confirm string) Candle {
+	return Candle{
+		TS:          s.start,
+		Open:        s.open,
+		High:        s.high,
+		Low:         s.low,
+		Close:       s.close,
+		Vol:         formatDecimal(s.vol, s.volScale),
+		VolCcyQuote: formatDecimal(s.quote, s.quoteScale),
+		Confirm:     confirm,
+	}
+}
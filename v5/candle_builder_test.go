@@ -0,0 +1,197 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func candleUpdateString(u CandleUpdate) string {
+	c := u.Candle
+	return fmt.Sprintf("%s@%d o=%s h=%s l=%s c=%s v=%s q=%s %s", u.InstId, c.TS, c.Open, c.High, c.Low, c.Close, c.Vol, c.VolCcyQuote, c.Confirm)
+}
+
+func TestCandleBuilder_TimeBar(t *testing.T) {
+	var got []string
+	b, err := NewCandleBuilder(CandleTimeBar(5*time.Second), func(u CandleUpdate) { got = append(got, candleUpdateString(u)) })
+	if err != nil {
+		t.Fatalf("NewCandleBuilder() error = %v", err)
+	}
+	ws := &WSClient{}
+	ws.OnTradesAll(b.OnTrade)
+
+	ws.onDataMessage([]byte(`{"arg":{"channel":"trades-all","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","tradeId":"1","px":"100.5","sz":"0.1","side":"buy","ts":"1700000001000"}]}`))
+	b.OnTrade(MarketTrade{InstId: "BTC-USDT", Px: "101", Sz: "0.25", TS: 1700000003000})
+	b.OnTrade(MarketTrade{InstId: "BTC-USDT", Px: "99", Sz: "1", TS: 1700000004999})
+	// 早于当前柱：忽略。
+	b.OnTrade(MarketTrade{InstId: "BTC-USDT", Px: "1", Sz: "1", TS: 1699999999999})
+	// 跨柱：先收柱再开新柱（中间无成交的周期不补空柱）。
+	b.OnTrade(MarketTrade{InstId: "BTC-USDT", Px: "98", Sz: "2", TS: 1700000016000})
+
+	want := []string{
+		"BTC-USDT@1700000000000 o=100.5 h=100.5 l=100.5 c=100.5 v=0.1 q=10.05 0",
+		"BTC-USDT@1700000000000 o=100.5 h=101 l=100.5 c=101 v=0.35 q=35.3 0",
+		"BTC-USDT@1700000000000 o=100.5 h=101 l=99 c=99 v=1.35 q=134.3 0",
+		"BTC-USDT@1700000000000 o=100.5 h=101 l=99 c=99 v=1.35 q=134.3 1",
+		"BTC-USDT@1700000015000 o=98 h=98 l=98 c=98 v=2 q=196 0",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("got\n%v\nwant\n%v", got, want)
+	}
+
+	got = nil
+	b.Flush(time.UnixMilli(1700000019999))
+	if len(got) != 0 {
+		t.Fatalf("flushed early: %v", got)
+	}
+	b.Flush(time.UnixMilli(1700000020000))
+	if len(got) != 1 || got[0] != "BTC-USDT@1700000015000 o=98 h=98 l=98 c=98 v=2 q=196 1" {
+		t.Fatalf("flush = %v", got)
+	}
+	if _, ok := b.Current("BTC-USDT"); ok {
+		t.Fatalf("current after flush")
+	}
+
+	// 收柱后迟到的成交（属于已输出的柱）被忽略，不会再输出同一 TS 的收柱。
+	got = nil
+	b.OnTrade(MarketTrade{InstId: "BTC-USDT", Px: "97", Sz: "1", TS: 1700000019000})
+	b.Flush(time.UnixMilli(1700000025000))
+	if len(got) != 0 {
+		t.Fatalf("late trade reopened flushed bar: %v", got)
+	}
+	b.OnTrade(MarketTrade{InstId: "BTC-USDT", Px: "97", Sz: "1", TS: 1700000020000})
+	if len(got) != 1 || got[0] != "BTC-USDT@1700000020000 o=97 h=97 l=97 c=97 v=1 q=97 0" {
+		t.Fatalf("next bar = %v", got)
+	}
+}
+
+func TestCandleBuilder_VolumeAndTickBars(t *testing.T) {
+	var got []string
+	vb, err := NewCandleBuilder(CandleVolumeBar("1.5"), func(u CandleUpdate) { got = append(got, candleUpdateString(u)) }, WithCandleBuilderUpdates(false))
+	if err != nil {
+		t.Fatalf("NewCandleBuilder() error = %v", err)
+	}
+	vb.OnTrade(MarketTrade{InstId: "ETH-USDT", Px: "10", Sz: "1", TS: 1})
+	vb.OnTrade(MarketTrade{InstId: "ETH-USDT", Px: "12", Sz: "0.7", TS: 2})
+	vb.OnTrade(MarketTrade{InstId: "ETH-USDT", Px: "11", Sz: "0.5", TS: 3})
+	if c, ok := vb.Current("ETH-USDT"); !ok || c.Vol != "0.5" || c.TS != 3 {
+		t.Fatalf("current = %#v", c)
+	}
+	if len(got) != 1 || got[0] != "ETH-USDT@1 o=10 h=12 l=10 c=12 v=1.7 q=18.4 1" {
+		t.Fatalf("volume bars = %v", got)
+	}
+
+	got = nil
+	tb, err := NewCandleBuilder(CandleTickBar(3), func(u CandleUpdate) { got = append(got, candleUpdateString(u)) }, WithCandleBuilderUpdates(false))
+	if err != nil {
+		t.Fatalf("NewCandleBuilder() error = %v", err)
+	}
+	// trades 频道的聚合成交按 Count 计笔数。
+	tb.OnTrade(MarketTrade{InstId: "A", Px: "1", Sz: "1", TS: 1, Count: "2"})
+	tb.OnTrade(MarketTrade{InstId: "B", Px: "5", Sz: "1", TS: 1})
+	tb.OnTrade(MarketTrade{InstId: "A", Px: "2", Sz: "1", TS: 2})
+	if len(got) != 1 || got[0] != "A@1 o=1 h=2 l=1 c=2 v=2 q=3 1" {
+		t.Fatalf("tick bars = %v", got)
+	}
+
+	for _, bar := range []CandleBar{CandleVolumeBar("0"), CandleTickBar(0), CandleTimeBar(0), {Kind: 9}} {
+		if _, err := NewCandleBuilder(bar, func(CandleUpdate) {}); err == nil {
+			t.Fatalf("expected error for %#v", bar)
+		}
+	}
+	if _, err := NewCandleBuilder(CandleTickBar(1), nil); err == nil {
+		t.Fatalf("expected nil handler error")
+	}
+}
+
+func TestCandleBuilder_SeedFromREST(t *testing.T) {
+	const t0 = int64(1700000100000) // 按 3m 对齐
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/market/candles" || r.URL.Query().Get("bar") != "1m" || r.URL.Query().Get("limit") != "5" {
+			t.Errorf("unexpected request %s", r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// 降序返回；最后一根未完结。
+		_, _ = fmt.Fprintf(w, `{"code":"0","msg":"","data":[
+			["%d","9","9","9","9","100","1","900","0"],
+			["%d","7","8","6","7.5","1","1","7.5","1"],
+			["%d","5","6","4","5.5","2","1","11","1"],
+			["%d","3","4","2","3.5","3","1","10.5","1"],
+			["%d","1","5","1","2","4","1","8","1"]]}`,
+			t0+4*60000, t0+3*60000, t0+2*60000, t0+60000, t0)
+	}))
+	t.Cleanup(srv.Close)
+
+	var got []string
+	b, err := NewCandleBuilder(CandleTimeBar(3*time.Minute), func(u CandleUpdate) { got = append(got, candleUpdateString(u)) })
+	if err != nil {
+		t.Fatalf("NewCandleBuilder() error = %v", err)
+	}
+	b.now = func() time.Time { return time.UnixMilli(t0 + 4*60000 + 30000) }
+	c := NewClient(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	history, err := b.SeedFromREST(context.Background(), c, "BTC-USDT", "1m", 5)
+	if err != nil {
+		t.Fatalf("SeedFromREST() error = %v", err)
+	}
+	if len(history) != 1 || history[0].TS != t0 || history[0].Open != "1" || history[0].High != "6" || history[0].Low != "1" ||
+		history[0].Close != "5.5" || history[0].Vol != "9" || history[0].VolCcyQuote != "29.5" || history[0].Confirm != "1" {
+		t.Fatalf("history = %#v", history)
+	}
+	cur, ok := b.Current("BTC-USDT")
+	// 未完结的源 K 线并入当前柱。
+	if !ok || cur.TS != t0+180000 || cur.Open != "7" || cur.High != "9" || cur.Close != "9" || cur.Vol != "101" || cur.VolCcyQuote != "907.5" {
+		t.Fatalf("current = %#v", cur)
+	}
+
+	// 已计入源 K 线（截止到拉取时刻 t0+4m30s）的成交被忽略；之后的成交继续更新当前柱。
+	b.OnTrade(MarketTrade{InstId: "BTC-USDT", Px: "100", Sz: "1", TS: t0 + 4*60000 - 1})
+	b.OnTrade(MarketTrade{InstId: "BTC-USDT", Px: "100", Sz: "1", TS: t0 + 4*60000 + 10000})
+	b.OnTrade(MarketTrade{InstId: "BTC-USDT", Px: "8.5", Sz: "0.5", TS: t0 + 4*60000 + 30000})
+	if len(got) != 1 || got[0] != fmt.Sprintf("BTC-USDT@%d o=7 h=9 l=6 c=8.5 v=101.5 q=911.75 0", t0+180000) {
+		t.Fatalf("updates = %v", got)
+	}
+
+	// 无未完结源 K 线时截止到最后一根源 K 线的结束时间。
+	if _, err := b.Seed("ETH-USDT", []Candle{{TS: t0, Open: "1", High: "1", Low: "1", Close: "1", Vol: "1", Confirm: "1"}}, time.Minute); err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+	b.OnTrade(MarketTrade{InstId: "ETH-USDT", Px: "2", Sz: "1", TS: t0 + 60000 - 1})
+	b.OnTrade(MarketTrade{InstId: "ETH-USDT", Px: "2", Sz: "1", TS: t0 + 60000})
+	if cur, _ := b.Current("ETH-USDT"); cur.Vol != "2" || cur.Close != "2" {
+		t.Fatalf("eth current = %#v", cur)
+	}
+
+	if _, err := b.Seed("BTC-USDT", []Candle{{TS: t0 + 1, Confirm: "1"}}, time.Minute); err == nil {
+		t.Fatalf("expected alignment error")
+	}
+	if _, err := b.Seed("BTC-USDT", nil, 2*time.Minute); err == nil {
+		t.Fatalf("expected divisibility error")
+	}
+	vb, _ := NewCandleBuilder(CandleTickBar(1), func(CandleUpdate) {})
+	if _, err := vb.Seed("BTC-USDT", nil, time.Minute); err == nil {
+		t.Fatalf("expected time bar error")
+	}
+}
+
+func TestCandleBarDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"1s":    time.Second,
+		"15m":   15 * time.Minute,
+		"4H":    4 * time.Hour,
+		"1Dutc": 24 * time.Hour,
+		"1W":    7 * 24 * time.Hour,
+	}
+	for bar, want := range cases {
+		if got, err := candleBarDuration(bar); err != nil || got != want {
+			t.Fatalf("%s: got %s err %v", bar, got, err)
+		}
+	}
+	for _, bar := range []string{"", "1M", "m", "0m", "xH"} {
+		if _, err := candleBarDuration(bar); err == nil {
+			t.Fatalf("%q: expected error", bar)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package okx
+
+import "fmt"
+
+// Capability 表示一类接口所需的权限（用于在本地限制 Client 可调用的接口范围）。
+//
+// 每个 REST Service 与 WS 交易 op 在构造请求处标注所需权限；GET 接口均为 CapabilityRead。
+type Capability string
+
+const (
+	// CapabilityRead 为只读接口（行情/查询，含只读性质的 POST，如 order-precheck、position-builder）。
+	CapabilityRead Capability = "read"
+	// CapabilityTrade 为交易类接口（下单/撤单/改单、策略委托、价差/RFQ/跟单/策略交易、账户交易配置等）。
+	CapabilityTrade Capability = "trade"
+	// CapabilityTransfer 为资金划转（含母子账户划转）。
+	CapabilityTransfer Capability = "transfer"
+	// CapabilityWithdraw 为提币/撤销提币。
+	CapabilityWithdraw Capability = "withdraw"
+	// CapabilityFinance 为金融/借贷类接口（赚币、借币还币、一键还债、自动借币/还币、质押借币、设置抵押资产等）。
+	CapabilityFinance Capability = "finance"
+	// CapabilitySubAccountAdmin 为子账户管理（创建子账户、创建/修改/删除子账户 APIKey、设置转出权限）。
+	CapabilitySubAccountAdmin Capability = "subaccount_admin"
+)
+
+// CapabilityError 表示 Client 未被授权调用某接口（WithAllowedCapabilities），请求未发送。
+type CapabilityError struct {
+	Capability Capability
+	// Method 为 REST method（WS 为 "WS"）。
+	Method string
+	// RequestPath 为 REST endpoint（WS 为 op）。
+	RequestPath string
+}
+
+func (e *CapabilityError) Error() string {
+	if e == nil {
+		return "<OKX CapabilityError>"
+	}
+	return fmt.Sprintf("okx: capability %q not allowed: %s %s", e.Capability, e.Method, e.RequestPath)
+}
+
+// WithAllowedCapabilities 限制 Client（及由其创建的 WSClient）可调用的接口范围；未授权的调用在本地返回 *CapabilityError。
+//
+// 未设置时不做限制；设置为空表示禁止所有接口。
+func WithAllowedCapabilities(caps ...Capability) Option {
+	allowed := make(map[Capability]struct{}, len(caps))
+	for _, cp := range caps {
+		allowed[cp] = struct{}{}
+	}
+	return func(c *Client) {
+		c.capabilities = allowed
+	}
+}
+
+func (c *Client) checkCapability(cp Capability, method, requestPath string) error {
+	if c == nil || c.capabilities == nil {
+		return nil
+	}
+	if _, ok := c.capabilities[cp]; ok {
+		return nil
+	}
+	return &CapabilityError{Capability: cp, Method: method, RequestPath: requestPath}
+}
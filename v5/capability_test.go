@@ -0,0 +1,135 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func requireCapabilityError(t *testing.T, err error, cp Capability) {
+	t.Helper()
+	var capErr *CapabilityError
+	if !errors.As(err, &capErr) {
+		t.Fatalf("error = %T %v, want *CapabilityError", err, err)
+	}
+	if capErr.Capability != cp {
+		t.Fatalf("Capability = %q, want %q", capErr.Capability, cp)
+	}
+}
+
+// postEndpointCapabilities 扫描包内各 Service 源文件，返回 POST endpoint 及其在构造请求处标注的权限。
+func postEndpointCapabilities(t *testing.T) map[string]Capability {
+	t.Helper()
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	names := map[string]Capability{
+		"CapabilityRead":            CapabilityRead,
+		"CapabilityTrade":           CapabilityTrade,
+		"CapabilityTransfer":        CapabilityTransfer,
+		"CapabilityWithdraw":        CapabilityWithdraw,
+		"CapabilityFinance":         CapabilityFinance,
+		"CapabilitySubAccountAdmin": CapabilitySubAccountAdmin,
+	}
+	re := regexp.MustCompile(`\.do\w*\(ctx, (Capability\w+), http\.MethodPost, "(/api/v5/[^"?]+)"`)
+	out := make(map[string]Capability)
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", f, err)
+		}
+		for _, m := range re.FindAllStringSubmatch(string(b), -1) {
+			cp, ok := names[m[1]]
+			if !ok {
+				t.Fatalf("%s: unknown capability %s for %s", f, m[1], m[2])
+			}
+			if prev, ok := out[m[2]]; ok && prev != cp {
+				t.Fatalf("%s tagged %q and %q", m[2], prev, cp)
+			}
+			out[m[2]] = cp
+		}
+	}
+	return out
+}
+
+func TestServiceCapabilities(t *testing.T) {
+	caps := postEndpointCapabilities(t)
+	want := map[string]Capability{
+		"/api/v5/trade/order-precheck":               CapabilityRead,
+		"/api/v5/account/position-builder":           CapabilityRead,
+		"/api/v5/trade/order":                        CapabilityTrade,
+		"/api/v5/account/set-leverage":               CapabilityTrade,
+		"/api/v5/tradingBot/grid/order-algo":         CapabilityTrade,
+		"/api/v5/asset/transfer":                     CapabilityTransfer,
+		"/api/v5/asset/subaccount/transfer":          CapabilityTransfer,
+		"/api/v5/asset/withdrawal":                   CapabilityWithdraw,
+		"/api/v5/finance/savings/purchase-redempt":   CapabilityFinance,
+		"/api/v5/account/spot-manual-borrow-repay":   CapabilityFinance,
+		"/api/v5/account/set-collateral-assets":      CapabilityFinance,
+		"/api/v5/trade/one-click-repay":              CapabilityFinance,
+		"/api/v5/users/subaccount/apikey":            CapabilitySubAccountAdmin,
+		"/api/v5/users/subaccount/modify-apikey":     CapabilitySubAccountAdmin,
+		"/api/v5/users/subaccount/create-subaccount": CapabilitySubAccountAdmin,
+	}
+	for p, cp := range want {
+		if got, ok := caps[p]; !ok || got != cp {
+			t.Fatalf("capability(%s) = %q (found=%v), want %q", p, got, ok, cp)
+		}
+	}
+	if got := (*CapabilityError)(nil).Error(); got != "<OKX CapabilityError>" {
+		t.Fatalf("nil Error() = %q", got)
+	}
+}
+
+func TestWithAllowedCapabilities(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instType":"SPOT","instId":"BTC-USDT","last":"1","ts":"1"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(
+		WithBaseURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+		WithCredentials(Credentials{APIKey: "k", SecretKey: "s", Passphrase: "p"}),
+		WithRequestGateDisabled(),
+		WithAllowedCapabilities(CapabilityRead),
+	)
+	ctx := context.Background()
+
+	if _, err := c.NewMarketTickerService().InstId("BTC-USDT").Do(ctx); err != nil {
+		t.Fatalf("Ticker error = %v", err)
+	}
+
+	_, err := c.NewAssetWithdrawalService().Ccy("USDT").Amt("1").Dest("4").ToAddr("addr").Chain("USDT-TRC20").Do(ctx)
+	requireCapabilityError(t, err, CapabilityWithdraw)
+	_, err = c.NewAssetTransferService().Ccy("USDT").Amt("1").From("6").To("18").Do(ctx)
+	requireCapabilityError(t, err, CapabilityTransfer)
+	_, err = c.NewUsersSubaccountCreateAPIKeyService().SubAcct("sub1").Label("l").Passphrase("Passphrase1!").Perm("read_only").Do(ctx)
+	requireCapabilityError(t, err, CapabilitySubAccountAdmin)
+	_, err = c.NewPlaceOrderService().InstId("BTC-USDT").TdMode("cash").Side("buy").OrdType("market").Sz("1").Do(ctx)
+	requireCapabilityError(t, err, CapabilityTrade)
+	_, err = c.NewWSPrivate().PlaceOrder(ctx, WSPlaceOrderArg{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "market", Sz: "1"})
+	requireCapabilityError(t, err, CapabilityTrade)
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("http calls = %d, want 1", got)
+	}
+	if st := c.ClientStats(); st.ErrorCodeCounts["CAPABILITY_FORBIDDEN"] != 4 {
+		t.Fatalf("ErrorCodeCounts = %#v", st.ErrorCodeCounts)
+	}
+}
@@ -87,12 +87,21 @@ type Client struct {
 
 	errHandler ClientErrorHandler
 
+	risk *preTradeRiskGuard
+
+	dryRun *dryRun
+
+	capabilities map[Capability]struct{}
+
 	statsRequestTotal atomic.Uint64
 	statsSuccessTotal atomic.Uint64
 	statsFailureTotal atomic.Uint64
 	statsRetryTotal   atomic.Uint64
+	statsRiskReject   atomic.Uint64
+	statsDryRunTotal  atomic.Uint64
 	statsErrorCodeMu  sync.Mutex
 	statsErrorCodes   map[string]uint64
+	statsRiskRules    map[string]uint64
 
 	tradeAccountRateLimitMu          sync.Mutex
 	tradeAccountRateLimitPrimed      atomic.Bool
@@ -228,11 +237,12 @@ type responseEnvelope struct {
 	Data json.RawMessage `json:"data"`
 }
 
-func (c *Client) do(ctx context.Context, method, endpoint string, query url.Values, body any, signed bool, out any) error {
-	return c.doWithHeaders(ctx, method, endpoint, query, body, signed, nil, out)
+// do 发送 REST 请求；cp 为该接口所需的权限，由各 Service 在构造请求处标注（见 WithAllowedCapabilities）。
+func (c *Client) do(ctx context.Context, cp Capability, method, endpoint string, query url.Values, body any, signed bool, out any) error {
+	return c.doWithHeaders(ctx, cp, method, endpoint, query, body, signed, nil, out)
 }
 
-func (c *Client) doWithHeaders(ctx context.Context, method, endpoint string, query url.Values, body any, signed bool, extraHeader http.Header, out any) error {
+func (c *Client) doWithHeaders(ctx context.Context, cp Capability, method, endpoint string, query url.Values, body any, signed bool, extraHeader http.Header, out any) error {
 	c.recordClientRequest()
 	fail := func(err error) error {
 		c.recordClientFailure(err)
@@ -240,6 +250,9 @@ func (c *Client) doWithHeaders(ctx context.Context, method, endpoint string, que
 	}
 
 	requestPath := rest.BuildRequestPath(endpoint, query)
+	if err := c.checkCapability(cp, method, requestPath); err != nil {
+		return fail(err)
+	}
 
 	var bodyBytes []byte
 	var bodyString string
@@ -270,6 +283,20 @@ func (c *Client) doWithHeaders(ctx context.Context, method, endpoint string, que
 			}
 		}
 
+		if resp, respHeader, ok, err := c.dryRunREST(ctx, cp, method, endpoint, bodyBytes, signed); ok {
+			if attemptCancel != nil {
+				attemptCancel()
+			}
+			if err == nil {
+				err = decodeEnvelope(http.StatusOK, resp, respHeader, method, requestPath, out)
+			}
+			if err != nil {
+				return fail(err)
+			}
+			c.recordClientSuccess()
+			return nil
+		}
+
 		if signed && isTradeAccountRateLimitedREST(method, endpoint) {
 			if err := c.ensureTradeAccountRateLimit(attemptCtx); err != nil {
 				if attemptCancel != nil {
@@ -367,7 +394,7 @@ func (c *Client) doWithHeaders(ctx context.Context, method, endpoint string, que
 	}
 }
 
-func (c *Client) doWithHeadersAndRequestID(ctx context.Context, method, endpoint string, query url.Values, body any, signed bool, extraHeader http.Header, out any) (requestID string, err error) {
+func (c *Client) doWithHeadersAndRequestID(ctx context.Context, cp Capability, method, endpoint string, query url.Values, body any, signed bool, extraHeader http.Header, out any) (requestID string, err error) {
 	c.recordClientRequest()
 	fail := func(err error) (string, error) {
 		c.recordClientFailure(err)
@@ -375,6 +402,9 @@ func (c *Client) doWithHeadersAndRequestID(ctx context.Context, method, endpoint
 	}
 
 	requestPath := rest.BuildRequestPath(endpoint, query)
+	if err := c.checkCapability(cp, method, requestPath); err != nil {
+		return fail(err)
+	}
 
 	var bodyBytes []byte
 	var bodyString string
@@ -405,6 +435,23 @@ func (c *Client) doWithHeadersAndRequestID(ctx context.Context, method, endpoint
 			}
 		}
 
+		if resp, respHeader, ok, err := c.dryRunREST(ctx, cp, method, endpoint, bodyBytes, signed); ok {
+			if attemptCancel != nil {
+				attemptCancel()
+			}
+			if respHeader != nil {
+				requestID = respHeader.Get("x-request-id")
+			}
+			if err == nil {
+				err = decodeEnvelope(http.StatusOK, resp, respHeader, method, requestPath, out)
+			}
+			if err != nil {
+				return fail(err)
+			}
+			c.recordClientSuccess()
+			return requestID, nil
+		}
+
 		if signed && isTradeAccountRateLimitedREST(method, endpoint) {
 			if err := c.ensureTradeAccountRateLimit(attemptCtx); err != nil {
 				if attemptCancel != nil {
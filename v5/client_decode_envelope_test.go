@@ -39,7 +39,7 @@ func TestClientDo_OutNil_MissingEnvelopeFailClose(t *testing.T) {
 				WithHTTPClient(srv.Client()),
 			)
 
-			err := c.do(context.Background(), http.MethodPost, "/api/v5/test", nil, map[string]string{"k": "v"}, false, nil)
+			err := c.do(context.Background(), CapabilityRead, http.MethodPost, "/api/v5/test", nil, map[string]string{"k": "v"}, false, nil)
 			if err == nil {
 				t.Fatalf("expected error")
 			}
@@ -82,7 +82,7 @@ func TestClientDo_NonEnvelope_OutStruct_Compatible(t *testing.T) {
 	var out struct {
 		Status string `json:"status"`
 	}
-	err := c.do(context.Background(), http.MethodGet, "/api/v5/test", nil, nil, false, &out)
+	err := c.do(context.Background(), CapabilityRead, http.MethodGet, "/api/v5/test", nil, nil, false, &out)
 	if err != nil {
 		t.Fatalf("do() error = %v", err)
 	}
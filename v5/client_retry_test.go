@@ -124,7 +124,7 @@ func TestClientRetry_DoesNotRetry_NonGET(t *testing.T) {
 		WithRetry(RetryConfig{MaxRetries: 1}),
 	)
 
-	err := c.doWithHeaders(context.Background(), http.MethodPost, "/api/v5/test", nil, map[string]string{"k": "v"}, false, nil, nil)
+	err := c.doWithHeaders(context.Background(), CapabilityRead, http.MethodPost, "/api/v5/test", nil, map[string]string{"k": "v"}, false, nil, nil)
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -18,6 +18,14 @@ type ClientStats struct {
 	// - HTTP 错误：HTTP_XXX（如 HTTP_500）
 	// - 其他错误：REQUEST_HTTP / CONTEXT_CANCELED / UNKNOWN 等
 	ErrorCodeCounts map[string]uint64
+
+	// RiskRejectTotal 为预交易风控（WithPreTradeRisk）拒单次数（含 WS 交易 op；不计入 RequestTotal）。
+	RiskRejectTotal uint64
+	// RiskRejectCounts 聚合风控拒单的规则分布（key 为 RiskRule）。
+	RiskRejectCounts map[string]uint64
+
+	// DryRunTotal 为 dry-run（WithDryRun）拦截并返回合成响应的次数（含 WS 交易 op）。
+	DryRunTotal uint64
 }
 
 // ClientStats 返回 Client 的 REST 运行统计快照（并发安全）。
@@ -31,6 +39,8 @@ func (c *Client) ClientStats() ClientStats {
 	s.SuccessTotal = c.statsSuccessTotal.Load()
 	s.FailureTotal = c.statsFailureTotal.Load()
 	s.RetryTotal = c.statsRetryTotal.Load()
+	s.RiskRejectTotal = c.statsRiskReject.Load()
+	s.DryRunTotal = c.statsDryRunTotal.Load()
 
 	c.statsErrorCodeMu.Lock()
 	if len(c.statsErrorCodes) > 0 {
@@ -39,6 +49,12 @@ func (c *Client) ClientStats() ClientStats {
 			s.ErrorCodeCounts[code] = n
 		}
 	}
+	if len(c.statsRiskRules) > 0 {
+		s.RiskRejectCounts = make(map[string]uint64, len(c.statsRiskRules))
+		for rule, n := range c.statsRiskRules {
+			s.RiskRejectCounts[rule] = n
+		}
+	}
 	c.statsErrorCodeMu.Unlock()
 
 	return s
@@ -84,6 +100,20 @@ func (c *Client) recordClientFailure(err error) {
 	c.statsErrorCodeMu.Unlock()
 }
 
+func (c *Client) recordRiskReject(err *RiskRejectError) {
+	if c == nil || err == nil {
+		return
+	}
+	c.statsRiskReject.Add(1)
+
+	c.statsErrorCodeMu.Lock()
+	if c.statsRiskRules == nil {
+		c.statsRiskRules = make(map[string]uint64)
+	}
+	c.statsRiskRules[string(err.Rule)]++
+	c.statsErrorCodeMu.Unlock()
+}
+
 func classifyClientErrorCode(err error) string {
 	if err == nil {
 		return ""
@@ -91,6 +121,10 @@ func classifyClientErrorCode(err error) string {
 	if errors.Is(err, errMissingCredentials) {
 		return "MISSING_CREDENTIALS"
 	}
+	var capErr *CapabilityError
+	if errors.As(err, &capErr) {
+		return "CAPABILITY_FORBIDDEN"
+	}
 
 	var apiErr *APIError
 	if errors.As(err, &apiErr) {
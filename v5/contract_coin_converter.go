@@ -0,0 +1,270 @@
+package okx
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// contractCoinConvertScale 是张转币结果（coin/usds）的最大小数位数（四舍五入后去除末尾 0）。
+const contractCoinConvertScale = 8
+
+// ContractCoinConverter 基于产品信息（ctVal/ctValCcy/ctMult/lotSz）在本地完成张/币换算。
+//
+// 语义对齐 GET /api/v5/public/convert-contract-coin：
+// - type=1 币转张：结果按 lotSz 取整；opType=open 向下取整，opType=close（默认）四舍五入；
+// - type=2 张转币：结果最多保留 8 位小数；
+// - unit=usds 仅适用于 U 本位（linear）交割/永续，此时 sz（或结果）表示 USD(T) 计价的名义价值。
+//
+// 说明：纯本地计算，不消耗 REST 限速；调用方需自行保证 Instrument 为最新（例如监听 instruments 推送）。
+type ContractCoinConverter struct {
+	instType string
+	instId   string
+
+	// ctVal 为单张合约面值（已乘 ctMult）。
+	ctVal   *big.Rat
+	inverse bool
+
+	lotSz    *big.Rat
+	lotScale int
+}
+
+// ContractCoinConvertRequest 表示一次本地张/币换算请求（字段含义与 PublicConvertContractCoinService 一致）。
+type ContractCoinConvertRequest struct {
+	// Type 为转换类型：1=币转张，2=张转币；默认 1。
+	Type string
+	// Sz 为数量：币转张时为币（或 usds）数量，张转币时为张数。
+	Sz string
+	// Px 为委托价格；币本位合约或 unit=usds 时必填。
+	Px string
+	// Unit 为币的单位：coin/usds；默认 coin。
+	Unit string
+	// OpType 为下单类型：open/close；默认 close（仅影响币转张的取整方式）。
+	OpType string
+}
+
+var (
+	errContractCoinConverterInvalidInstType = errors.New("okx: contract coin converter requires SWAP/FUTURES/OPTION instrument")
+	errContractCoinConverterMissingCtVal    = errors.New("okx: contract coin converter requires ctVal")
+	errContractCoinConvertMissingSz         = errors.New("okx: contract coin convert requires sz")
+	errContractCoinConvertMissingPx         = errors.New("okx: contract coin convert requires px")
+)
+
+// NewContractCoinConverter 基于 Instrument 创建本地张/币换算器。
+func NewContractCoinConverter(inst Instrument) (*ContractCoinConverter, error) {
+	switch inst.InstType {
+	case "SWAP", "FUTURES", "OPTION":
+	default:
+		return nil, errContractCoinConverterInvalidInstType
+	}
+	if inst.CtVal == "" {
+		return nil, errContractCoinConverterMissingCtVal
+	}
+
+	ctVal, err := parseDecimal(inst.CtVal)
+	if err != nil {
+		return nil, fmt.Errorf("okx: contract coin converter invalid ctVal: %w", err)
+	}
+	if ctVal.Sign() <= 0 {
+		return nil, fmt.Errorf("okx: contract coin converter invalid ctVal %q", inst.CtVal)
+	}
+	if inst.CtMult != "" {
+		mult, err := parseDecimal(inst.CtMult)
+		if err != nil {
+			return nil, fmt.Errorf("okx: contract coin converter invalid ctMult: %w", err)
+		}
+		if mult.Sign() <= 0 {
+			return nil, fmt.Errorf("okx: contract coin converter invalid ctMult %q", inst.CtMult)
+		}
+		ctVal.Mul(ctVal, mult)
+	}
+
+	cv := &ContractCoinConverter{
+		instType: inst.InstType,
+		instId:   inst.InstId,
+		ctVal:    ctVal,
+		inverse:  isInverseContract(inst),
+	}
+	if inst.LotSz != "" {
+		lotSz, err := parseDecimal(inst.LotSz)
+		if err != nil {
+			return nil, fmt.Errorf("okx: contract coin converter invalid lotSz: %w", err)
+		}
+		if lotSz.Sign() > 0 {
+			cv.lotSz = lotSz
+			cv.lotScale = decimalScale(inst.LotSz)
+		}
+	}
+	return cv, nil
+}
+
+// isInverseContract 判断是否为币本位（inverse）合约：优先使用 ctType，缺失时按 ctValCcy 是否为标的币推断。
+func isInverseContract(inst Instrument) bool {
+	switch inst.CtType {
+	case "inverse":
+		return true
+	case "linear":
+		return false
+	}
+	if inst.InstType == "OPTION" {
+		return false
+	}
+	family := inst.InstFamily
+	if family == "" {
+		family = inst.Uly
+	}
+	base := family
+	if i := strings.IndexByte(family, '-'); i >= 0 {
+		base = family[:i]
+	}
+	return base != "" && inst.CtValCcy != "" && inst.CtValCcy != base
+}
+
+// InstId 返回换算器对应的产品 ID。
+func (cv *ContractCoinConverter) InstId() string {
+	if cv == nil {
+		return ""
+	}
+	return cv.instId
+}
+
+// Inverse 表示是否为币本位（inverse）合约。
+func (cv *ContractCoinConverter) Inverse() bool {
+	return cv != nil && cv.inverse
+}
+
+// Convert 执行一次本地张/币换算，返回结构与 PublicConvertContractCoinService 一致。
+func (cv *ContractCoinConverter) Convert(req ContractCoinConvertRequest) (ConvertContractCoin, error) {
+	if cv == nil {
+		return ConvertContractCoin{}, errors.New("okx: nil contract coin converter")
+	}
+
+	convertType := req.Type
+	if convertType == "" {
+		convertType = "1"
+	}
+	unit := req.Unit
+	if unit == "" {
+		unit = "coin"
+	}
+
+	var (
+		sz  string
+		err error
+	)
+	switch convertType {
+	case "1":
+		sz, err = cv.CoinToContract(req.Sz, req.Px, unit, req.OpType)
+	case "2":
+		sz, err = cv.ContractToCoin(req.Sz, req.Px, unit)
+	default:
+		return ConvertContractCoin{}, fmt.Errorf("okx: contract coin convert invalid type %q", req.Type)
+	}
+	if err != nil {
+		return ConvertContractCoin{}, err
+	}
+	return ConvertContractCoin{
+		Type:   convertType,
+		InstId: cv.instId,
+		Px:     req.Px,
+		Sz:     sz,
+		Unit:   unit,
+	}, nil
+}
+
+// CoinToContract 币转张：sz 为币（unit=coin）或 USD(T)（unit=usds）数量，返回按 lotSz 取整后的张数。
+func (cv *ContractCoinConverter) CoinToContract(sz, px, unit, opType string) (string, error) {
+	if cv == nil {
+		return "", errors.New("okx: nil contract coin converter")
+	}
+	amount, price, err := cv.parseConvertInput(sz, px, unit)
+	if err != nil {
+		return "", err
+	}
+
+	// 单张合约对应的“输入单位”价值。
+	perContract := new(big.Rat).Set(cv.ctVal)
+	switch {
+	case cv.inverse:
+		// 币本位：ctVal 以计价币（如 USD）表示，1 张 = ctVal / px 个币。
+		perContract.Quo(perContract, price)
+	case unit == "usds":
+		perContract.Mul(perContract, price)
+	}
+
+	mode := decimalRoundHalfUp
+	switch opType {
+	case "", "close":
+	case "open":
+		mode = decimalRoundDown
+	default:
+		return "", fmt.Errorf("okx: contract coin convert invalid opType %q", opType)
+	}
+
+	contracts := new(big.Rat).Quo(amount, perContract)
+	if cv.lotSz == nil {
+		return formatDecimal(contracts, contractCoinConvertScale), nil
+	}
+	rounded := roundDecimalToStep(contracts, cv.lotSz, mode)
+	return formatDecimal(rounded, cv.lotScale), nil
+}
+
+// ContractToCoin 张转币：sz 为张数，返回币（unit=coin）或 USD(T)（unit=usds）数量。
+func (cv *ContractCoinConverter) ContractToCoin(sz, px, unit string) (string, error) {
+	if cv == nil {
+		return "", errors.New("okx: nil contract coin converter")
+	}
+	contracts, price, err := cv.parseConvertInput(sz, px, unit)
+	if err != nil {
+		return "", err
+	}
+
+	out := new(big.Rat).Mul(contracts, cv.ctVal)
+	switch {
+	case cv.inverse:
+		out.Quo(out, price)
+	case unit == "usds":
+		out.Mul(out, price)
+	}
+	return formatDecimal(out, contractCoinConvertScale), nil
+}
+
+func (cv *ContractCoinConverter) parseConvertInput(sz, px, unit string) (amount, price *big.Rat, err error) {
+	if sz == "" {
+		return nil, nil, errContractCoinConvertMissingSz
+	}
+	amount, err = parseDecimal(sz)
+	if err != nil {
+		return nil, nil, fmt.Errorf("okx: contract coin convert invalid sz: %w", err)
+	}
+	if amount.Sign() < 0 {
+		return nil, nil, fmt.Errorf("okx: contract coin convert invalid sz %q", sz)
+	}
+
+	switch unit {
+	case "", "coin":
+		unit = "coin"
+	case "usds":
+		if cv.inverse || cv.instType == "OPTION" {
+			return nil, nil, fmt.Errorf("okx: contract coin convert unit=usds only applies to linear SWAP/FUTURES, instId=%s", cv.instId)
+		}
+	default:
+		return nil, nil, fmt.Errorf("okx: contract coin convert invalid unit %q", unit)
+	}
+
+	if !cv.inverse && unit == "coin" {
+		return amount, nil, nil
+	}
+	if px == "" {
+		return nil, nil, errContractCoinConvertMissingPx
+	}
+	price, err = parseDecimal(px)
+	if err != nil {
+		return nil, nil, fmt.Errorf("okx: contract coin convert invalid px: %w", err)
+	}
+	if price.Sign() <= 0 {
+		return nil, nil, fmt.Errorf("okx: contract coin convert invalid px %q", px)
+	}
+	return amount, price, nil
+}
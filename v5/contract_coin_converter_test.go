@@ -0,0 +1,147 @@
+package okx
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestContractCoinConverter_MatchesRecordedResponses(t *testing.T) {
+	btcUSDSwap := Instrument{InstType: "SWAP", InstId: "BTC-USD-SWAP", InstFamily: "BTC-USD", Uly: "BTC-USD", SettleCcy: "BTC", CtVal: "100", CtMult: "1", CtValCcy: "USD", CtType: "inverse", LotSz: "1"}
+	btcUSDTSwap := Instrument{InstType: "SWAP", InstId: "BTC-USDT-SWAP", InstFamily: "BTC-USDT", Uly: "BTC-USDT", SettleCcy: "USDT", CtVal: "0.01", CtMult: "1", CtValCcy: "BTC", CtType: "linear", LotSz: "0.01"}
+	btcOption := Instrument{InstType: "OPTION", InstId: "BTC-USD-240628-50000-C", InstFamily: "BTC-USD", Uly: "BTC-USD", SettleCcy: "BTC", CtVal: "0.01", CtMult: "1", CtValCcy: "BTC", LotSz: "1"}
+
+	cases := []struct {
+		name     string
+		inst     Instrument
+		req      ContractCoinConvertRequest
+		recorded string
+	}{
+		{
+			name:     "inverse_coin_to_contract_close",
+			inst:     btcUSDSwap,
+			req:      ContractCoinConvertRequest{Sz: "0.888", Px: "35000"},
+			recorded: `{"code":"0","msg":"","data":[{"instId":"BTC-USD-SWAP","px":"35000","sz":"311","type":"1","unit":"coin"}]}`,
+		},
+		{
+			name:     "inverse_coin_to_contract_open",
+			inst:     btcUSDSwap,
+			req:      ContractCoinConvertRequest{Sz: "0.888", Px: "35000", OpType: "open"},
+			recorded: `{"code":"0","msg":"","data":[{"instId":"BTC-USD-SWAP","px":"35000","sz":"310","type":"1","unit":"coin"}]}`,
+		},
+		{
+			name:     "inverse_contract_to_coin",
+			inst:     btcUSDSwap,
+			req:      ContractCoinConvertRequest{Type: "2", Sz: "311", Px: "35000"},
+			recorded: `{"code":"0","msg":"","data":[{"instId":"BTC-USD-SWAP","px":"35000","sz":"0.88857143","type":"2","unit":"coin"}]}`,
+		},
+		{
+			name:     "linear_coin_to_contract",
+			inst:     btcUSDTSwap,
+			req:      ContractCoinConvertRequest{Sz: "0.888"},
+			recorded: `{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","px":"","sz":"88.8","type":"1","unit":"coin"}]}`,
+		},
+		{
+			name:     "linear_usds_to_contract_close",
+			inst:     btcUSDTSwap,
+			req:      ContractCoinConvertRequest{Sz: "1000", Px: "35000", Unit: "usds"},
+			recorded: `{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","px":"35000","sz":"2.86","type":"1","unit":"usds"}]}`,
+		},
+		{
+			name:     "linear_usds_to_contract_open",
+			inst:     btcUSDTSwap,
+			req:      ContractCoinConvertRequest{Sz: "1000", Px: "35000", Unit: "usds", OpType: "open"},
+			recorded: `{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","px":"35000","sz":"2.85","type":"1","unit":"usds"}]}`,
+		},
+		{
+			name:     "linear_contract_to_coin",
+			inst:     btcUSDTSwap,
+			req:      ContractCoinConvertRequest{Type: "2", Sz: "88.8"},
+			recorded: `{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","px":"","sz":"0.888","type":"2","unit":"coin"}]}`,
+		},
+		{
+			name:     "linear_contract_to_usds",
+			inst:     btcUSDTSwap,
+			req:      ContractCoinConvertRequest{Type: "2", Sz: "88.8", Px: "35000", Unit: "usds"},
+			recorded: `{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","px":"35000","sz":"31080","type":"2","unit":"usds"}]}`,
+		},
+		{
+			name:     "option_coin_to_contract",
+			inst:     btcOption,
+			req:      ContractCoinConvertRequest{Sz: "0.5"},
+			recorded: `{"code":"0","msg":"","data":[{"instId":"BTC-USD-240628-50000-C","px":"","sz":"50","type":"1","unit":"coin"}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var env struct {
+				Data []ConvertContractCoin `json:"data"`
+			}
+			if err := json.Unmarshal([]byte(tc.recorded), &env); err != nil || len(env.Data) != 1 {
+				t.Fatalf("recorded unmarshal: %v", err)
+			}
+			want := env.Data[0]
+
+			cv, err := NewContractCoinConverter(tc.inst)
+			if err != nil {
+				t.Fatalf("NewContractCoinConverter() error = %v", err)
+			}
+			got, err := cv.Convert(tc.req)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if got != want {
+				t.Fatalf("Convert() = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestContractCoinConverter_InferInverseWithoutCtType(t *testing.T) {
+	cv, err := NewContractCoinConverter(Instrument{InstType: "FUTURES", InstId: "BTC-USD-240628", InstFamily: "BTC-USD", CtVal: "100", CtValCcy: "USD", LotSz: "1"})
+	if err != nil {
+		t.Fatalf("NewContractCoinConverter() error = %v", err)
+	}
+	if !cv.Inverse() {
+		t.Fatalf("expected inverse")
+	}
+
+	cv, err = NewContractCoinConverter(Instrument{InstType: "SWAP", InstId: "ETH-USDT-SWAP", InstFamily: "ETH-USDT", CtVal: "0.1", CtMult: "10", CtValCcy: "ETH", LotSz: "1"})
+	if err != nil {
+		t.Fatalf("NewContractCoinConverter() error = %v", err)
+	}
+	if cv.Inverse() {
+		t.Fatalf("expected linear")
+	}
+	got, err := cv.CoinToContract("3", "", "coin", "")
+	if err != nil || got != "3" {
+		t.Fatalf("CoinToContract() = %q, %v; want 3 (ctVal*ctMult=1)", got, err)
+	}
+}
+
+func TestContractCoinConverter_Errors(t *testing.T) {
+	if _, err := NewContractCoinConverter(Instrument{InstType: "SPOT", InstId: "BTC-USDT"}); !errors.Is(err, errContractCoinConverterInvalidInstType) {
+		t.Fatalf("error = %v, want %v", err, errContractCoinConverterInvalidInstType)
+	}
+	if _, err := NewContractCoinConverter(Instrument{InstType: "SWAP", InstId: "BTC-USD-SWAP"}); !errors.Is(err, errContractCoinConverterMissingCtVal) {
+		t.Fatalf("error = %v, want %v", err, errContractCoinConverterMissingCtVal)
+	}
+
+	cv, err := NewContractCoinConverter(Instrument{InstType: "SWAP", InstId: "BTC-USD-SWAP", CtVal: "100", CtValCcy: "USD", CtType: "inverse", LotSz: "1"})
+	if err != nil {
+		t.Fatalf("NewContractCoinConverter() error = %v", err)
+	}
+	if _, err := cv.Convert(ContractCoinConvertRequest{Sz: "1"}); !errors.Is(err, errContractCoinConvertMissingPx) {
+		t.Fatalf("error = %v, want %v", err, errContractCoinConvertMissingPx)
+	}
+	if _, err := cv.Convert(ContractCoinConvertRequest{Px: "1"}); !errors.Is(err, errContractCoinConvertMissingSz) {
+		t.Fatalf("error = %v, want %v", err, errContractCoinConvertMissingSz)
+	}
+	if _, err := cv.Convert(ContractCoinConvertRequest{Sz: "1", Px: "1", Unit: "usds"}); err == nil {
+		t.Fatalf("expected error for unit=usds on inverse contract")
+	}
+	if _, err := cv.Convert(ContractCoinConvertRequest{Type: "3", Sz: "1", Px: "1"}); err == nil {
+		t.Fatalf("expected error for invalid type")
+	}
+}
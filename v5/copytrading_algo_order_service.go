@@ -129,7 +129,7 @@ func (s *CopyTradingAlgoOrderService) Do(ctx context.Context) (*CopyTradingSubPo
 	}
 
 	var data []CopyTradingSubPositionAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/copytrading/algo-order", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/copytrading/algo-order", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +53,7 @@ func (s *CopyTradingAmendProfitSharingRatioService) Do(ctx context.Context) (*Co
 	}
 
 	var data []CopyTradingResult
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/copytrading/amend-profit-sharing-ratio", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/copytrading/amend-profit-sharing-ratio", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +80,7 @@ func (s *CopyTradingCloseSubpositionService) Do(ctx context.Context) (*CopyTradi
 	}
 
 	var data []CopyTradingSubPositionAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/copytrading/close-subposition", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/copytrading/close-subposition", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -21,7 +21,7 @@ var errEmptyCopyTradingConfigResponse = errors.New("okx: empty copytrading confi
 // Do 获取账户配置信息（GET /api/v5/copytrading/config）。
 func (s *CopyTradingConfigService) Do(ctx context.Context) (*CopyTradingConfig, error) {
 	var data []CopyTradingConfig
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/config", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/config", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
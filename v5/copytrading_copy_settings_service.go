@@ -50,7 +50,7 @@ func (s *CopyTradingCopySettingsService) Do(ctx context.Context) (*CopyTradingCo
 	q.Set("uniqueCode", s.uniqueCode)
 
 	var data []CopyTradingCopySettings
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/copy-settings", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/copy-settings", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -33,7 +33,7 @@ func (s *CopyTradingCurrentLeadTradersService) Do(ctx context.Context) ([]CopyTr
 	}
 
 	var data []CopyTradingCurrentLeadTrader
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/current-lead-traders", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/current-lead-traders", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
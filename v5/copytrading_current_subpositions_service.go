@@ -49,7 +49,7 @@ func (s *CopyTradingCurrentSubpositionsService) Limit(limit int) *CopyTradingCur
 // Do 获取当前带单（GET /api/v5/copytrading/current-subpositions）。
 func (s *CopyTradingCurrentSubpositionsService) Do(ctx context.Context) ([]CopyTradingSubPosition, error) {
 	var data []CopyTradingSubPosition
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/current-subpositions", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/current-subpositions", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -33,7 +33,7 @@ func (s *CopyTradingInstrumentsService) Do(ctx context.Context) ([]CopyTradingIn
 	}
 
 	var data []CopyTradingInstrument
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/instruments", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/instruments", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -73,7 +73,7 @@ func (s *CopyTradingProfitSharingDetailsService) Limit(limit int) *CopyTradingPr
 // Do 获取历史分润明细（GET /api/v5/copytrading/profit-sharing-details）。
 func (s *CopyTradingProfitSharingDetailsService) Do(ctx context.Context) ([]CopyTradingProfitSharingDetail, error) {
 	var data []CopyTradingProfitSharingDetail
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/profit-sharing-details", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/profit-sharing-details", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -36,7 +36,7 @@ func (s *CopyTradingPublicConfigService) Do(ctx context.Context) (*CopyTradingPu
 	}
 
 	var data []CopyTradingPublicConfig
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/public-config", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/public-config", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
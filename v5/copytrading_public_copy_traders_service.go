@@ -58,7 +58,7 @@ func (s *CopyTradingPublicCopyTradersService) Do(ctx context.Context) (*CopyTrad
 	}
 
 	var data []CopyTradingPublicCopyTraders
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/public-copy-traders", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/public-copy-traders", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
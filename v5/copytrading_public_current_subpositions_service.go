@@ -51,7 +51,7 @@ func (s *CopyTradingPublicCurrentSubpositionsService) Do(ctx context.Context) ([
 	}
 
 	var data []CopyTradingSubPosition
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/public-current-subpositions", s.q.values(), nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/public-current-subpositions", s.q.values(), nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
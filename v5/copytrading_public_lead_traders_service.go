@@ -128,7 +128,7 @@ func (s *CopyTradingPublicLeadTradersService) Do(ctx context.Context) (*CopyTrad
 	}
 
 	var data []CopyTradingPublicLeadTraders
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/public-lead-traders", v, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/public-lead-traders", v, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -52,7 +52,7 @@ func (s *CopyTradingPublicPnlService) Do(ctx context.Context) ([]CopyTradingPnl,
 	q.Set("lastDays", s.lastDays)
 
 	var data []CopyTradingPnl
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/public-pnl", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/public-pnl", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
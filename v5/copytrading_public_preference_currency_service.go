@@ -45,7 +45,7 @@ func (s *CopyTradingPublicPreferenceCurrencyService) Do(ctx context.Context) ([]
 	q.Set("uniqueCode", s.uniqueCode)
 
 	var data []CopyTradingPreferenceCurrency
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/public-preference-currency", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/public-preference-currency", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -55,7 +55,7 @@ func (s *CopyTradingPublicStatsService) Do(ctx context.Context) (*CopyTradingPub
 	q.Set("lastDays", s.lastDays)
 
 	var data []CopyTradingPublicStats
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/public-stats", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/public-stats", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
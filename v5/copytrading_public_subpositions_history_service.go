@@ -51,7 +51,7 @@ func (s *CopyTradingPublicSubpositionsHistoryService) Do(ctx context.Context) ([
 	}
 
 	var data []CopyTradingSubPosition
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/public-subpositions-history", s.q.values(), nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/public-subpositions-history", s.q.values(), nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
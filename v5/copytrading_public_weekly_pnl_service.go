@@ -45,7 +45,7 @@ func (s *CopyTradingPublicWeeklyPnlService) Do(ctx context.Context) ([]CopyTradi
 	q.Set("uniqueCode", s.uniqueCode)
 
 	var data []CopyTradingPnl
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/public-weekly-pnl", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/public-weekly-pnl", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
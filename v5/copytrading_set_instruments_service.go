@@ -53,7 +53,7 @@ func (s *CopyTradingSetInstrumentsService) Do(ctx context.Context) ([]CopyTradin
 	}
 
 	var data []CopyTradingInstrument
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/copytrading/set-instruments", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/copytrading/set-instruments", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
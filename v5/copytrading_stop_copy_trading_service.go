@@ -62,7 +62,7 @@ func (s *CopyTradingStopCopyTradingService) Do(ctx context.Context) (*CopyTradin
 	}
 
 	var data []CopyTradingResult
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/copytrading/stop-copy-trading", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/copytrading/stop-copy-trading", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
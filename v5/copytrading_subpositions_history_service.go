@@ -49,7 +49,7 @@ func (s *CopyTradingSubpositionsHistoryService) Limit(limit int) *CopyTradingSub
 // Do 获取历史带单（GET /api/v5/copytrading/subpositions-history）。
 func (s *CopyTradingSubpositionsHistoryService) Do(ctx context.Context) ([]CopyTradingSubPosition, error) {
 	var data []CopyTradingSubPosition
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/subpositions-history", s.q.values(), nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/subpositions-history", s.q.values(), nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -33,7 +33,7 @@ func (s *CopyTradingTotalProfitSharingService) Do(ctx context.Context) ([]CopyTr
 	}
 
 	var data []CopyTradingTotalProfitSharing
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/total-profit-sharing", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/total-profit-sharing", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
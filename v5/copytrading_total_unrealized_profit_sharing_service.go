@@ -33,7 +33,7 @@ func (s *CopyTradingTotalUnrealizedProfitSharingService) Do(ctx context.Context)
 	}
 
 	var data []CopyTradingTotalUnrealizedProfitSharing
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/total-unrealized-profit-sharing", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/total-unrealized-profit-sharing", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
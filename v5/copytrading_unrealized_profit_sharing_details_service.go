@@ -33,7 +33,7 @@ func (s *CopyTradingUnrealizedProfitSharingDetailsService) Do(ctx context.Contex
 	}
 
 	var data []CopyTradingUnrealizedProfitSharingDetail
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/copytrading/unrealized-profit-sharing-details", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/copytrading/unrealized-profit-sharing-details", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
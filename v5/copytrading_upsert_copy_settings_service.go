@@ -196,7 +196,7 @@ func (s *CopyTradingUpsertCopySettingsService) Do(ctx context.Context) (*CopyTra
 	}
 
 	var data []CopyTradingResult
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, s.endpoint, nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, s.endpoint, nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
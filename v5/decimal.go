@@ -0,0 +1,109 @@
+package okx
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// parseDecimal 将 OKX 的十进制字符串（如 "0.01"、"35000"）解析为精确有理数。
+//
+// 说明：SDK 对外保持 string（无损），仅在需要本地计算时转换为 big.Rat，避免 float 精度问题。
+func parseDecimal(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("okx: empty decimal")
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("okx: invalid decimal %q", s)
+	}
+	return r, nil
+}
+
+// decimalRoundMode 表示十进制舍入方式。
+type decimalRoundMode uint8
+
+const (
+	decimalRoundHalfUp decimalRoundMode = iota
+	decimalRoundDown
+	decimalRoundUp
+)
+
+// roundDecimalToStep 将 v 舍入为 step 的整数倍（step<=0 时原样返回）。
+func roundDecimalToStep(v, step *big.Rat, mode decimalRoundMode) *big.Rat {
+	if v == nil {
+		return nil
+	}
+	if step == nil || step.Sign() <= 0 {
+		return new(big.Rat).Set(v)
+	}
+	n := roundRatToInt(new(big.Rat).Quo(v, step), mode)
+	return new(big.Rat).Mul(new(big.Rat).SetInt(n), step)
+}
+
+// roundDecimalToScale 将 v 舍入到小数点后 scale 位。
+func roundDecimalToScale(v *big.Rat, scale int, mode decimalRoundMode) *big.Rat {
+	if v == nil {
+		return nil
+	}
+	if scale < 0 {
+		scale = 0
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	step := new(big.Rat).SetFrac(big.NewInt(1), pow)
+	return roundDecimalToStep(v, step, mode)
+}
+
+func roundRatToInt(v *big.Rat, mode decimalRoundMode) *big.Int {
+	num := new(big.Int).Set(v.Num())
+	den := v.Denom()
+	neg := num.Sign() < 0
+	if neg {
+		num.Neg(num)
+	}
+
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() != 0 {
+		switch mode {
+		case decimalRoundUp:
+			q.Add(q, big.NewInt(1))
+		case decimalRoundHalfUp:
+			if new(big.Int).Lsh(r, 1).Cmp(den) >= 0 {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	}
+	if neg {
+		q.Neg(q)
+	}
+	return q
+}
+
+// formatDecimal 将有理数格式化为十进制字符串（最多 maxScale 位小数，去除末尾 0）。
+func formatDecimal(v *big.Rat, maxScale int) string {
+	if v == nil {
+		return ""
+	}
+	if maxScale < 0 {
+		maxScale = 0
+	}
+	s := roundDecimalToScale(v, maxScale, decimalRoundHalfUp).FloatString(maxScale)
+	if strings.IndexByte(s, '.') >= 0 {
+		s = strings.TrimRight(s, "0")
+		s = strings.TrimSuffix(s, ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
+
+// decimalScale 返回十进制字符串的小数位数（如 "0.010" -> 3）。
+func decimalScale(s string) int {
+	s = strings.TrimSpace(s)
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		return len(s) - dot - 1
+	}
+	return 0
+}
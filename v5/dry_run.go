@@ -0,0 +1,388 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DryRunCategory 表示 dry-run 拦截的接口类别。
+type DryRunCategory string
+
+const (
+	DryRunCategoryTrade      DryRunCategory = "trade"
+	DryRunCategoryTransfer   DryRunCategory = "transfer"
+	DryRunCategoryWithdrawal DryRunCategory = "withdrawal"
+	DryRunCategoryBorrow     DryRunCategory = "borrow"
+	DryRunCategoryBot        DryRunCategory = "bot"
+	// DryRunCategoryFinance 为赚币/质押/自动借还等金融类写接口（借还币见 DryRunCategoryBorrow）。
+	DryRunCategoryFinance DryRunCategory = "finance"
+	// DryRunCategorySubAccount 为子账户管理类写接口。
+	DryRunCategorySubAccount DryRunCategory = "subaccount"
+)
+
+// DryRunPolicy 控制 dry-run（影子模式）：交易/划转/提币/借币/策略创建类接口不发往 OKX，而是返回合成的成功响应。
+//
+// 约定：
+// - 默认拒绝：除 GET 与已知只读的 POST（如 order-precheck、position-builder）外，所有签名写请求均被拦截；
+// - 只读接口（行情/查询）照常请求 OKX；
+// - 参数校验、预交易风控照常执行（先于拦截）；
+// - 合成响应 code=0（下单类 sCode=0），ordId/algoId/transId/wdId 为本地生成的数字字符串。
+type DryRunPolicy struct {
+	// Categories 为需要拦截的接口类别；为空表示全部类别。
+	Categories []DryRunCategory
+	// Precheck 为 true 时，下单（REST order/batch-orders 与 WS order/batch-orders）会先逐笔调用只读的
+	// POST /api/v5/trade/order-precheck 校验，失败则直接返回该错误（仅跨币种/组合保证金模式可用）。
+	Precheck bool
+	// Logger 接收每一次被拦截的请求与合成响应（可选；在调用 goroutine 中同步执行）。
+	Logger func(rec DryRunRecord)
+}
+
+// DryRunRecord 表示一次被 dry-run 拦截的请求。
+type DryRunRecord struct {
+	Time     time.Time
+	Category DryRunCategory
+	// Method 为 REST method（WS 为 "WS"）。
+	Method string
+	// Path 为 REST endpoint（WS 为 op）。
+	Path     string
+	Request  json.RawMessage
+	Response json.RawMessage
+	// Err 为 precheck 失败原因（为空表示已返回合成响应）。
+	Err error
+}
+
+// WithDryRun 启用 dry-run（对 REST 与由该 Client 创建的 WSClient 同时生效）。
+func WithDryRun(policy DryRunPolicy) Option {
+	d := newDryRun(policy)
+	return func(c *Client) {
+		c.dryRun = d
+	}
+}
+
+// WithWSDryRun 为单个 WSClient 启用 dry-run（覆盖 Client 级配置；仅影响 WS 交易 op）。
+func WithWSDryRun(policy DryRunPolicy) WSOption {
+	d := newDryRun(policy)
+	return func(w *WSClient) {
+		w.dryRun = d
+	}
+}
+
+// dryRunRoute 描述一个被拦截的接口及其合成响应的特殊字段。
+type dryRunRoute struct {
+	category DryRunCategory
+	// id 为真实响应中由服务端生成的 ID 字段（ordId/algoId/transId/wdId 等）；请求未给出时合成，为空表示不合成。
+	id string
+	// result 为 true 时合成 result=true（mass-cancel 类接口）。
+	result bool
+	// triggerTime 为 true 时按 timeOut 合成 triggerTime（cancel-all-after 类接口）。
+	triggerTime bool
+}
+
+// dryRunRESTRoutes 为需要特殊合成字段或类别的 REST 写接口（均为 POST）；
+// 未列出的签名写接口按各 Service 标注的权限归类后同样拦截（见 restRoute）。
+var dryRunRESTRoutes = map[string]dryRunRoute{
+	"/api/v5/trade/order":                   {category: DryRunCategoryTrade, id: "ordId"},
+	"/api/v5/trade/batch-orders":            {category: DryRunCategoryTrade, id: "ordId"},
+	"/api/v5/trade/cancel-order":            {category: DryRunCategoryTrade, id: "ordId"},
+	"/api/v5/trade/cancel-batch-orders":     {category: DryRunCategoryTrade, id: "ordId"},
+	"/api/v5/trade/amend-order":             {category: DryRunCategoryTrade, id: "ordId"},
+	"/api/v5/trade/amend-batch-orders":      {category: DryRunCategoryTrade, id: "ordId"},
+	"/api/v5/trade/close-position":          {category: DryRunCategoryTrade},
+	"/api/v5/trade/order-algo":              {category: DryRunCategoryTrade, id: "algoId"},
+	"/api/v5/trade/cancel-algos":            {category: DryRunCategoryTrade, id: "algoId"},
+	"/api/v5/trade/amend-algos":             {category: DryRunCategoryTrade, id: "algoId"},
+	"/api/v5/trade/mass-cancel":             {category: DryRunCategoryTrade, result: true},
+	"/api/v5/trade/cancel-all-after":        {category: DryRunCategoryTrade, triggerTime: true},
+	"/api/v5/sprd/order":                    {category: DryRunCategoryTrade, id: "ordId"},
+	"/api/v5/sprd/cancel-order":             {category: DryRunCategoryTrade, id: "ordId"},
+	"/api/v5/sprd/amend-order":              {category: DryRunCategoryTrade, id: "ordId"},
+	"/api/v5/sprd/mass-cancel":              {category: DryRunCategoryTrade, result: true},
+	"/api/v5/sprd/cancel-all-after":         {category: DryRunCategoryTrade, triggerTime: true},
+	"/api/v5/copytrading/algo-order":        {category: DryRunCategoryTrade},
+	"/api/v5/copytrading/close-subposition": {category: DryRunCategoryTrade},
+
+	"/api/v5/asset/transfer":            {category: DryRunCategoryTransfer, id: "transId"},
+	"/api/v5/asset/subaccount/transfer": {category: DryRunCategoryTransfer, id: "transId"},
+
+	"/api/v5/asset/withdrawal":        {category: DryRunCategoryWithdrawal, id: "wdId"},
+	"/api/v5/asset/cancel-withdrawal": {category: DryRunCategoryWithdrawal, id: "wdId"},
+
+	"/api/v5/account/spot-manual-borrow-repay":        {category: DryRunCategoryBorrow, id: "tradeId"},
+	"/api/v5/finance/flexible-loan/adjust-collateral": {category: DryRunCategoryBorrow},
+
+	"/api/v5/tradingBot/grid/order-algo":            {category: DryRunCategoryBot, id: "algoId"},
+	"/api/v5/tradingBot/grid/amend-order-algo":      {category: DryRunCategoryBot, id: "algoId"},
+	"/api/v5/tradingBot/grid/stop-order-algo":       {category: DryRunCategoryBot, id: "algoId"},
+	"/api/v5/tradingBot/recurring/order-algo":       {category: DryRunCategoryBot, id: "algoId"},
+	"/api/v5/tradingBot/recurring/amend-order-algo": {category: DryRunCategoryBot, id: "algoId"},
+	"/api/v5/tradingBot/recurring/stop-order-algo":  {category: DryRunCategoryBot, id: "algoId"},
+	"/api/v5/tradingBot/signal/order-algo":          {category: DryRunCategoryBot, id: "algoId"},
+	"/api/v5/tradingBot/signal/stop-order-algo":     {category: DryRunCategoryBot, id: "algoId"},
+}
+
+// dryRunWSOps 为 dry-run 拦截的 WS 交易 op。
+var dryRunWSOps = map[string]dryRunRoute{
+	wsOpOrder:             {category: DryRunCategoryTrade, id: "ordId"},
+	wsOpBatchOrders:       {category: DryRunCategoryTrade, id: "ordId"},
+	wsOpCancelOrder:       {category: DryRunCategoryTrade, id: "ordId"},
+	wsOpBatchCancelOrders: {category: DryRunCategoryTrade, id: "ordId"},
+	wsOpAmendOrder:        {category: DryRunCategoryTrade, id: "ordId"},
+	wsOpBatchAmendOrders:  {category: DryRunCategoryTrade, id: "ordId"},
+	wsOpMassCancel:        {category: DryRunCategoryTrade, result: true},
+	wsOpSprdOrder:         {category: DryRunCategoryTrade, id: "ordId"},
+	wsOpSprdCancelOrder:   {category: DryRunCategoryTrade, id: "ordId"},
+	wsOpSprdAmendOrder:    {category: DryRunCategoryTrade, id: "ordId"},
+	wsOpSprdMassCancel:    {category: DryRunCategoryTrade, result: true},
+}
+
+// dryRunEchoKeys 为合成响应从请求中回显的字段（仅回显字符串值，用于满足各 Service 的 ack 一致性校验）。
+var dryRunEchoKeys = []string{
+	"instId", "ordId", "clOrdId", "algoId", "algoClOrdId", "reqId", "tag",
+	"sprdId", "side", "posSide", "ccy", "amt", "from", "to", "clientId", "chain", "wdId", "subPosId",
+}
+
+type dryRun struct {
+	policy     DryRunPolicy
+	categories map[DryRunCategory]struct{}
+	seq        atomic.Int64
+}
+
+func newDryRun(policy DryRunPolicy) *dryRun {
+	d := &dryRun{policy: policy}
+	if len(policy.Categories) > 0 {
+		d.categories = make(map[DryRunCategory]struct{}, len(policy.Categories))
+		for _, cat := range policy.Categories {
+			d.categories[cat] = struct{}{}
+		}
+	}
+	d.seq.Store(time.Now().UnixMilli() * 1000)
+	return d
+}
+
+func (d *dryRun) route(routes map[string]dryRunRoute, key string) (dryRunRoute, bool) {
+	if d == nil {
+		return dryRunRoute{}, false
+	}
+	r, ok := routes[key]
+	if !ok {
+		return dryRunRoute{}, false
+	}
+	if d.categories != nil {
+		if _, ok := d.categories[r.category]; !ok {
+			return dryRunRoute{}, false
+		}
+	}
+	return r, true
+}
+
+// restRoute 返回 REST 请求的拦截规则：签名的非 GET 请求除只读接口（CapabilityRead）外一律拦截（默认拒绝）。
+func (d *dryRun) restRoute(cp Capability, method, endpoint string, signed bool) (dryRunRoute, bool) {
+	if d == nil || !signed || method == http.MethodGet {
+		return dryRunRoute{}, false
+	}
+	routes := dryRunRESTRoutes
+	if _, ok := routes[endpoint]; !ok {
+		cat, write := dryRunRESTCategory(cp, endpoint)
+		if !write {
+			return dryRunRoute{}, false
+		}
+		routes = map[string]dryRunRoute{endpoint: {category: cat}}
+	}
+	return d.route(routes, endpoint)
+}
+
+// dryRunRESTCategory 按接口权限对写接口归类；只读接口返回 write=false。
+func dryRunRESTCategory(cp Capability, endpoint string) (cat DryRunCategory, write bool) {
+	switch cp {
+	case CapabilityRead:
+		return "", false
+	case CapabilityTransfer:
+		return DryRunCategoryTransfer, true
+	case CapabilityWithdraw:
+		return DryRunCategoryWithdrawal, true
+	case CapabilityFinance:
+		return DryRunCategoryFinance, true
+	case CapabilitySubAccountAdmin:
+		return DryRunCategorySubAccount, true
+	}
+	if strings.HasPrefix(endpoint, "/api/v5/tradingBot/") {
+		return DryRunCategoryBot, true
+	}
+	return DryRunCategoryTrade, true
+}
+
+func (d *dryRun) nextID() string {
+	return strconv.FormatInt(d.seq.Add(1), 10)
+}
+
+// synthesize 按请求体（对象或数组）逐项生成合成的响应 data 数组。
+func (d *dryRun) synthesize(r dryRunRoute, body []byte, now time.Time) (json.RawMessage, error) {
+	var items []map[string]json.RawMessage
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, err
+		}
+	} else {
+		var item map[string]json.RawMessage
+		if len(body) > 0 {
+			if err := json.Unmarshal(body, &item); err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, item)
+	}
+
+	ts := strconv.FormatInt(now.UnixMilli(), 10)
+	out := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		ack := map[string]any{
+			"sCode": "0",
+			"sMsg":  "",
+			"ts":    ts,
+		}
+		for _, k := range dryRunEchoKeys {
+			var s string
+			if raw, ok := item[k]; ok && json.Unmarshal(raw, &s) == nil {
+				ack[k] = s
+			}
+		}
+		if r.id != "" {
+			if _, ok := ack[r.id]; !ok {
+				ack[r.id] = d.nextID()
+			}
+		}
+		if r.result {
+			ack["result"] = true
+		}
+		if r.triggerTime {
+			var timeOut string
+			_ = json.Unmarshal(item["timeOut"], &timeOut)
+			sec, _ := strconv.ParseInt(timeOut, 10, 64)
+			ack["triggerTime"] = strconv.FormatInt(now.Add(time.Duration(sec)*time.Second).UnixMilli(), 10)
+		}
+		out = append(out, ack)
+	}
+	return json.Marshal(out)
+}
+
+func (d *dryRun) log(rec DryRunRecord) {
+	if d == nil || d.policy.Logger == nil {
+		return
+	}
+	defer func() {
+		_ = recover()
+	}()
+	d.policy.Logger(rec)
+}
+
+// precheckOrders 在 dry-run 下单前逐笔调用 order-precheck（只读）。
+func (d *dryRun) precheckOrders(ctx context.Context, c *Client, body []byte) error {
+	if d == nil || !d.policy.Precheck || c == nil {
+		return nil
+	}
+	var orders []orderPrecheckRequest
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &orders); err != nil {
+			return err
+		}
+	} else {
+		var o orderPrecheckRequest
+		if err := json.Unmarshal(body, &o); err != nil {
+			return err
+		}
+		orders = append(orders, o)
+	}
+	for _, o := range orders {
+		if err := c.do(ctx, CapabilityRead, http.MethodPost, "/api/v5/trade/order-precheck", nil, o, true, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dryRunREST 在 dry-run 启用且命中拦截接口时返回合成的响应 envelope（ok=true）。
+func (c *Client) dryRunREST(ctx context.Context, cp Capability, method, endpoint string, body []byte, signed bool) (resp []byte, respHeader http.Header, ok bool, err error) {
+	if c == nil || c.dryRun == nil {
+		return nil, nil, false, nil
+	}
+	r, hit := c.dryRun.restRoute(cp, method, endpoint, signed)
+	if !hit {
+		return nil, nil, false, nil
+	}
+
+	now := c.now()
+	rec := DryRunRecord{Time: now, Category: r.category, Method: method, Path: endpoint, Request: json.RawMessage(body)}
+	if endpoint == "/api/v5/trade/order" || endpoint == "/api/v5/trade/batch-orders" {
+		if err := c.dryRun.precheckOrders(ctx, c, body); err != nil {
+			rec.Err = err
+			c.dryRun.log(rec)
+			return nil, nil, true, err
+		}
+	}
+
+	data, err := c.dryRun.synthesize(r, body, now)
+	if err != nil {
+		return nil, nil, true, fmt.Errorf("okx: dry-run synthesize %s: %w", endpoint, err)
+	}
+	rec.Response = data
+	c.statsDryRunTotal.Add(1)
+	c.dryRun.log(rec)
+
+	resp, err = json.Marshal(responseEnvelope{Code: "0", Msg: "", Data: data})
+	respHeader = make(http.Header)
+	respHeader.Set("x-request-id", "dryrun-"+c.dryRun.nextID())
+	return resp, respHeader, true, err
+}
+
+// dryRunWS 在 dry-run 启用且命中 WS 交易 op 时返回合成的 op 响应（ok=true）。
+func (w *WSClient) dryRunWS(ctx context.Context, op string, args any) (reply *WSOpReply, raw []byte, ok bool, err error) {
+	if w == nil {
+		return nil, nil, false, nil
+	}
+	d := w.dryRun
+	if d == nil && w.c != nil {
+		d = w.c.dryRun
+	}
+	r, hit := d.route(dryRunWSOps, op)
+	if !hit {
+		return nil, nil, false, nil
+	}
+
+	body, err := json.Marshal(args)
+	if err != nil {
+		return nil, nil, true, err
+	}
+	now := time.Now()
+	if w.c != nil {
+		now = w.c.now()
+	}
+	rec := DryRunRecord{Time: now, Category: r.category, Method: requestGateMethodWS, Path: op, Request: json.RawMessage(body)}
+	if op == wsOpOrder || op == wsOpBatchOrders {
+		if err := d.precheckOrders(ctx, w.c, body); err != nil {
+			rec.Err = err
+			d.log(rec)
+			return nil, nil, true, err
+		}
+	}
+
+	data, err := d.synthesize(r, body, now)
+	if err != nil {
+		return nil, nil, true, fmt.Errorf("okx: dry-run synthesize %s: %w", op, err)
+	}
+	rec.Response = data
+	if w.c != nil {
+		w.c.statsDryRunTotal.Add(1)
+	}
+	d.log(rec)
+
+	us := strconv.FormatInt(now.UnixMicro(), 10)
+	reply = &WSOpReply{ID: w.nextOpID(), Op: op, Code: "0", Msg: "", Data: data, InTime: us, OutTime: us}
+	raw, err = json.Marshal(reply)
+	return reply, raw, true, err
+}
@@ -0,0 +1,263 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newDryRunTestClient(t *testing.T, policy DryRunPolicy) (*Client, *[]string, *sync.Mutex) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v5/market/ticker":
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instType":"SPOT","instId":"BTC-USDT","last":"50000","ts":"1"}]}`))
+		case "/api/v5/trade/order-precheck":
+			var req orderPrecheckRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Sz == "999" {
+				_, _ = w.Write([]byte(`{"code":"51008","msg":"Order failed. Insufficient balance","data":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"adjEq":"1","adjEqChg":"0","imr":"1","imrChg":"0","mmr":"1","mmrChg":"0","mgnRatio":"1","mgnRatioChg":"0","availBal":"1","availBalChg":"0","liqPx":"","liqPxDiff":"","liqPxDiffRatio":"","posBal":"","posBalChg":"","type":""}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			_, _ = w.Write([]byte(`{"code":"50000","msg":"unexpected","data":[]}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(
+		WithBaseURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+		WithCredentials(Credentials{APIKey: "k", SecretKey: "s", Passphrase: "p"}),
+		WithRequestGateDisabled(),
+		WithDryRun(policy),
+	)
+	return c, &paths, &mu
+}
+
+func TestDryRun_REST(t *testing.T) {
+	var recs []DryRunRecord
+	c, paths, mu := newDryRunTestClient(t, DryRunPolicy{Logger: func(rec DryRunRecord) { recs = append(recs, rec) }})
+	ctx := context.Background()
+
+	ack, err := c.NewPlaceOrderService().InstId("BTC-USDT").TdMode("cash").Side("buy").OrdType("limit").Px("1").Sz("1").ClOrdId("c1").Do(ctx)
+	if err != nil {
+		t.Fatalf("PlaceOrder error = %v", err)
+	}
+	if ack.SCode != "0" || ack.OrdId == "" || ack.ClOrdId != "c1" {
+		t.Fatalf("ack = %#v", ack)
+	}
+
+	acks, err := c.NewBatchPlaceOrdersService().Orders([]BatchPlaceOrder{
+		{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Px: "1", Sz: "1"},
+		{InstId: "BTC-USDT", TdMode: "cash", Side: "sell", OrdType: "limit", Px: "2", Sz: "1"},
+	}).Do(ctx)
+	if err != nil {
+		t.Fatalf("BatchPlaceOrders error = %v", err)
+	}
+	if len(acks) != 2 || acks[0].OrdId == acks[1].OrdId {
+		t.Fatalf("acks = %#v", acks)
+	}
+
+	tr, err := c.NewAssetTransferService().Ccy("USDT").Amt("1").From("6").To("18").Do(ctx)
+	if err != nil {
+		t.Fatalf("Transfer error = %v", err)
+	}
+	if tr.TransId == "" || tr.Ccy != "USDT" || tr.Amt != "1" {
+		t.Fatalf("transfer ack = %#v", tr)
+	}
+
+	if _, err := c.NewMarketTickerService().InstId("BTC-USDT").Do(ctx); err != nil {
+		t.Fatalf("Ticker error = %v", err)
+	}
+
+	mu.Lock()
+	got := append([]string(nil), (*paths)...)
+	mu.Unlock()
+	if len(got) != 1 || got[0] != "/api/v5/market/ticker" {
+		t.Fatalf("http paths = %v, want only ticker", got)
+	}
+
+	if len(recs) != 3 || recs[0].Category != DryRunCategoryTrade || recs[0].Path != "/api/v5/trade/order" || recs[2].Category != DryRunCategoryTransfer {
+		t.Fatalf("records = %#v", recs)
+	}
+	// 合成响应只包含该接口真实响应中的 ID 字段。
+	for i, want := range map[int]string{0: "ordId", 2: "transId"} {
+		var data []map[string]any
+		if err := json.Unmarshal(recs[i].Response, &data); err != nil || len(data) != 1 {
+			t.Fatalf("response = %s (%v)", recs[i].Response, err)
+		}
+		for _, k := range []string{"ordId", "algoId", "transId", "wdId"} {
+			if _, ok := data[0][k]; ok != (k == want) {
+				t.Fatalf("%s response = %s, want only %s", recs[i].Path, recs[i].Response, want)
+			}
+		}
+	}
+	if st := c.ClientStats(); st.DryRunTotal != 3 || st.SuccessTotal != 4 {
+		t.Fatalf("stats = %#v", st)
+	}
+}
+
+func TestDryRun_CategoriesAndPrecheck(t *testing.T) {
+	c, paths, mu := newDryRunTestClient(t, DryRunPolicy{Categories: []DryRunCategory{DryRunCategoryTrade}, Precheck: true})
+	ctx := context.Background()
+
+	if _, err := c.NewPlaceOrderService().InstId("BTC-USDT").TdMode("cross").Side("buy").OrdType("limit").Px("1").Sz("1").Do(ctx); err != nil {
+		t.Fatalf("PlaceOrder error = %v", err)
+	}
+	_, err := c.NewPlaceOrderService().InstId("BTC-USDT").TdMode("cross").Side("buy").OrdType("limit").Px("1").Sz("999").Do(ctx)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "51008" {
+		t.Fatalf("error = %T %v, want APIError 51008", err, err)
+	}
+
+	mu.Lock()
+	got := append([]string(nil), (*paths)...)
+	mu.Unlock()
+	if len(got) != 2 || got[0] != "/api/v5/trade/order-precheck" || got[1] != "/api/v5/trade/order-precheck" {
+		t.Fatalf("http paths = %v", got)
+	}
+
+	// 未纳入 Categories 的接口照常请求 OKX。
+	c2, paths2, _ := newDryRunTestClient(t, DryRunPolicy{Categories: []DryRunCategory{DryRunCategoryWithdrawal}})
+	if _, err := c2.NewMarketTickerService().InstId("BTC-USDT").Do(ctx); err != nil {
+		t.Fatalf("Ticker error = %v", err)
+	}
+	if r, ok := c2.dryRun.restRoute(CapabilityTrade, http.MethodPost, "/api/v5/trade/order", true); ok {
+		t.Fatalf("route = %#v, want miss", r)
+	}
+	if len(*paths2) != 1 {
+		t.Fatalf("http paths = %v", *paths2)
+	}
+}
+
+func TestDryRun_WS(t *testing.T) {
+	c, _, _ := newDryRunTestClient(t, DryRunPolicy{})
+	w := c.NewWSPrivate()
+	ctx := context.Background()
+
+	ack, err := w.PlaceOrder(ctx, WSPlaceOrderArg{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Px: "1", Sz: "1", ClOrdId: "w1"})
+	if err != nil {
+		t.Fatalf("PlaceOrder error = %v", err)
+	}
+	if ack.SCode != "0" || ack.OrdId == "" || ack.ClOrdId != "w1" {
+		t.Fatalf("ack = %#v", ack)
+	}
+
+	cancel, err := w.CancelOrder(ctx, WSCancelOrderArg{InstId: "BTC-USDT", OrdId: ack.OrdId})
+	if err != nil {
+		t.Fatalf("CancelOrder error = %v", err)
+	}
+	if cancel.OrdId != ack.OrdId {
+		t.Fatalf("cancel ack = %#v", cancel)
+	}
+
+	// WS 级配置覆盖 Client 级配置。
+	var n int
+	w2 := c.NewWSPrivate(WithWSDryRun(DryRunPolicy{Logger: func(DryRunRecord) { n++ }}))
+	if _, err := w2.PlaceOrder(ctx, WSPlaceOrderArg{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "market", Sz: "1"}); err != nil {
+		t.Fatalf("PlaceOrder error = %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("logger calls = %d, want 1", n)
+	}
+	if st := c.ClientStats(); st.DryRunTotal != 3 {
+		t.Fatalf("DryRunTotal = %d, want 3", st.DryRunTotal)
+	}
+}
+
+func TestDryRun_DefaultDenyAllPOST(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(
+		WithBaseURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+		WithCredentials(Credentials{APIKey: "k", SecretKey: "s", Passphrase: "p"}),
+		WithRequestGateDisabled(),
+		WithDryRun(DryRunPolicy{}),
+	)
+	ctx := context.Background()
+
+	caps := postEndpointCapabilities(t)
+	endpoints := make([]string, 0, len(caps))
+	for p := range caps {
+		endpoints = append(endpoints, p)
+	}
+	sort.Strings(endpoints)
+	if len(endpoints) < 100 {
+		t.Fatalf("found %d POST endpoints, want >= 100", len(endpoints))
+	}
+	// 评审中列出的资金/交易类接口必须在扫描结果中且被拦截。
+	for _, p := range []string{
+		"/api/v5/rfq/create-rfq",
+		"/api/v5/rfq/create-quote",
+		"/api/v5/rfq/execute-quote",
+		"/api/v5/asset/convert/trade",
+		"/api/v5/trade/easy-convert",
+		"/api/v5/trade/one-click-repay",
+		"/api/v5/trade/one-click-repay-v2",
+		"/api/v5/tradingBot/grid/adjust-investment",
+		"/api/v5/fiat/buy-sell/trade",
+		"/api/v5/tradingBot/grid/withdraw-income",
+		"/api/v5/tradingBot/grid/close-position",
+		"/api/v5/tradingBot/signal/sub-order",
+		"/api/v5/tradingBot/signal/close-position",
+		"/api/v5/finance/savings/purchase-redempt",
+		"/api/v5/finance/staking-defi/purchase",
+		"/api/v5/finance/staking-defi/redeem",
+		"/api/v5/account/position/margin-balance",
+		"/api/v5/account/move-positions",
+	} {
+		if i := sort.SearchStrings(endpoints, p); i == len(endpoints) || endpoints[i] != p {
+			t.Fatalf("POST endpoint %s not found by scan", p)
+		}
+		if _, ok := c.dryRun.restRoute(caps[p], http.MethodPost, p, true); !ok {
+			t.Fatalf("restRoute(%s) miss, want intercepted", p)
+		}
+	}
+
+	var reads []string
+	for _, p := range endpoints {
+		var out json.RawMessage
+		if err := c.do(ctx, caps[p], http.MethodPost, p, nil, map[string]string{}, true, &out); err != nil {
+			t.Fatalf("POST %s error = %v", p, err)
+		}
+		if caps[p] == CapabilityRead {
+			reads = append(reads, p)
+		}
+	}
+
+	// 只有已知只读的 POST 会到达服务端。
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(paths, ",") != strings.Join(reads, ",") {
+		t.Fatalf("http paths = %v, want read-only %v", paths, reads)
+	}
+	if got, want := c.ClientStats().DryRunTotal, uint64(len(endpoints)-len(reads)); got != want {
+		t.Fatalf("DryRunTotal = %d, want %d", got, want)
+	}
+}
@@ -21,7 +21,7 @@ var errEmptyFiatBuySellCurrenciesResponse = errors.New("okx: empty fiat buy-sell
 // Do 获取买卖交易币种（GET /api/v5/fiat/buy-sell/currencies）。
 func (s *FiatBuySellCurrenciesService) Do(ctx context.Context) (*FiatBuySellCurrencies, error) {
 	var data []FiatBuySellCurrencies
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/fiat/buy-sell/currencies", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/fiat/buy-sell/currencies", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
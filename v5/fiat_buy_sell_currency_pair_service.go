@@ -45,7 +45,7 @@ func (s *FiatBuySellCurrencyPairService) Do(ctx context.Context) ([]FiatBuySellC
 	q.Set("toCcy", s.toCcy)
 
 	var data []FiatBuySellCurrencyPair
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/fiat/buy-sell/currency-pair", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/fiat/buy-sell/currency-pair", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -83,7 +83,7 @@ func (s *FiatBuySellHistoryService) Do(ctx context.Context) ([]FiatBuySellOrder,
 	}
 
 	var data []FiatBuySellOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/fiat/buy-sell/history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/fiat/buy-sell/history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -80,7 +80,7 @@ func (s *FiatBuySellQuoteService) Do(ctx context.Context) (*FiatBuySellQuote, er
 	}
 
 	var data []FiatBuySellQuote
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/fiat/buy-sell/quote", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityRead, http.MethodPost, "/api/v5/fiat/buy-sell/quote", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +107,7 @@ func (s *FiatBuySellTradeService) Do(ctx context.Context) (*FiatBuySellOrder, er
 	}
 
 	var data []FiatBuySellOrder
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/fiat/buy-sell/trade", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/fiat/buy-sell/trade", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -50,5 +50,5 @@ func (s *FinanceFlexibleLoanAdjustCollateralService) Do(ctx context.Context) err
 	if s.req.Type == "" || s.req.CollateralCcy == "" || s.req.CollateralAmt == "" {
 		return errFinanceFlexibleLoanAdjustCollateralMissingRequired
 	}
-	return s.c.do(ctx, http.MethodPost, "/api/v5/finance/flexible-loan/adjust-collateral", nil, s.req, true, nil)
+	return s.c.do(ctx, CapabilityFinance, http.MethodPost, "/api/v5/finance/flexible-loan/adjust-collateral", nil, s.req, true, nil)
 }
@@ -18,7 +18,7 @@ func (c *Client) NewFinanceFlexibleLoanBorrowCurrenciesService() *FinanceFlexibl
 // Do 获取可借币种列表（GET /api/v5/finance/flexible-loan/borrow-currencies）。
 func (s *FinanceFlexibleLoanBorrowCurrenciesService) Do(ctx context.Context) ([]FinanceFlexibleLoanBorrowCurrency, error) {
 	var data []FinanceFlexibleLoanBorrowCurrency
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/flexible-loan/borrow-currencies", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/flexible-loan/borrow-currencies", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -35,7 +35,7 @@ func (s *FinanceFlexibleLoanCollateralAssetsService) Do(ctx context.Context) (*F
 	}
 
 	var data []FinanceFlexibleLoanCollateralAssets
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/flexible-loan/collateral-assets", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/flexible-loan/collateral-assets", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -66,7 +66,7 @@ func (s *FinanceFlexibleLoanInterestAccruedService) Do(ctx context.Context) ([]F
 	}
 
 	var data []FinanceFlexibleLoanInterestAccrued
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/flexible-loan/interest-accrued", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/flexible-loan/interest-accrued", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -66,7 +66,7 @@ func (s *FinanceFlexibleLoanLoanHistoryService) Do(ctx context.Context) ([]Finan
 	}
 
 	var data []FinanceFlexibleLoanLoanHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/flexible-loan/loan-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/flexible-loan/loan-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
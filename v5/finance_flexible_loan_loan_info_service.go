@@ -21,7 +21,7 @@ var errEmptyFinanceFlexibleLoanLoanInfo = errors.New("okx: empty flexible loan l
 // Do 获取借贷信息（GET /api/v5/finance/flexible-loan/loan-info）。
 func (s *FinanceFlexibleLoanLoanInfoService) Do(ctx context.Context) (*FinanceFlexibleLoanInfo, error) {
 	var data []FinanceFlexibleLoanInfo
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/flexible-loan/loan-info", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/flexible-loan/loan-info", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
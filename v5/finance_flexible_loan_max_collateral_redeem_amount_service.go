@@ -39,7 +39,7 @@ func (s *FinanceFlexibleLoanMaxCollateralRedeemAmountService) Do(ctx context.Con
 	q.Set("ccy", s.ccy)
 
 	var data []FinanceFlexibleLoanMaxCollateralRedeemAmount
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/flexible-loan/max-collateral-redeem-amount", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/flexible-loan/max-collateral-redeem-amount", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
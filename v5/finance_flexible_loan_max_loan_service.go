@@ -52,7 +52,7 @@ func (s *FinanceFlexibleLoanMaxLoanService) Do(ctx context.Context) (*FinanceFle
 	}
 
 	var data []FinanceFlexibleLoanMaxLoan
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/finance/flexible-loan/max-loan", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityRead, http.MethodPost, "/api/v5/finance/flexible-loan/max-loan", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +32,7 @@ func (s *FinanceSavingsBalanceService) Do(ctx context.Context) ([]FinanceSavings
 	}
 
 	var data []FinanceSavingsBalance
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/savings/balance", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/savings/balance", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -66,7 +66,7 @@ func (s *FinanceSavingsLendingHistoryService) Do(ctx context.Context) ([]Finance
 	}
 
 	var data []FinanceSavingsLendingHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/savings/lending-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/savings/lending-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -66,7 +66,7 @@ func (s *FinanceSavingsLendingRateHistoryService) Do(ctx context.Context) ([]Fin
 	}
 
 	var data []FinanceSavingsLendingRateHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/savings/lending-rate-history", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/savings/lending-rate-history", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -32,7 +32,7 @@ func (s *FinanceSavingsLendingRateSummaryService) Do(ctx context.Context) ([]Fin
 	}
 
 	var data []FinanceSavingsLendingRateSummary
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/savings/lending-rate-summary", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/savings/lending-rate-summary", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -60,7 +60,7 @@ func (s *FinanceSavingsPurchaseRedemptService) Do(ctx context.Context) (*Finance
 	}
 
 	var data []FinanceSavingsPurchaseRedemptAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/finance/savings/purchase-redempt", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/finance/savings/purchase-redempt", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +46,7 @@ func (s *FinanceSavingsSetLendingRateService) Do(ctx context.Context) (*FinanceS
 	}
 
 	var data []FinanceSavingsSetLendingRateAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/finance/savings/set-lending-rate", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/finance/savings/set-lending-rate", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +46,7 @@ func (s *FinanceStakingDefiCancelService) Do(ctx context.Context) (*FinanceStaki
 	}
 
 	var data []FinanceStakingDefiOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/finance/staking-defi/cancel", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/finance/staking-defi/cancel", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +36,7 @@ func (s *FinanceStakingDefiETHAPYHistoryService) Do(ctx context.Context) ([]Fina
 	q.Set("days", s.days)
 
 	var data []FinanceStakingDefiAPYHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/staking-defi/eth/apy-history", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/staking-defi/eth/apy-history", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -21,7 +21,7 @@ var errEmptyFinanceStakingDefiETHBalance = errors.New("okx: empty staking-defi e
 // Do 获取 ETH 质押余额（GET /api/v5/finance/staking-defi/eth/balance）。
 func (s *FinanceStakingDefiETHBalanceService) Do(ctx context.Context) (*FinanceStakingDefiETHBalance, error) {
 	var data []FinanceStakingDefiETHBalance
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/staking-defi/eth/balance", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/staking-defi/eth/balance", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
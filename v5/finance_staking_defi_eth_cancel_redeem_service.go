@@ -40,7 +40,7 @@ func (s *FinanceStakingDefiETHCancelRedeemService) Do(ctx context.Context) (*Fin
 
 	req := financeStakingDefiETHCancelRedeemRequest{OrdId: s.ordId}
 	var data []FinanceStakingDefiOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/finance/staking-defi/eth/cancel-redeem", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/finance/staking-defi/eth/cancel-redeem", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -21,7 +21,7 @@ var errEmptyFinanceStakingDefiETHProductInfo = errors.New("okx: empty staking-de
 // Do 获取 ETH 质押产品信息（GET /api/v5/finance/staking-defi/eth/product-info）。
 func (s *FinanceStakingDefiETHProductInfoService) Do(ctx context.Context) (*FinanceStakingDefiETHProductInfo, error) {
 	var data []FinanceStakingDefiETHProductInfo
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/staking-defi/eth/product-info", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/staking-defi/eth/product-info", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -38,5 +38,5 @@ func (s *FinanceStakingDefiETHRedeemService) Do(ctx context.Context) error {
 	}
 
 	req := financeStakingDefiETHRedeemRequest{Amt: s.amt}
-	return s.c.do(ctx, http.MethodPost, "/api/v5/finance/staking-defi/eth/redeem", nil, req, true, nil)
+	return s.c.do(ctx, CapabilityFinance, http.MethodPost, "/api/v5/finance/staking-defi/eth/redeem", nil, req, true, nil)
 }
@@ -55,7 +55,7 @@ func (s *FinanceStakingDefiOffersService) Do(ctx context.Context) ([]FinanceStak
 	}
 
 	var data []FinanceStakingDefiOffer
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/staking-defi/offers", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/staking-defi/offers", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -65,7 +65,7 @@ func (s *FinanceStakingDefiOrdersActiveService) Do(ctx context.Context) ([]Finan
 	}
 
 	var data []FinanceStakingDefiOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/staking-defi/orders-active", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/staking-defi/orders-active", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
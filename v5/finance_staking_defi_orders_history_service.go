@@ -87,7 +87,7 @@ func (s *FinanceStakingDefiOrdersHistoryService) Do(ctx context.Context) ([]Fina
 	}
 
 	var data []FinanceStakingDefiOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/staking-defi/orders-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/staking-defi/orders-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -70,7 +70,7 @@ func (s *FinanceStakingDefiPurchaseService) Do(ctx context.Context) (*FinanceSta
 	}
 
 	var data []FinanceStakingDefiOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/finance/staking-defi/purchase", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/finance/staking-defi/purchase", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
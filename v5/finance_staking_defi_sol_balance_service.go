@@ -21,7 +21,7 @@ var errEmptyFinanceStakingDefiSOLBalance = errors.New("okx: empty staking-defi s
 // Do 获取 SOL 质押余额（GET /api/v5/finance/staking-defi/sol/balance）。
 func (s *FinanceStakingDefiSOLBalanceService) Do(ctx context.Context) (*FinanceStakingDefiSOLBalance, error) {
 	var data []FinanceStakingDefiSOLBalance
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/staking-defi/sol/balance", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/staking-defi/sol/balance", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -20,7 +20,7 @@ func (c *Client) NewFinanceStakingDefiSOLProductInfoService() *FinanceStakingDef
 // 注意：OKX 文档示例中该接口的 data 为对象（非数组）。
 func (s *FinanceStakingDefiSOLProductInfoService) Do(ctx context.Context) (*FinanceStakingDefiSOLProductInfo, error) {
 	var data FinanceStakingDefiSOLProductInfo
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/staking-defi/sol/product-info", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/staking-defi/sol/product-info", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return &data, nil
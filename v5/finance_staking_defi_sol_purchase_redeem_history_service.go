@@ -76,7 +76,7 @@ func (s *FinanceStakingDefiSOLPurchaseRedeemHistoryService) Do(ctx context.Conte
 	}
 
 	var data []FinanceStakingDefiPurchaseRedeemHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/finance/staking-defi/sol/purchase-redeem-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/finance/staking-defi/sol/purchase-redeem-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
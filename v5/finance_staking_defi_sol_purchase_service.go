@@ -38,5 +38,5 @@ func (s *FinanceStakingDefiSOLPurchaseService) Do(ctx context.Context) error {
 	}
 
 	req := financeStakingDefiSOLPurchaseRequest{Amt: s.amt}
-	return s.c.do(ctx, http.MethodPost, "/api/v5/finance/staking-defi/sol/purchase", nil, req, true, nil)
+	return s.c.do(ctx, CapabilityFinance, http.MethodPost, "/api/v5/finance/staking-defi/sol/purchase", nil, req, true, nil)
 }
@@ -38,5 +38,5 @@ func (s *FinanceStakingDefiSOLRedeemService) Do(ctx context.Context) error {
 	}
 
 	req := financeStakingDefiSOLRedeemRequest{Amt: s.amt}
-	return s.c.do(ctx, http.MethodPost, "/api/v5/finance/staking-defi/sol/redeem", nil, req, true, nil)
+	return s.c.do(ctx, CapabilityFinance, http.MethodPost, "/api/v5/finance/staking-defi/sol/redeem", nil, req, true, nil)
 }
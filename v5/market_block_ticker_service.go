@@ -51,7 +51,7 @@ func (s *MarketBlockTickerService) Do(ctx context.Context) (*MarketBlockTicker,
 	q.Set("instId", s.instId)
 
 	var data []MarketBlockTicker
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/block-ticker", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/block-ticker", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -46,7 +46,7 @@ func (s *MarketBlockTickersService) Do(ctx context.Context) ([]MarketBlockTicker
 	}
 
 	var data []MarketBlockTicker
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/block-tickers", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/block-tickers", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -50,7 +50,7 @@ func (s *MarketBooksFullService) Do(ctx context.Context) (*OrderBook, error) {
 	}
 
 	var data []OrderBook
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/books-full", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/books-full", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
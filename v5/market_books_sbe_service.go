@@ -167,3 +167,12 @@ func (s *MarketBooksSBEService) Do(ctx context.Context) ([]byte, error) {
 		return resp, nil
 	}
 }
+
+// DoDecoded 获取并解码 SBE 订单簿快照（SnapshotDepthResponseEvent）。
+func (s *MarketBooksSBEService) DoDecoded(ctx context.Context) (*SBESnapshotDepth, error) {
+	b, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeSBESnapshotDepth(b)
+}
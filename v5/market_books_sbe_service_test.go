@@ -134,3 +134,27 @@ func TestMarketBooksSBEService_Do(t *testing.T) {
 		}
 	})
 }
+
+func TestMarketBooksSBEService_DoDecoded(t *testing.T) {
+	body := sbeFixture(t,
+		"1a00 ee03 0100 0100",
+		"40222018240a0600 6400000000000000 0a00000000000000 fe fd",
+		"1400 00000000",
+		"1400 01000000",
+		"5a3d400000000000 dc05000000000000 03000000",
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/sbe")
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	got, err := c.NewMarketBooksSBEService().InstIdCode(10).DoDecoded(context.Background())
+	if err != nil {
+		t.Fatalf("DoDecoded() error = %v", err)
+	}
+	if got.InstIdCode != 10 || got.SeqId != 100 || len(got.Bids) != 1 || got.Bids[0].Px != "42100.10" {
+		t.Fatalf("snapshot = %#v", got)
+	}
+}
@@ -54,7 +54,7 @@ func (s *MarketCallAuctionDetailsService) Do(ctx context.Context) (*MarketCallAu
 	q.Set("instId", s.instId)
 
 	var data []MarketCallAuctionDetails
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/call-auction-details", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/call-auction-details", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -143,7 +143,7 @@ func (s *MarketCandlesService) Do(ctx context.Context) ([]Candle, error) {
 	}
 
 	var data []Candle
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/candles", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/candles", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
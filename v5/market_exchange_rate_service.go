@@ -27,7 +27,7 @@ var errEmptyMarketExchangeRateResponse = errors.New("okx: empty market exchange
 // Do 获取法币汇率（GET /api/v5/market/exchange-rate）。
 func (s *MarketExchangeRateService) Do(ctx context.Context) (*MarketExchangeRate, error) {
 	var data []MarketExchangeRate
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/exchange-rate", nil, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/exchange-rate", nil, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -78,7 +78,7 @@ func (s *MarketHistoryCandlesService) Do(ctx context.Context) ([]Candle, error)
 	}
 
 	var data []Candle
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/history-candles", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/history-candles", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -78,7 +78,7 @@ func (s *MarketHistoryIndexCandlesService) Do(ctx context.Context) ([]PriceCandl
 	}
 
 	var data []PriceCandle
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/history-index-candles", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/history-index-candles", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
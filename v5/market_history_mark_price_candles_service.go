@@ -78,7 +78,7 @@ func (s *MarketHistoryMarkPriceCandlesService) Do(ctx context.Context) ([]PriceC
 	}
 
 	var data []PriceCandle
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/history-mark-price-candles", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/history-mark-price-candles", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
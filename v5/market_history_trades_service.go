@@ -79,7 +79,7 @@ func (s *MarketHistoryTradesService) Do(ctx context.Context) ([]MarketTrade, err
 	}
 
 	var data []MarketTrade
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/history-trades", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/history-trades", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
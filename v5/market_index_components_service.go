@@ -59,7 +59,7 @@ func (s *MarketIndexComponentsService) Do(ctx context.Context) (*MarketIndexComp
 	q.Set("index", s.index)
 
 	var data MarketIndexComponents
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/index-components", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/index-components", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if data.Index == "" && data.Last == "" && data.TS == 0 && len(data.Components) == 0 {
@@ -49,7 +49,7 @@ func (s *MarketIndexTickersService) Do(ctx context.Context) ([]IndexTicker, erro
 	}
 
 	var data []IndexTicker
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/index-tickers", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/index-tickers", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
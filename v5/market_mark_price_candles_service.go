@@ -78,7 +78,7 @@ func (s *MarketMarkPriceCandlesService) Do(ctx context.Context) ([]PriceCandle,
 	}
 
 	var data []PriceCandle
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/mark-price-candles", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/mark-price-candles", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
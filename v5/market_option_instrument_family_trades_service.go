@@ -59,7 +59,7 @@ func (s *MarketOptionInstrumentFamilyTradesService) Do(ctx context.Context) ([]M
 	q.Set("instFamily", s.instFamily)
 
 	var data []MarketOptionInstrumentFamilyTrades
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/option/instrument-family-trades", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/option/instrument-family-trades", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
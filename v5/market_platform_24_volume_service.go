@@ -29,7 +29,7 @@ var errEmptyMarketPlatform24VolumeResponse = errors.New("okx: empty market platf
 // Do 获取平台 24 小时总成交量（GET /api/v5/market/platform-24-volume）。
 func (s *MarketPlatform24VolumeService) Do(ctx context.Context) (*MarketPlatform24Volume, error) {
 	var data []MarketPlatform24Volume
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/platform-24-volume", nil, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/platform-24-volume", nil, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
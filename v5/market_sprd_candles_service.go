@@ -78,7 +78,7 @@ func (s *MarketSprdCandlesService) Do(ctx context.Context) ([]Candle, error) {
 	}
 
 	var data []Candle
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/sprd-candles", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/sprd-candles", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -61,7 +61,7 @@ func (s *MarketSprdTickerService) Do(ctx context.Context) (*MarketSprdTicker, er
 	q.Set("sprdId", s.sprdId)
 
 	var data []MarketSprdTicker
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/sprd-ticker", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/sprd-ticker", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
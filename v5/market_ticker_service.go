@@ -64,7 +64,7 @@ func (s *MarketTickerService) Do(ctx context.Context) (*MarketTicker, error) {
 	q.Set("instId", s.instId)
 
 	var data []MarketTicker
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/ticker", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/ticker", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
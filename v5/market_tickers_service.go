@@ -46,7 +46,7 @@ func (s *MarketTickersService) Do(ctx context.Context) ([]MarketTicker, error) {
 	}
 
 	var data []MarketTicker
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/tickers", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/tickers", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -64,7 +64,7 @@ func (s *MarketTradesService) Do(ctx context.Context) ([]MarketTrade, error) {
 	}
 
 	var data []MarketTrade
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/market/trades", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/market/trades", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
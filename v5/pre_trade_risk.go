@@ -0,0 +1,579 @@
+package okx
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RiskRule 表示触发拒单的预交易风控规则。
+type RiskRule string
+
+const (
+	RiskRulePolicy     RiskRule = "policy"
+	RiskRuleInstId     RiskRule = "instId"
+	RiskRuleTdMode     RiskRule = "tdMode"
+	RiskRuleNotional   RiskRule = "notional"
+	RiskRulePosition   RiskRule = "position"
+	RiskRuleOrderRate  RiskRule = "orderRate"
+	RiskRulePriceBand  RiskRule = "priceBand"
+	RiskRuleInvalidArg RiskRule = "invalidArg"
+)
+
+// RiskPolicy 是 SDK 内置的预交易风控策略（下单/改单/策略委托/市价全平，REST 与 WS 共用）。
+//
+// 约定：
+// - 所有检查均在网络 I/O 之前完成；拒单返回 *RiskRejectError，并计入 ClientStats。
+// - 字段为空/<=0 表示不启用该项检查。
+// - 批量请求为“全有或全无”：任一笔被拒，整批不发送。
+// - 数值字段保持为 string（无损）；非法配置会导致所有受控请求被拒（fail-closed）。
+type RiskPolicy struct {
+	// AllowedInstIds 为允许交易的产品白名单。
+	AllowedInstIds []string
+	// AllowedTdModes 为允许的交易模式（cash/cross/isolated/spot_isolated）；市价全平按 mgnMode 检查。
+	AllowedTdModes []string
+
+	// MaxOrderNotional 为单笔订单最大名义价值（计价币/USD 口径）。
+	// 合约需通过 InstrumentFunc 提供 ctVal，否则按 sz*px 估算；市价单需通过 PriceFunc 提供参考价，否则拒单。
+	// 现货市价买单未指定 tgtCcy 时按 OKX 默认 quote_ccy 处理（sz 即名义价值）。
+	// 改单只给出 newSz 时价格回退 PriceFunc；只给出 newPx 时需通过 OrderFunc 提供原订单数量，否则拒单。
+	MaxOrderNotional string
+	// MaxPosition 为单产品最大持仓（与下单 sz 同单位：币或张）；需通过 PositionFunc 提供当前持仓。
+	// 仅拒绝“使持仓绝对值增大且超过上限”的订单，reduceOnly 与平仓方向的订单不受限。
+	// 改单按 newSz 相对原订单数量的增量检查，原订单需通过 OrderFunc 提供，否则拒单。
+	MaxPosition string
+	// MaxOrdersPerSecond 为单产品每秒最大下单/改单次数（滑动 1s 窗口）。
+	MaxOrdersPerSecond int
+	// PriceBand 为价格带（防胖手指）检查：仅作用于带 px 的限价类订单（limit/post_only/fok/ioc 等，含批量与改单），
+	// 不作用于策略委托的委托价与平仓；改单需通过 OrderFunc 提供原订单方向，否则拒单；拒单时 Err 为 *PriceBandError。
+	// 止盈止损触发价（tpTriggerPx/slTriggerPx）按“提交即触发”的方向检查：止盈按订单方向，止损按反方向。
+	PriceBand *PriceBandGuard
+
+	// InstrumentFunc 返回产品信息（用于合约 ctVal 换算名义价值）。
+	InstrumentFunc func(instId string) (Instrument, bool)
+	// PriceFunc 返回产品参考价（用于市价单/策略单的名义价值估算）。
+	PriceFunc func(instId string) (string, bool)
+	// PositionFunc 返回当前持仓：posSide=net（或空）时为带符号净持仓，long/short 时为该方向持仓数量。
+	PositionFunc func(instId, posSide string) (string, bool)
+	// OrderFunc 返回改单目标挂单（用于补齐改单的 side 与原数量）；ordId 与 clOrdId 可能只给出其一。
+	OrderFunc func(instId, ordId, clOrdId string) (TradeOrder, bool)
+}
+
+// RiskRejectError 表示请求被预交易风控拒绝（未发出任何网络请求）。
+type RiskRejectError struct {
+	Rule RiskRule
+	// Op 表示被拦截的操作（REST 为 endpoint，WS 为 op）。
+	Op     string
+	InstId string
+	// Index 为批量请求中被拒订单的下标（单笔请求为 0）。
+	Index  int
+	Detail string
+	// Err 为底层原因（例如 *PriceBandError），可能为空。
+	Err error
+}
+
+func (e *RiskRejectError) Error() string {
+	if e == nil {
+		return "<OKX RiskRejectError>"
+	}
+	return fmt.Sprintf("okx: pre-trade risk reject rule=%s op=%s instId=%s index=%d: %s", e.Rule, e.Op, e.InstId, e.Index, e.Detail)
+}
+
+func (e *RiskRejectError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// WithPreTradeRisk 启用预交易风控（对 REST 与由该 Client 创建的 WSClient 同时生效）。
+func WithPreTradeRisk(policy RiskPolicy) Option {
+	g := newPreTradeRiskGuard(policy)
+	return func(c *Client) {
+		c.risk = g
+	}
+}
+
+// riskOrder 是风控检查所需的订单要素（各下单/改单入口统一映射到该结构）。
+type riskOrder struct {
+	instId  string
+	tdMode  string
+	side    string
+	posSide string
+	px      string
+	sz      string
+	tgtCcy  string
+	ordType string
+	// triggerPx 为策略委托触发价（委托价缺失或为市价时作为参考价）。
+	triggerPx string
+	// tp/sl 为止盈止损策略委托（conditional/oco）的触发价与委托价。
+	tpTriggerPx, tpOrdPx string
+	slTriggerPx, slOrdPx string
+	// ordId/clOrdId 为改单目标订单（用于 OrderFunc）。
+	ordId   string
+	clOrdId string
+	// origSz 为改单目标订单的原数量（由 OrderFunc 补齐，用于持仓检查）。
+	origSz string
+
+	reduceOnly bool
+	// market 为市价单（策略委托为触发后市价）。
+	market bool
+	// algo 为策略委托：不做价格带检查。
+	algo bool
+	// amend 为改单：side 与缺失的 newSz/newPx 由 OrderFunc/PriceFunc 补齐后检查；持仓按数量增量检查，不检查 tdMode。
+	amend bool
+	// closing 为平仓（市价全平/closeFraction）：仅检查白名单与频率。
+	closing bool
+}
+
+type preTradeRiskGuard struct {
+	policy RiskPolicy
+
+	allowedInstIds map[string]struct{}
+	allowedTdModes map[string]struct{}
+	maxNotional    *big.Rat
+	maxPosition    *big.Rat
+	policyErr      string
+
+	mu     sync.Mutex
+	events map[string][]time.Time
+}
+
+func newPreTradeRiskGuard(policy RiskPolicy) *preTradeRiskGuard {
+	g := &preTradeRiskGuard{
+		policy: policy,
+		events: make(map[string][]time.Time),
+	}
+	if len(policy.AllowedInstIds) > 0 {
+		g.allowedInstIds = make(map[string]struct{}, len(policy.AllowedInstIds))
+		for _, id := range policy.AllowedInstIds {
+			g.allowedInstIds[id] = struct{}{}
+		}
+	}
+	if len(policy.AllowedTdModes) > 0 {
+		g.allowedTdModes = make(map[string]struct{}, len(policy.AllowedTdModes))
+		for _, m := range policy.AllowedTdModes {
+			g.allowedTdModes[m] = struct{}{}
+		}
+	}
+	if policy.MaxOrderNotional != "" {
+		v, err := parseDecimal(policy.MaxOrderNotional)
+		if err != nil || v.Sign() <= 0 {
+			g.policyErr = fmt.Sprintf("invalid MaxOrderNotional %q", policy.MaxOrderNotional)
+		}
+		g.maxNotional = v
+	}
+	if policy.MaxPosition != "" {
+		v, err := parseDecimal(policy.MaxPosition)
+		if err != nil || v.Sign() <= 0 {
+			g.policyErr = fmt.Sprintf("invalid MaxPosition %q", policy.MaxPosition)
+		}
+		g.maxPosition = v
+	}
+	return g
+}
+
+// checkPreTradeRisk 在发送前对订单执行风控；未启用风控时直接放行。
+func (c *Client) checkPreTradeRisk(op string, orders ...riskOrder) error {
+	if c == nil || c.risk == nil {
+		return nil
+	}
+	now := time.Now()
+	if c.now != nil {
+		now = c.now()
+	}
+	if err := c.risk.check(op, now, orders); err != nil {
+		c.recordRiskReject(err)
+		return err
+	}
+	return nil
+}
+
+func (g *preTradeRiskGuard) check(op string, now time.Time, orders []riskOrder) *RiskRejectError {
+	reject := func(i int, rule RiskRule, detail string) *RiskRejectError {
+		return &RiskRejectError{Rule: rule, Op: op, InstId: orders[i].instId, Index: i, Detail: detail}
+	}
+
+	for i, o := range orders {
+		if g.policyErr != "" {
+			return reject(i, RiskRulePolicy, g.policyErr)
+		}
+		if g.allowedInstIds != nil {
+			if _, ok := g.allowedInstIds[o.instId]; !ok {
+				if o.instId == "" {
+					return reject(i, RiskRuleInstId, "instId required by allowlist")
+				}
+				return reject(i, RiskRuleInstId, "instId not allowed")
+			}
+		}
+		if g.allowedTdModes != nil && !o.amend {
+			if _, ok := g.allowedTdModes[o.tdMode]; !ok {
+				return reject(i, RiskRuleTdMode, fmt.Sprintf("tdMode %q not allowed", o.tdMode))
+			}
+		}
+		if o.closing {
+			continue
+		}
+		if o.amend {
+			o = g.resolveAmend(o)
+		}
+		if g.policy.PriceBand != nil && o.bandChecked() {
+			if o.side == "" {
+				return reject(i, RiskRulePriceBand, "order side unavailable")
+			}
+			if err := g.policy.PriceBand.Check(o.instId, o.side, o.px); err != nil {
+				rej := reject(i, RiskRulePriceBand, err.Error())
+				rej.Err = err
+				return rej
+			}
+		}
+		if g.policy.PriceBand != nil {
+			if err := g.checkTriggerBand(o); err != nil {
+				rej := reject(i, RiskRulePriceBand, err.Error())
+				rej.Err = err
+				return rej
+			}
+		}
+		if g.maxNotional != nil {
+			if rule, detail := g.checkNotional(o); rule != "" {
+				return reject(i, rule, detail)
+			}
+		}
+		if g.maxPosition != nil && !o.reduceOnly {
+			if o.amend {
+				var rule RiskRule
+				var detail string
+				if o, rule, detail = g.amendIncrease(o); rule != "" {
+					return reject(i, rule, detail)
+				}
+			}
+			if o.sz != "" {
+				if rule, detail := g.checkPosition(o); rule != "" {
+					return reject(i, rule, detail)
+				}
+			}
+		}
+	}
+
+	if g.policy.MaxOrdersPerSecond <= 0 {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// 先按产品汇总本次请求的笔数，确认不超限后再统一记账，保证批量请求“全有或全无”。
+	cutoff := now.Add(-time.Second)
+	pending := make(map[string]int, len(orders))
+	for i, o := range orders {
+		pending[o.instId]++
+		events := g.pruneLocked(o.instId, cutoff)
+		if len(events)+pending[o.instId] > g.policy.MaxOrdersPerSecond {
+			return reject(i, RiskRuleOrderRate, fmt.Sprintf("exceeds %d orders/s", g.policy.MaxOrdersPerSecond))
+		}
+	}
+	for _, o := range orders {
+		g.events[o.instId] = append(g.events[o.instId], now)
+	}
+	return nil
+}
+
+func (g *preTradeRiskGuard) pruneLocked(instId string, cutoff time.Time) []time.Time {
+	events := g.events[instId]
+	n := 0
+	for n < len(events) && !events[n].After(cutoff) {
+		n++
+	}
+	if n > 0 {
+		events = append(events[:0], events[n:]...)
+		g.events[instId] = events
+	}
+	return events
+}
+
+// checkNotional 返回拒单规则与原因（rule 为空表示通过）。
+func (g *preTradeRiskGuard) checkNotional(o riskOrder) (RiskRule, string) {
+	if o.amend {
+		if o.sz == "" && o.px == "" {
+			return "", ""
+		}
+		if o.sz == "" {
+			// 只改价：名义价值取决于原订单数量。
+			return RiskRuleNotional, "order size unavailable"
+		}
+	}
+	sz, err := parseDecimal(o.sz)
+	if err != nil {
+		return RiskRuleInvalidArg, fmt.Sprintf("invalid sz %q", o.sz)
+	}
+
+	var notional *big.Rat
+	if g.quoteSized(o) {
+		notional = sz
+	} else {
+		inst, hasInst := g.instrument(o.instId)
+		cv, err := contractConverterFor(inst, hasInst)
+		if err != nil {
+			return RiskRuleInvalidArg, fmt.Sprintf("invalid instrument: %v", err)
+		}
+		if cv != nil && cv.inverse {
+			// 币本位：ctVal 以 USD 计价，名义价值与价格无关。
+			notional = new(big.Rat).Mul(sz, cv.ctVal)
+		} else {
+			px, rule, detail := g.referencePrice(o, RiskRuleNotional)
+			if rule != "" {
+				return rule, detail
+			}
+			notional = new(big.Rat).Mul(sz, px)
+			if cv != nil {
+				notional.Mul(notional, cv.ctVal)
+			}
+		}
+	}
+
+	if notional.Cmp(g.maxNotional) > 0 {
+		return RiskRuleNotional, fmt.Sprintf("notional %s exceeds max %s", formatDecimal(notional, 8), g.policy.MaxOrderNotional)
+	}
+	return "", ""
+}
+
+// checkPosition 返回拒单规则与原因（rule 为空表示通过）。
+func (g *preTradeRiskGuard) checkPosition(o riskOrder) (RiskRule, string) {
+	sz, err := parseDecimal(o.sz)
+	if err != nil {
+		return RiskRuleInvalidArg, fmt.Sprintf("invalid sz %q", o.sz)
+	}
+	if g.quoteSized(o) {
+		px, rule, detail := g.referencePrice(o, RiskRulePosition)
+		if rule != "" {
+			return rule, detail
+		}
+		sz.Quo(sz, px)
+	}
+
+	current := new(big.Rat)
+	if g.policy.PositionFunc != nil {
+		if s, ok := g.policy.PositionFunc(o.instId, o.posSide); ok && s != "" {
+			v, err := parseDecimal(s)
+			if err != nil {
+				return RiskRulePosition, fmt.Sprintf("invalid current position %q", s)
+			}
+			current = v
+		}
+	}
+
+	delta := new(big.Rat).Set(sz)
+	switch {
+	case o.posSide == "short" && o.side == "buy", o.posSide != "short" && o.side == "sell":
+		delta.Neg(delta)
+	}
+	projected := new(big.Rat).Add(current, delta)
+
+	curAbs := new(big.Rat).Abs(current)
+	projAbs := new(big.Rat).Abs(projected)
+	if projAbs.Cmp(curAbs) > 0 && projAbs.Cmp(g.maxPosition) > 0 {
+		return RiskRulePosition, fmt.Sprintf("projected position %s exceeds max %s", formatDecimal(projected, 8), g.policy.MaxPosition)
+	}
+	return "", ""
+}
+
+// resolveAmend 通过 OrderFunc 补齐改单的方向与（未改的）数量。
+func (g *preTradeRiskGuard) resolveAmend(o riskOrder) riskOrder {
+	if g.policy.OrderFunc == nil {
+		return o
+	}
+	ord, ok := g.policy.OrderFunc(o.instId, o.ordId, o.clOrdId)
+	if !ok {
+		return o
+	}
+	o.side = ord.Side
+	o.posSide = ord.PosSide
+	o.tgtCcy = ord.TgtCcy
+	o.ordType = ord.OrdType
+	o.origSz = ord.Sz
+	o.reduceOnly = ord.ReduceOnly == "true"
+	if o.sz == "" && o.px != "" {
+		o.sz = ord.Sz
+	}
+	return o
+}
+
+// amendIncrease 将改单换算为数量增量（newSz - 原数量）以检查持仓；未改数量或数量未增大时 sz 为空（不检查）。
+func (g *preTradeRiskGuard) amendIncrease(o riskOrder) (riskOrder, RiskRule, string) {
+	if o.sz == "" || o.sz == o.origSz {
+		o.sz = ""
+		return o, "", ""
+	}
+	if o.origSz == "" || o.side == "" {
+		return o, RiskRulePosition, "order size unavailable"
+	}
+	newSz, err := parseDecimal(o.sz)
+	if err != nil {
+		return o, RiskRuleInvalidArg, fmt.Sprintf("invalid sz %q", o.sz)
+	}
+	origSz, err := parseDecimal(o.origSz)
+	if err != nil {
+		return o, RiskRulePosition, fmt.Sprintf("invalid order size %q", o.origSz)
+	}
+	inc := new(big.Rat).Sub(newSz, origSz)
+	if inc.Sign() <= 0 {
+		o.sz = ""
+		return o, "", ""
+	}
+	o.sz = inc.RatString()
+	return o, "", ""
+}
+
+// checkTriggerBand 检查止盈止损触发价：只拒绝“已越过当前价、提交即触发”且超出价格带的触发价，
+// 远离当前价的正常止损不受影响。止盈触发按订单方向检查，止损触发按反方向检查。
+func (g *preTradeRiskGuard) checkTriggerBand(o riskOrder) error {
+	if o.side != "buy" && o.side != "sell" {
+		return nil
+	}
+	opposite := "buy"
+	if o.side == "buy" {
+		opposite = "sell"
+	}
+	if o.tpTriggerPx != "" {
+		if err := g.policy.PriceBand.Check(o.instId, o.side, o.tpTriggerPx); err != nil {
+			return err
+		}
+	}
+	if o.slTriggerPx != "" {
+		if err := g.policy.PriceBand.Check(o.instId, opposite, o.slTriggerPx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bandChecked 报告订单是否受价格带约束：带 px 的限价类下单与改单，不含策略委托。
+func (o riskOrder) bandChecked() bool {
+	if o.algo || o.px == "" || o.px == "-1" {
+		return false
+	}
+	return o.amend || riskLimitOrdTypes[o.ordType]
+}
+
+var riskLimitOrdTypes = map[string]bool{
+	"limit":             true,
+	"post_only":         true,
+	"fok":               true,
+	"ioc":               true,
+	"mmp":               true,
+	"mmp_and_post_only": true,
+}
+
+// quoteSized 报告 sz 是否以计价币计：显式 tgtCcy=quote_ccy，或现货市价买单未指定 tgtCcy（OKX 默认 quote_ccy）。
+func (g *preTradeRiskGuard) quoteSized(o riskOrder) bool {
+	if o.tgtCcy != "" {
+		return o.tgtCcy == "quote_ccy"
+	}
+	if !o.market || o.side != "buy" {
+		return false
+	}
+	if inst, ok := g.instrument(o.instId); ok {
+		return inst.InstType == "SPOT" || inst.InstType == "MARGIN"
+	}
+	// 无产品信息时按 instId 形态判断：现货/杠杆为 BASE-QUOTE。
+	return strings.Count(o.instId, "-") == 1
+}
+
+func (g *preTradeRiskGuard) instrument(instId string) (Instrument, bool) {
+	if g.policy.InstrumentFunc == nil || instId == "" {
+		return Instrument{}, false
+	}
+	return g.policy.InstrumentFunc(instId)
+}
+
+// referencePrice 返回订单价格（缺失或为市价 "-1" 时依次回退触发价与 PriceFunc）；无法获取参考价时按 rule 拒单（fail-closed）。
+func (g *preTradeRiskGuard) referencePrice(o riskOrder, rule RiskRule) (*big.Rat, RiskRule, string) {
+	s := o.px
+	if s == "-1" {
+		s = ""
+	}
+	if s == "" {
+		s = o.triggerPx
+	}
+	if s == "" {
+		s = maxDecimalString(riskLegPx(o.tpOrdPx, o.tpTriggerPx), riskLegPx(o.slOrdPx, o.slTriggerPx))
+	}
+	if s == "" && g.policy.PriceFunc != nil && o.instId != "" {
+		s, _ = g.policy.PriceFunc(o.instId)
+	}
+	if s == "" {
+		return nil, rule, "reference price unavailable"
+	}
+	px, err := parseDecimal(s)
+	if err != nil || px.Sign() <= 0 {
+		return nil, RiskRuleInvalidArg, fmt.Sprintf("invalid px %q", s)
+	}
+	return px, "", ""
+}
+
+// riskLegPx 返回止盈/止损腿的参考价：限价委托取委托价，市价（-1 或未设置）取触发价。
+func riskLegPx(ordPx, triggerPx string) string {
+	if ordPx != "" && ordPx != "-1" {
+		return ordPx
+	}
+	return triggerPx
+}
+
+// maxDecimalString 返回两个十进制字符串中较大者（空串视为缺失）。
+func maxDecimalString(a, b string) string {
+	if a == "" || (b != "" && compareDecimalString(b, a) > 0) {
+		return b
+	}
+	return a
+}
+
+func contractConverterFor(inst Instrument, ok bool) (*ContractCoinConverter, error) {
+	if !ok {
+		return nil, nil
+	}
+	switch inst.InstType {
+	case "SWAP", "FUTURES", "OPTION":
+		return NewContractCoinConverter(inst)
+	}
+	return nil, nil
+}
+
+func riskOrderFromBatchPlace(o BatchPlaceOrder) riskOrder {
+	return riskOrder{
+		instId:     o.InstId,
+		tdMode:     o.TdMode,
+		side:       o.Side,
+		posSide:    o.PosSide,
+		px:         o.Px,
+		sz:         o.Sz,
+		tgtCcy:     o.TgtCcy,
+		ordType:    o.OrdType,
+		reduceOnly: o.ReduceOnly != nil && *o.ReduceOnly,
+		market:     o.OrdType == "market",
+	}
+}
+
+func riskOrderFromBatchAmend(o BatchAmendOrder) riskOrder {
+	return riskOrder{instId: o.InstId, ordId: o.OrdId, clOrdId: o.ClOrdId, px: o.NewPx, sz: o.NewSz, amend: true}
+}
+
+func riskOrderFromWSPlace(arg WSPlaceOrderArg) riskOrder {
+	return riskOrder{
+		instId:     arg.InstId,
+		tdMode:     arg.TdMode,
+		side:       arg.Side,
+		posSide:    arg.PosSide,
+		px:         arg.Px,
+		sz:         arg.Sz,
+		tgtCcy:     arg.TgtCcy,
+		ordType:    arg.OrdType,
+		reduceOnly: arg.ReduceOnly != nil && *arg.ReduceOnly,
+		market:     arg.OrdType == "market",
+	}
+}
+
+func riskOrderFromWSAmend(arg WSAmendOrderArg) riskOrder {
+	return riskOrder{instId: arg.InstId, ordId: arg.OrdId, clOrdId: arg.ClOrdId, px: arg.NewPx, sz: arg.NewSz, amend: true}
+}
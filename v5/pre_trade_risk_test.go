@@ -0,0 +1,337 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newPreTradeRiskTestClient(t *testing.T, policy RiskPolicy, opts ...Option) (*Client, *atomic.Int64) {
+	t.Helper()
+
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v5/trade/batch-orders":
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"ordId":"1","clOrdId":"","tag":"","sCode":"0","sMsg":""},{"ordId":"2","clOrdId":"","tag":"","sCode":"0","sMsg":""}]}`))
+		case "/api/v5/trade/close-position":
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT","posSide":"","clOrdId":"","tag":""}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"ordId":"1","clOrdId":"","tag":"","sCode":"0","sMsg":""}]}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	base := []Option{
+		WithBaseURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+		WithCredentials(Credentials{APIKey: "k", SecretKey: "s", Passphrase: "p"}),
+		WithRequestGateDisabled(),
+		WithPreTradeRisk(policy),
+	}
+	return NewClient(append(base, opts...)...), &calls
+}
+
+func requireRiskReject(t *testing.T, err error, rule RiskRule) *RiskRejectError {
+	t.Helper()
+	var riskErr *RiskRejectError
+	if !errors.As(err, &riskErr) {
+		t.Fatalf("error = %T %v, want *RiskRejectError", err, err)
+	}
+	if riskErr.Rule != rule {
+		t.Fatalf("Rule = %q, want %q (%v)", riskErr.Rule, rule, riskErr)
+	}
+	return riskErr
+}
+
+func TestPreTradeRisk_AllowlistAndTdMode(t *testing.T) {
+	c, calls := newPreTradeRiskTestClient(t, RiskPolicy{
+		AllowedInstIds: []string{"BTC-USDT"},
+		AllowedTdModes: []string{"cash"},
+	})
+
+	_, err := c.NewPlaceOrderService().InstId("ETH-USDT").TdMode("cash").Side("buy").OrdType("limit").Px("1").Sz("1").Do(context.Background())
+	requireRiskReject(t, err, RiskRuleInstId)
+
+	_, err = c.NewPlaceOrderService().InstId("BTC-USDT").TdMode("cross").Side("buy").OrdType("limit").Px("1").Sz("1").Do(context.Background())
+	requireRiskReject(t, err, RiskRuleTdMode)
+
+	if got := calls.Load(); got != 0 {
+		t.Fatalf("http calls = %d, want 0", got)
+	}
+
+	if _, err := c.NewPlaceOrderService().InstId("BTC-USDT").TdMode("cash").Side("buy").OrdType("limit").Px("1").Sz("1").Do(context.Background()); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("http calls = %d, want 1", got)
+	}
+
+	st := c.ClientStats()
+	if st.RiskRejectTotal != 2 {
+		t.Fatalf("RiskRejectTotal = %d, want 2", st.RiskRejectTotal)
+	}
+	if st.RiskRejectCounts[string(RiskRuleInstId)] != 1 || st.RiskRejectCounts[string(RiskRuleTdMode)] != 1 {
+		t.Fatalf("RiskRejectCounts = %#v", st.RiskRejectCounts)
+	}
+	if st.FailureTotal != 0 {
+		t.Fatalf("FailureTotal = %d, want 0", st.FailureTotal)
+	}
+}
+
+func TestPreTradeRisk_MaxOrderNotional(t *testing.T) {
+	instruments := map[string]Instrument{
+		"BTC-USDT-SWAP": {InstType: "SWAP", InstId: "BTC-USDT-SWAP", CtVal: "0.01", CtValCcy: "BTC", CtType: "linear", LotSz: "0.01"},
+		"BTC-USD-SWAP":  {InstType: "SWAP", InstId: "BTC-USD-SWAP", CtVal: "100", CtValCcy: "USD", CtType: "inverse", LotSz: "1"},
+	}
+	c, calls := newPreTradeRiskTestClient(t, RiskPolicy{
+		MaxOrderNotional: "10000",
+		InstrumentFunc: func(instId string) (Instrument, bool) {
+			inst, ok := instruments[instId]
+			return inst, ok
+		},
+		PriceFunc: func(instId string) (string, bool) {
+			if instId == "BTC-USDT" {
+				return "50000", true
+			}
+			return "", false
+		},
+		OrderFunc: func(instId, ordId, clOrdId string) (TradeOrder, bool) {
+			if ordId == "2" {
+				return TradeOrder{InstId: instId, OrdId: ordId, Side: "buy", OrdType: "limit", Sz: "0.1"}, true
+			}
+			return TradeOrder{}, false
+		},
+	})
+	ctx := context.Background()
+
+	// spot：0.3 * 50000 = 15000 > 10000
+	_, err := c.NewPlaceOrderService().InstId("BTC-USDT").TdMode("cash").Side("buy").OrdType("limit").Px("50000").Sz("0.3").Do(ctx)
+	requireRiskReject(t, err, RiskRuleNotional)
+
+	// spot 市价卖单：回退 PriceFunc。
+	_, err = c.NewPlaceOrderService().InstId("BTC-USDT").TdMode("cash").Side("sell").OrdType("market").Sz("0.3").Do(ctx)
+	requireRiskReject(t, err, RiskRuleNotional)
+
+	// spot 市价买单未指定 tgtCcy：OKX 默认 quote_ccy，sz 即名义价值。
+	_, err = c.NewPlaceOrderService().InstId("BTC-USDT").TdMode("cash").Side("buy").OrdType("market").Sz("15000").Do(ctx)
+	requireRiskReject(t, err, RiskRuleNotional)
+
+	// spot 市价单 tgtCcy=quote_ccy：sz 即名义价值。
+	if _, err := c.NewPlaceOrderService().InstId("BTC-USDT").TdMode("cash").Side("buy").OrdType("market").TgtCcy("quote_ccy").Sz("9000").Do(ctx); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	// linear：15 张 * 0.01 * 50000 = 7500 <= 10000
+	if _, err := c.NewPlaceOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("buy").OrdType("limit").Px("50000").Sz("15").Do(ctx); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	// linear：25 张 -> 12500 > 10000
+	_, err = c.NewPlaceOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("buy").OrdType("limit").Px("50000").Sz("25").Do(ctx)
+	requireRiskReject(t, err, RiskRuleNotional)
+
+	// inverse：101 张 * 100 USD = 10100 > 10000（市价单也无需参考价）。
+	_, err = c.NewPlaceOrderService().InstId("BTC-USD-SWAP").TdMode("cross").Side("buy").OrdType("market").Sz("101").Do(ctx)
+	requireRiskReject(t, err, RiskRuleNotional)
+
+	// 无参考价的市价单：fail-closed。
+	rej := requireRiskReject(t, func() error {
+		_, err := c.NewPlaceOrderService().InstId("ETH-USDT").TdMode("cash").Side("sell").OrdType("market").Sz("1").Do(ctx)
+		return err
+	}(), RiskRuleNotional)
+	if rej.Op != "/api/v5/trade/order" || rej.InstId != "ETH-USDT" {
+		t.Fatalf("reject = %#v", rej)
+	}
+
+	// 改单：同时给出 newSz/newPx 时检查名义价值。
+	_, err = c.NewAmendOrderService().InstId("BTC-USDT").OrdId("1").NewSz("1").NewPx("50000").Do(ctx)
+	requireRiskReject(t, err, RiskRuleNotional)
+	// 改单只给出 newSz：价格回退 PriceFunc。
+	_, err = c.NewAmendOrderService().InstId("BTC-USDT").OrdId("1").NewSz("1").Do(ctx)
+	requireRiskReject(t, err, RiskRuleNotional)
+	if _, err := c.NewAmendOrderService().InstId("BTC-USDT").OrdId("1").NewSz("0.1").Do(ctx); err != nil {
+		t.Fatalf("amend Do() error = %v", err)
+	}
+	// 改单只给出 newPx：原订单数量来自 OrderFunc，无法获取时拒单。
+	_, err = c.NewAmendOrderService().InstId("BTC-USDT").OrdId("1").NewPx("50000").Do(ctx)
+	requireRiskReject(t, err, RiskRuleNotional)
+	if _, err := c.NewAmendOrderService().InstId("BTC-USDT").OrdId("2").NewPx("50000").Do(ctx); err != nil {
+		t.Fatalf("amend Do() error = %v", err)
+	}
+	_, err = c.NewAmendOrderService().InstId("BTC-USDT").OrdId("2").NewPx("150000").Do(ctx)
+	requireRiskReject(t, err, RiskRuleNotional)
+
+	// 计划委托 orderPx=-1（触发后市价）：按触发价估算名义价值。
+	_, err = c.NewPlaceAlgoOrderService().InstId("BTC-USDT").TdMode("cash").Side("sell").OrdType("trigger").
+		TriggerPx("50000").OrderPx("-1").Sz("0.3").Do(ctx)
+	requireRiskReject(t, err, RiskRuleNotional)
+	if _, err := c.NewPlaceAlgoOrderService().InstId("BTC-USDT").TdMode("cash").Side("sell").OrdType("trigger").
+		TriggerPx("40000").OrderPx("-1").Sz("0.2").Do(ctx); err != nil {
+		t.Fatalf("algo Do() error = %v", err)
+	}
+	// 止盈止损（市价）：按较高的腿触发价估算名义价值（22 张 * 0.01 * 50000 = 11000）。
+	_, err = c.NewPlaceAlgoOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("sell").OrdType("oco").
+		TpTriggerPx("50000").TpOrdPx("-1").SlTriggerPx("40000").SlOrdPx("-1").Sz("22").Do(ctx)
+	requireRiskReject(t, err, RiskRuleNotional)
+	if _, err := c.NewPlaceAlgoOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("sell").OrdType("oco").
+		TpTriggerPx("50000").TpOrdPx("-1").SlTriggerPx("40000").SlOrdPx("-1").Sz("15").Do(ctx); err != nil {
+		t.Fatalf("algo Do() error = %v", err)
+	}
+
+	if got := calls.Load(); got != 6 {
+		t.Fatalf("http calls = %d, want 6", got)
+	}
+}
+
+func TestPreTradeRisk_MaxPosition(t *testing.T) {
+	c, calls := newPreTradeRiskTestClient(t, RiskPolicy{
+		MaxPosition: "10",
+		OrderFunc: func(instId, ordId, clOrdId string) (TradeOrder, bool) {
+			if ordId == "1" {
+				return TradeOrder{InstId: instId, OrdId: ordId, Side: "buy", PosSide: "long", OrdType: "limit", Sz: "1"}, true
+			}
+			return TradeOrder{}, false
+		},
+		PositionFunc: func(instId, posSide string) (string, bool) {
+			switch posSide {
+			case "long":
+				return "8", true
+			case "short":
+				return "0", true
+			}
+			return "-8", true
+		},
+	})
+	ctx := context.Background()
+
+	// net：-8 - 3 = -11，绝对值增大且超限。
+	_, err := c.NewPlaceOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("sell").OrdType("limit").Px("1").Sz("3").Do(ctx)
+	requireRiskReject(t, err, RiskRulePosition)
+
+	// net：-8 + 15 = 7，绝对值减小。
+	if _, err := c.NewPlaceOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("buy").OrdType("limit").Px("1").Sz("15").Do(ctx); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	// long：8 + 3 = 11 超限；卖出 long 为平仓方向。
+	_, err = c.NewPlaceOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("buy").PosSide("long").OrdType("limit").Px("1").Sz("3").Do(ctx)
+	requireRiskReject(t, err, RiskRulePosition)
+	if _, err := c.NewPlaceOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("sell").PosSide("long").OrdType("limit").Px("1").Sz("3").Do(ctx); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	// reduceOnly 不受持仓上限约束。
+	if _, err := c.NewPlaceOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("sell").OrdType("limit").Px("1").Sz("30").ReduceOnly(true).Do(ctx); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	// 策略委托：按 sz 检查。
+	_, err = c.NewPlaceAlgoOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("sell").OrdType("conditional").Sz("3").Do(ctx)
+	requireRiskReject(t, err, RiskRulePosition)
+
+	// 改单按数量增量检查：long 8 + (3-1) = 10 未超限；8 + (4-1) = 11 超限。
+	if _, err := c.NewAmendOrderService().InstId("BTC-USDT-SWAP").OrdId("1").NewSz("3").Do(ctx); err != nil {
+		t.Fatalf("amend Do() error = %v", err)
+	}
+	_, err = c.NewAmendOrderService().InstId("BTC-USDT-SWAP").OrdId("1").NewSz("4").Do(ctx)
+	requireRiskReject(t, err, RiskRulePosition)
+	// 只改价不影响持仓；原订单未知时改数量拒单。
+	if _, err := c.NewAmendOrderService().InstId("BTC-USDT-SWAP").OrdId("1").NewPx("2").Do(ctx); err != nil {
+		t.Fatalf("amend Do() error = %v", err)
+	}
+	_, err = c.NewAmendOrderService().InstId("BTC-USDT-SWAP").OrdId("9").NewSz("1").Do(ctx)
+	requireRiskReject(t, err, RiskRulePosition)
+
+	if got := calls.Load(); got != 5 {
+		t.Fatalf("http calls = %d, want 5", got)
+	}
+}
+
+func TestPreTradeRisk_OrderRateAndBatch(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	c, calls := newPreTradeRiskTestClient(t, RiskPolicy{MaxOrdersPerSecond: 2}, WithNowFunc(func() time.Time { return now }))
+	ctx := context.Background()
+
+	orders := []BatchPlaceOrder{
+		{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Px: "1", Sz: "1"},
+		{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Px: "1", Sz: "1"},
+	}
+	if _, err := c.NewBatchPlaceOrdersService().Orders(orders).Do(ctx); err != nil {
+		t.Fatalf("batch Do() error = %v", err)
+	}
+
+	// 同一秒内第 3 笔：拒绝。
+	_, err := c.NewAmendOrderService().InstId("BTC-USDT").OrdId("1").NewSz("2").Do(ctx)
+	requireRiskReject(t, err, RiskRuleOrderRate)
+
+	// 其他产品独立计数；批量中任一笔被拒则整批不发送且不占用额度。
+	mixed := []BatchPlaceOrder{
+		{InstId: "ETH-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Px: "1", Sz: "1"},
+		{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Px: "1", Sz: "1"},
+	}
+	_, err = c.NewBatchPlaceOrdersService().Orders(mixed).Do(ctx)
+	if rej := requireRiskReject(t, err, RiskRuleOrderRate); rej.Index != 1 || rej.InstId != "BTC-USDT" {
+		t.Fatalf("reject = %#v", rej)
+	}
+
+	now = now.Add(1500 * time.Millisecond)
+	if _, err := c.NewClosePositionsService().InstId("BTC-USDT").MgnMode("cross").Do(ctx); err != nil {
+		t.Fatalf("close Do() error = %v", err)
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("http calls = %d, want 2", got)
+	}
+}
+
+func TestPreTradeRisk_InvalidPolicyFailsClosed(t *testing.T) {
+	c, calls := newPreTradeRiskTestClient(t, RiskPolicy{MaxOrderNotional: "abc"})
+
+	_, err := c.NewClosePositionsService().InstId("BTC-USDT-SWAP").MgnMode("cross").Do(context.Background())
+	requireRiskReject(t, err, RiskRulePolicy)
+	if got := calls.Load(); got != 0 {
+		t.Fatalf("http calls = %d, want 0", got)
+	}
+}
+
+func TestPreTradeRisk_WSOps(t *testing.T) {
+	c := NewClient(
+		WithCredentials(Credentials{APIKey: "k", SecretKey: "s", Passphrase: "p"}),
+		WithPreTradeRisk(RiskPolicy{AllowedInstIds: []string{"BTC-USDT"}, MaxOrderNotional: "100"}),
+	)
+	w := c.NewWSPrivate()
+	ctx := context.Background()
+
+	_, err := w.PlaceOrder(ctx, WSPlaceOrderArg{InstId: "ETH-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Px: "1", Sz: "1"})
+	if rej := requireRiskReject(t, err, RiskRuleInstId); rej.Op != wsOpOrder {
+		t.Fatalf("Op = %q, want %q", rej.Op, wsOpOrder)
+	}
+
+	// 仅提供 instIdCode 时无法满足白名单。
+	_, err = w.PlaceOrder(ctx, WSPlaceOrderArg{InstIdCode: 1, TdMode: "cash", Side: "buy", OrdType: "limit", Px: "1", Sz: "1"})
+	requireRiskReject(t, err, RiskRuleInstId)
+
+	_, err = w.PlaceOrders(ctx,
+		WSPlaceOrderArg{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Px: "10", Sz: "1"},
+		WSPlaceOrderArg{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Px: "10", Sz: "20"},
+	)
+	if rej := requireRiskReject(t, err, RiskRuleNotional); rej.Index != 1 {
+		t.Fatalf("Index = %d, want 1", rej.Index)
+	}
+
+	_, err = w.AmendOrder(ctx, WSAmendOrderArg{InstId: "BTC-USDT", OrdId: "1", NewSz: "20", NewPx: "10"})
+	requireRiskReject(t, err, RiskRuleNotional)
+
+	_, err = w.AmendOrders(ctx, WSAmendOrderArg{InstId: "ETH-USDT", OrdId: "1", NewSz: "1"})
+	requireRiskReject(t, err, RiskRuleInstId)
+
+	if st := c.ClientStats(); st.RiskRejectTotal != 5 {
+		t.Fatalf("RiskRejectTotal = %d, want 5", st.RiskRejectTotal)
+	}
+}
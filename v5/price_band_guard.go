@@ -0,0 +1,367 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// PriceBandReason 表示价格带拒单原因。
+type PriceBandReason string
+
+const (
+	PriceBandReasonBuyLmt  PriceBandReason = "buyLmt"
+	PriceBandReasonSellLmt PriceBandReason = "sellLmt"
+	PriceBandReasonMark    PriceBandReason = "mark"
+	PriceBandReasonBBO     PriceBandReason = "bbo"
+	// PriceBandReasonMissing 表示缺少（或已过期的）参考数据（仅在 RejectOnMissing=true 时返回）。
+	PriceBandReasonMissing PriceBandReason = "missing"
+	PriceBandReasonInvalid PriceBandReason = "invalid"
+)
+
+// PriceBandConfig 是价格带（防胖手指）检查配置。
+//
+// 约定：
+// - 字段为 0 表示不启用该项检查；price-limit（buyLmt/sellLmt）始终检查（DisablePriceLimit=true 时不检查）。
+// - 各参考数据源缺失（从未收到或已过期）时规则一致：RejectOnMissing=true 拒单，否则跳过该项检查。
+// - 默认仅检查“不利方向”：买价过高/卖价过低；Symmetric=true 时双向检查。
+type PriceBandConfig struct {
+	// MaxMarkDeviationBps 为委托价相对标记价格的最大偏离（bps）。
+	MaxMarkDeviationBps float64
+	// MaxBBODeviationBps 为委托价相对最优价的最大偏离（bps；买单对比 ask，卖单对比 bid）。
+	MaxBBODeviationBps float64
+	// Symmetric 为 true 时双向检查偏离（买价过低/卖价过高同样拒绝）。
+	Symmetric bool
+	// DisablePriceLimit 为 true 时不检查 price-limit（例如未接入该数据源且启用了 RejectOnMissing）。
+	DisablePriceLimit bool
+
+	// MaxAge 为参考数据的最大有效期（按本地接收时间；0 表示不过期）。
+	MaxAge time.Duration
+	// RejectOnMissing 为 true 时，缺少（或已过期）参考数据的订单会被拒绝（fail-closed）。
+	RejectOnMissing bool
+}
+
+// PriceBandError 表示委托价超出价格带（可通过 errors.As 从 *RiskRejectError 中取出）。
+type PriceBandError struct {
+	InstId string
+	Side   string
+	Px     string
+
+	Reason PriceBandReason
+	// Ref 为参考价（buyLmt/sellLmt/markPx/bid/ask）。
+	Ref string
+	// Bound 为允许的价格边界。
+	Bound string
+	// DeviationBps 为委托价相对 Ref 的偏离（bps，带符号）。
+	DeviationBps float64
+}
+
+func (e *PriceBandError) Error() string {
+	if e == nil {
+		return "<OKX PriceBandError>"
+	}
+	if e.Reason == PriceBandReasonMissing || e.Reason == PriceBandReasonInvalid {
+		return fmt.Sprintf("okx: price band reject instId=%s side=%s px=%s reason=%s", e.InstId, e.Side, e.Px, e.Reason)
+	}
+	return fmt.Sprintf("okx: price band reject instId=%s side=%s px=%s reason=%s ref=%s bound=%s deviationBps=%.2f", e.InstId, e.Side, e.Px, e.Reason, e.Ref, e.Bound, e.DeviationBps)
+}
+
+// PriceBandGuard 维护每个产品的 price-limit / mark-price / 最优买卖价，并据此检查限价单委托价（并发安全）。
+//
+// 数据来源（任选组合）：
+// - WS：WSOptions() 返回的 handler（或在自有 handler 中调用 OnPriceLimit/OnMarkPrice/OnTicker），并订阅 WSArgs() 频道；
+// - REST：Seed() 拉取一次快照；
+// - 其他：UpdateBBO()（例如由本地订单簿驱动）。
+//
+// 接入风控：设置 RiskPolicy.PriceBand 后，下单/策略委托会在网络 I/O 前执行 Check。
+type PriceBandGuard struct {
+	cfg PriceBandConfig
+	now func() time.Time
+
+	mu    sync.RWMutex
+	state map[string]*priceBandState
+}
+
+type priceBandState struct {
+	buyLmt, sellLmt string
+	limitAt         time.Time
+
+	markPx string
+	markAt time.Time
+
+	bidPx, askPx string
+	bboAt        time.Time
+}
+
+// NewPriceBandGuard 创建 PriceBandGuard。
+func NewPriceBandGuard(cfg PriceBandConfig) *PriceBandGuard {
+	if cfg.MaxMarkDeviationBps < 0 {
+		cfg.MaxMarkDeviationBps = 0
+	}
+	if cfg.MaxBBODeviationBps < 0 {
+		cfg.MaxBBODeviationBps = 0
+	}
+	if cfg.MaxAge < 0 {
+		cfg.MaxAge = 0
+	}
+	return &PriceBandGuard{
+		cfg:   cfg,
+		now:   time.Now,
+		state: make(map[string]*priceBandState),
+	}
+}
+
+func (g *PriceBandGuard) stateLocked(instId string) *priceBandState {
+	st := g.state[instId]
+	if st == nil {
+		st = &priceBandState{}
+		g.state[instId] = st
+	}
+	return st
+}
+
+// OnPriceLimit 更新 price-limit（可直接作为 WithWSPriceLimitHandler 的回调）。
+func (g *PriceBandGuard) OnPriceLimit(limit PriceLimit) {
+	if g == nil || limit.InstId == "" {
+		return
+	}
+	now := g.now()
+	g.mu.Lock()
+	st := g.stateLocked(limit.InstId)
+	st.buyLmt = limit.BuyLmt
+	// enabled=false 表示该产品当前不限价（此时 buyLmt/sellLmt 为空，检查自然跳过）。
+	st.sellLmt = limit.SellLmt
+	st.limitAt = now
+	g.mu.Unlock()
+}
+
+// OnMarkPrice 更新标记价格（可直接作为 WithWSMarkPriceHandler 的回调）。
+func (g *PriceBandGuard) OnMarkPrice(price MarkPrice) {
+	if g == nil || price.InstId == "" || price.MarkPx == "" {
+		return
+	}
+	now := g.now()
+	g.mu.Lock()
+	st := g.stateLocked(price.InstId)
+	st.markPx = price.MarkPx
+	st.markAt = now
+	g.mu.Unlock()
+}
+
+// OnTicker 更新最优买卖价（可直接作为 WithWSTickersHandler 的回调）。
+func (g *PriceBandGuard) OnTicker(ticker MarketTicker) {
+	if g == nil {
+		return
+	}
+	g.UpdateBBO(ticker.InstId, ticker.BidPx, ticker.AskPx)
+}
+
+// UpdateBBO 更新最优买卖价（例如由本地订单簿驱动）。
+func (g *PriceBandGuard) UpdateBBO(instId, bidPx, askPx string) {
+	if g == nil || instId == "" || (bidPx == "" && askPx == "") {
+		return
+	}
+	now := g.now()
+	g.mu.Lock()
+	st := g.stateLocked(instId)
+	st.bidPx = bidPx
+	st.askPx = askPx
+	st.bboAt = now
+	g.mu.Unlock()
+}
+
+// WSOptions 返回用于保持数据实时更新的 WS handler 选项（price-limit / mark-price / tickers）。
+// 已设置的 handler 不会被替换：guard 先更新数据，再调用原 handler；请将这些选项放在自定义 handler 选项之后。
+func (g *PriceBandGuard) WSOptions() []WSOption {
+	return []WSOption{
+		func(c *WSClient) {
+			c.typedMu.Lock()
+			defer c.typedMu.Unlock()
+			c.priceLimitHandler = chainPriceBandHandler(g.OnPriceLimit, c.priceLimitHandler)
+		},
+		func(c *WSClient) {
+			c.typedMu.Lock()
+			defer c.typedMu.Unlock()
+			c.markPriceHandler = chainPriceBandHandler(g.OnMarkPrice, c.markPriceHandler)
+		},
+		func(c *WSClient) {
+			c.typedMu.Lock()
+			defer c.typedMu.Unlock()
+			c.tickersHandler = chainPriceBandHandler(g.OnTicker, c.tickersHandler)
+		},
+	}
+}
+
+// chainPriceBandHandler 返回先调用 guard 再调用原 handler（可能为 nil）的回调。
+func chainPriceBandHandler[T any](guard, prev func(T)) func(T) {
+	if prev == nil {
+		return guard
+	}
+	return func(v T) {
+		guard(v)
+		prev(v)
+	}
+}
+
+// WSArgs 返回需要订阅的 public 频道参数（price-limit / mark-price / tickers）。
+func (g *PriceBandGuard) WSArgs(instIds ...string) []WSArg {
+	args := make([]WSArg, 0, len(instIds)*3)
+	for _, id := range instIds {
+		args = append(args,
+			WSArg{Channel: WSChannelPriceLimit, InstId: id},
+			WSArg{Channel: WSChannelMarkPrice, InstId: id},
+			WSArg{Channel: WSChannelTickers, InstId: id},
+		)
+	}
+	return args
+}
+
+// Seed 通过 REST 拉取一次 price-limit / 行情（以及非 SPOT 的 mark-price）快照。
+func (g *PriceBandGuard) Seed(ctx context.Context, c *Client, instType, instId string) error {
+	if g == nil || c == nil {
+		return fmt.Errorf("okx: price band seed requires guard and client")
+	}
+	limits, err := c.NewPublicPriceLimitService().InstId(instId).Do(ctx)
+	if err != nil {
+		return err
+	}
+	for _, l := range limits {
+		g.OnPriceLimit(l)
+	}
+
+	ticker, err := c.NewMarketTickerService().InstId(instId).Do(ctx)
+	if err != nil {
+		return err
+	}
+	g.OnTicker(*ticker)
+
+	if instType == "" || instType == "SPOT" {
+		return nil
+	}
+	marks, err := c.NewPublicMarkPriceService().InstType(instType).InstId(instId).Do(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range marks {
+		g.OnMarkPrice(m)
+	}
+	return nil
+}
+
+// Check 检查限价单委托价是否落在价格带内；通过返回 nil，否则返回 *PriceBandError。
+func (g *PriceBandGuard) Check(instId, side, px string) error {
+	if g == nil {
+		return nil
+	}
+	reject := func(reason PriceBandReason, ref, bound string, dev float64) error {
+		return &PriceBandError{InstId: instId, Side: side, Px: px, Reason: reason, Ref: ref, Bound: bound, DeviationBps: dev}
+	}
+	if side != "buy" && side != "sell" {
+		return reject(PriceBandReasonInvalid, "", "", 0)
+	}
+	price, err := parseDecimal(px)
+	if err != nil || price.Sign() <= 0 {
+		return reject(PriceBandReasonInvalid, "", "", 0)
+	}
+	buy := side == "buy"
+
+	now := g.now()
+	g.mu.RLock()
+	var st priceBandState
+	if p := g.state[instId]; p != nil {
+		st = *p
+	}
+	g.mu.RUnlock()
+
+	fresh := func(at time.Time) bool {
+		return !at.IsZero() && (g.cfg.MaxAge <= 0 || now.Sub(at) <= g.cfg.MaxAge)
+	}
+
+	// 1) 交易所限价：买价不得高于 buyLmt，卖价不得低于 sellLmt。
+	if !g.cfg.DisablePriceLimit {
+		if fresh(st.limitAt) {
+			if buy && st.buyLmt != "" {
+				if lmt, err := parseDecimal(st.buyLmt); err == nil && price.Cmp(lmt) > 0 {
+					return reject(PriceBandReasonBuyLmt, st.buyLmt, st.buyLmt, deviationBps(price, lmt))
+				}
+			}
+			if !buy && st.sellLmt != "" {
+				if lmt, err := parseDecimal(st.sellLmt); err == nil && price.Cmp(lmt) < 0 {
+					return reject(PriceBandReasonSellLmt, st.sellLmt, st.sellLmt, deviationBps(price, lmt))
+				}
+			}
+		} else if g.cfg.RejectOnMissing {
+			return reject(PriceBandReasonMissing, "", "", 0)
+		}
+	}
+
+	// 2) 标记价格偏离。
+	if g.cfg.MaxMarkDeviationBps > 0 {
+		if fresh(st.markAt) {
+			if mark, err := parseDecimal(st.markPx); err == nil && mark.Sign() > 0 {
+				if bound, ok := g.withinBand(price, mark, buy, g.cfg.MaxMarkDeviationBps); !ok {
+					return reject(PriceBandReasonMark, st.markPx, bound, deviationBps(price, mark))
+				}
+			}
+		} else if g.cfg.RejectOnMissing {
+			return reject(PriceBandReasonMissing, "", "", 0)
+		}
+	}
+
+	// 3) 最优价偏离：买单对比 ask，卖单对比 bid。
+	if g.cfg.MaxBBODeviationBps > 0 {
+		refPx := st.bidPx
+		if buy {
+			refPx = st.askPx
+		}
+		if fresh(st.bboAt) && refPx != "" {
+			if ref, err := parseDecimal(refPx); err == nil && ref.Sign() > 0 {
+				if bound, ok := g.withinBand(price, ref, buy, g.cfg.MaxBBODeviationBps); !ok {
+					return reject(PriceBandReasonBBO, refPx, bound, deviationBps(price, ref))
+				}
+			}
+		} else if g.cfg.RejectOnMissing {
+			return reject(PriceBandReasonMissing, "", "", 0)
+		}
+	}
+	return nil
+}
+
+// withinBand 判断 price 是否在 ref±bps 内；不通过时返回被突破的边界。
+func (g *PriceBandGuard) withinBand(price, ref *big.Rat, buy bool, bps float64) (string, bool) {
+	band := new(big.Rat).SetFloat64(bps / 10000)
+	if band == nil {
+		return "", true
+	}
+	upper := new(big.Rat).Mul(ref, new(big.Rat).Add(big.NewRat(1, 1), band))
+	lower := new(big.Rat).Mul(ref, new(big.Rat).Sub(big.NewRat(1, 1), band))
+
+	if buy {
+		if price.Cmp(upper) > 0 {
+			return formatDecimal(upper, 8), false
+		}
+		if g.cfg.Symmetric && price.Cmp(lower) < 0 {
+			return formatDecimal(lower, 8), false
+		}
+		return "", true
+	}
+	if price.Cmp(lower) < 0 {
+		return formatDecimal(lower, 8), false
+	}
+	if g.cfg.Symmetric && price.Cmp(upper) > 0 {
+		return formatDecimal(upper, 8), false
+	}
+	return "", true
+}
+
+func deviationBps(price, ref *big.Rat) float64 {
+	if ref.Sign() == 0 {
+		return 0
+	}
+	d := new(big.Rat).Sub(price, ref)
+	d.Quo(d, ref)
+	f, _ := d.Float64()
+	return f * 10000
+}
@@ -0,0 +1,184 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func requirePriceBandReason(t *testing.T, err error, reason PriceBandReason) *PriceBandError {
+	t.Helper()
+	var pbErr *PriceBandError
+	if !errors.As(err, &pbErr) {
+		t.Fatalf("error = %T %v, want *PriceBandError", err, err)
+	}
+	if pbErr.Reason != reason {
+		t.Fatalf("Reason = %q, want %q (%v)", pbErr.Reason, reason, pbErr)
+	}
+	return pbErr
+}
+
+func TestPriceBandGuard_Check(t *testing.T) {
+	g := NewPriceBandGuard(PriceBandConfig{MaxMarkDeviationBps: 100, MaxBBODeviationBps: 50})
+	g.OnPriceLimit(PriceLimit{InstId: "BTC-USDT-SWAP", BuyLmt: "52000", SellLmt: "48000", Enabled: true})
+	g.OnMarkPrice(MarkPrice{InstId: "BTC-USDT-SWAP", MarkPx: "50000"})
+	g.OnTicker(MarketTicker{InstId: "BTC-USDT-SWAP", BidPx: "49990", AskPx: "50010"})
+
+	// 买在 ask 之上 0.5% 以内：通过。
+	if err := g.Check("BTC-USDT-SWAP", "buy", "50200"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	// 远低于市场的被动买单：默认不拦截。
+	if err := g.Check("BTC-USDT-SWAP", "buy", "48500"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	requirePriceBandReason(t, g.Check("BTC-USDT-SWAP", "buy", "52001"), PriceBandReasonBuyLmt)
+	requirePriceBandReason(t, g.Check("BTC-USDT-SWAP", "sell", "47999"), PriceBandReasonSellLmt)
+
+	// mark 上浮 100bps -> 50500；51000 超出。
+	pb := requirePriceBandReason(t, g.Check("BTC-USDT-SWAP", "buy", "51000"), PriceBandReasonMark)
+	if pb.Ref != "50000" || pb.Bound != "50500" || pb.DeviationBps != 200 {
+		t.Fatalf("PriceBandError = %#v", pb)
+	}
+
+	// bid 下浮 50bps -> 49740.05；49700 超出（mark 下限 49500 内）。
+	pb = requirePriceBandReason(t, g.Check("BTC-USDT-SWAP", "sell", "49700"), PriceBandReasonBBO)
+	if pb.Ref != "49990" || pb.Bound != "49740.05" {
+		t.Fatalf("PriceBandError = %#v", pb)
+	}
+
+	requirePriceBandReason(t, g.Check("BTC-USDT-SWAP", "buy", "abc"), PriceBandReasonInvalid)
+
+	// 未知产品：无参考数据，默认放行。
+	if err := g.Check("ETH-USDT-SWAP", "buy", "999999"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+}
+
+func TestPriceBandGuard_SymmetricAndStale(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	g := NewPriceBandGuard(PriceBandConfig{MaxMarkDeviationBps: 100, Symmetric: true, MaxAge: time.Second, RejectOnMissing: true})
+	g.now = func() time.Time { return now }
+
+	requirePriceBandReason(t, g.Check("BTC-USDT-SWAP", "buy", "50000"), PriceBandReasonMissing)
+
+	// 缺少 price-limit 与缺少标记价格同样按 RejectOnMissing 拒单。
+	g.OnMarkPrice(MarkPrice{InstId: "BTC-USDT-SWAP", MarkPx: "50000"})
+	requirePriceBandReason(t, g.Check("BTC-USDT-SWAP", "buy", "50000"), PriceBandReasonMissing)
+	g.OnPriceLimit(PriceLimit{InstId: "BTC-USDT-SWAP", BuyLmt: "52000", SellLmt: "48000"})
+	requirePriceBandReason(t, g.Check("BTC-USDT-SWAP", "buy", "49000"), PriceBandReasonMark)
+	if err := g.Check("BTC-USDT-SWAP", "sell", "50400"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	now = now.Add(2 * time.Second)
+	requirePriceBandReason(t, g.Check("BTC-USDT-SWAP", "sell", "50400"), PriceBandReasonMissing)
+}
+
+func TestPriceBandGuard_Seed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v5/public/price-limit":
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instType":"SWAP","instId":"BTC-USDT-SWAP","buyLmt":"52000","sellLmt":"48000","ts":"1","enabled":true}]}`))
+		case "/api/v5/market/ticker":
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instType":"SWAP","instId":"BTC-USDT-SWAP","last":"50000","askPx":"50010","bidPx":"49990","ts":"1"}]}`))
+		case "/api/v5/public/mark-price":
+			if r.URL.Query().Get("instType") != "SWAP" {
+				t.Errorf("instType = %q, want SWAP", r.URL.Query().Get("instType"))
+			}
+			_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"instType":"SWAP","instId":"BTC-USDT-SWAP","markPx":"50000","ts":"1"}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	g := NewPriceBandGuard(PriceBandConfig{MaxMarkDeviationBps: 100, MaxBBODeviationBps: 100})
+	if err := g.Seed(context.Background(), c, "SWAP", "BTC-USDT-SWAP"); err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+	requirePriceBandReason(t, g.Check("BTC-USDT-SWAP", "buy", "52500"), PriceBandReasonBuyLmt)
+	requirePriceBandReason(t, g.Check("BTC-USDT-SWAP", "buy", "50600"), PriceBandReasonMark)
+}
+
+func TestPreTradeRisk_PriceBand(t *testing.T) {
+	g := NewPriceBandGuard(PriceBandConfig{MaxMarkDeviationBps: 100})
+	g.OnMarkPrice(MarkPrice{InstId: "BTC-USDT-SWAP", MarkPx: "50000"})
+
+	c, calls := newPreTradeRiskTestClient(t, RiskPolicy{
+		PriceBand: g,
+		OrderFunc: func(instId, ordId, clOrdId string) (TradeOrder, bool) {
+			if ordId == "1" {
+				return TradeOrder{InstId: instId, OrdId: ordId, Side: "buy", OrdType: "limit", Sz: "1"}, true
+			}
+			return TradeOrder{}, false
+		},
+	})
+	ctx := context.Background()
+
+	_, err := c.NewPlaceOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("buy").OrdType("limit").Px("55000").Sz("1").Do(ctx)
+	requireRiskReject(t, err, RiskRulePriceBand)
+	requirePriceBandReason(t, err, PriceBandReasonMark)
+
+	_, err = c.NewWSPrivate().PlaceOrder(ctx, WSPlaceOrderArg{InstId: "BTC-USDT-SWAP", TdMode: "cross", Side: "sell", OrdType: "limit", Px: "45000", Sz: "1"})
+	requirePriceBandReason(t, err, PriceBandReasonMark)
+
+	// 市价单不受价格带约束。
+	if _, err := c.NewPlaceOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("buy").OrdType("market").Sz("1").Do(ctx); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	// 策略委托（远离当前价的计划委托）不受价格带约束。
+	if _, err := c.NewPlaceAlgoOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("buy").OrdType("trigger").
+		TriggerPx("60000").OrderPx("60000").Sz("1").Do(ctx); err != nil {
+		t.Fatalf("algo Do() error = %v", err)
+	}
+	// 止盈止损：远离当前价的止损放行；越过当前价（提交即触发）且超出价格带的触发价拒单。
+	if _, err := c.NewPlaceAlgoOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("sell").OrdType("conditional").
+		TpTriggerPx("60000").TpOrdPx("-1").SlTriggerPx("40000").SlOrdPx("-1").Sz("1").Do(ctx); err != nil {
+		t.Fatalf("algo Do() error = %v", err)
+	}
+	_, err = c.NewPlaceAlgoOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("sell").OrdType("conditional").
+		TpTriggerPx("45000").TpOrdPx("-1").Sz("1").Do(ctx)
+	requirePriceBandReason(t, err, PriceBandReasonMark)
+	_, err = c.NewPlaceAlgoOrderService().InstId("BTC-USDT-SWAP").TdMode("cross").Side("sell").OrdType("conditional").
+		SlTriggerPx("55000").SlOrdPx("-1").Sz("1").Do(ctx)
+	requirePriceBandReason(t, err, PriceBandReasonMark)
+	// 改单的 newPx 同样受价格带约束（方向来自 OrderFunc，无法获取时拒单）。
+	_, err = c.NewAmendOrderService().InstId("BTC-USDT-SWAP").OrdId("1").NewPx("55000").Do(ctx)
+	requirePriceBandReason(t, err, PriceBandReasonMark)
+	_, err = c.NewAmendOrderService().InstId("BTC-USDT-SWAP").OrdId("9").NewPx("50000").Do(ctx)
+	requireRiskReject(t, err, RiskRulePriceBand)
+
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("http calls = %d, want 3", got)
+	}
+	if st := c.ClientStats(); st.RiskRejectCounts[string(RiskRulePriceBand)] != 6 {
+		t.Fatalf("RiskRejectCounts = %#v", st.RiskRejectCounts)
+	}
+}
+
+func TestPriceBandGuard_WSOptionsChainHandlers(t *testing.T) {
+	g := NewPriceBandGuard(PriceBandConfig{MaxBBODeviationBps: 50, DisablePriceLimit: true, RejectOnMissing: true})
+
+	var got []string
+	w := &WSClient{}
+	WithWSTickersHandler(func(tk MarketTicker) { got = append(got, tk.InstId) })(w)
+	for _, opt := range g.WSOptions() {
+		opt(w)
+	}
+	w.onDataMessage([]byte(`{"arg":{"channel":"tickers","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","bidPx":"49990","askPx":"50010"}]}`))
+
+	if len(got) != 1 || got[0] != "BTC-USDT" {
+		t.Fatalf("user tickers handler got = %v", got)
+	}
+	if err := g.Check("BTC-USDT", "buy", "50100"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	requirePriceBandReason(t, g.Check("BTC-USDT", "buy", "51000"), PriceBandReasonBBO)
+}
@@ -57,7 +57,7 @@ func (s *PublicBlockTradesService) Do(ctx context.Context) ([]BlockTrade, error)
 	q.Set("instId", s.instId)
 
 	var data []BlockTrade
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/block-trades", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/block-trades", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -96,7 +96,7 @@ func (s *PublicConvertContractCoinService) Do(ctx context.Context) ([]ConvertCon
 	}
 
 	var data []ConvertContractCoin
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/convert-contract-coin", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/convert-contract-coin", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -103,7 +103,7 @@ func (s *PublicDeliveryExerciseHistoryService) Do(ctx context.Context) ([]Delive
 	}
 
 	var data []DeliveryExerciseHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/delivery-exercise-history", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/delivery-exercise-history", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -70,7 +70,7 @@ func (s *PublicDiscountRateInterestFreeQuotaService) Do(ctx context.Context) ([]
 	}
 
 	var data []DiscountRateInterestFreeQuota
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/discount-rate-interest-free-quota", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/discount-rate-interest-free-quota", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -99,7 +99,7 @@ func (s *PublicEconomicCalendarService) Do(ctx context.Context) ([]EconomicCalen
 	}
 
 	var data []EconomicCalendarEvent
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/economic-calendar", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/economic-calendar", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
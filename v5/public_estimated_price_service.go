@@ -48,7 +48,7 @@ func (s *PublicEstimatedPriceService) Do(ctx context.Context) ([]EstimatedPrice,
 	q.Set("instId", s.instId)
 
 	var data []EstimatedPrice
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/estimated-price", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/estimated-price", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -47,7 +47,7 @@ func (s *PublicEstimatedSettlementInfoService) Do(ctx context.Context) ([]Estima
 	q.Set("instId", s.instId)
 
 	var data []EstimatedSettlementInfo
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/estimated-settlement-info", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/estimated-settlement-info", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
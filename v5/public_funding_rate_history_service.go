@@ -81,7 +81,7 @@ func (s *PublicFundingRateHistoryService) Do(ctx context.Context) ([]FundingRate
 	}
 
 	var data []FundingRateHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/funding-rate-history", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/funding-rate-history", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
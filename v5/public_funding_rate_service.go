@@ -66,7 +66,7 @@ func (s *PublicFundingRateService) Do(ctx context.Context) (*FundingRate, error)
 	q.Set("instId", s.instId)
 
 	var data []FundingRate
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/funding-rate", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/funding-rate", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
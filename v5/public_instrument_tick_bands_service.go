@@ -63,7 +63,7 @@ func (s *PublicInstrumentTickBandsService) Do(ctx context.Context) ([]Instrument
 	}
 
 	var data []InstrumentTickBandInfo
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/instrument-tick-bands", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/instrument-tick-bands", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -27,7 +27,9 @@ type Instrument struct {
 	MinSz  string `json:"minSz"`
 
 	CtVal    string `json:"ctVal"`
+	CtMult   string `json:"ctMult"`
 	CtValCcy string `json:"ctValCcy"`
+	CtType   string `json:"ctType"`
 
 	State string `json:"state"`
 }
@@ -92,7 +94,7 @@ func (s *PublicInstrumentsService) Do(ctx context.Context) ([]Instrument, error)
 	}
 
 	var data []Instrument
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/instruments", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/instruments", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -143,7 +143,7 @@ func (s *PublicInsuranceFundService) Do(ctx context.Context) ([]InsuranceFund, e
 	}
 
 	var data []InsuranceFund
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/insurance-fund", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/insurance-fund", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
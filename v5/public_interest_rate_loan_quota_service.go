@@ -67,7 +67,7 @@ func (s *PublicInterestRateLoanQuotaService) Do(ctx context.Context) ([]Interest
 	q := url.Values{}
 
 	var data []InterestRateLoanQuota
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/interest-rate-loan-quota", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/interest-rate-loan-quota", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
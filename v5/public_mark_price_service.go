@@ -75,7 +75,7 @@ func (s *PublicMarkPriceService) Do(ctx context.Context) ([]MarkPrice, error) {
 	}
 
 	var data []MarkPrice
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/mark-price", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/mark-price", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
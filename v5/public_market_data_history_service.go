@@ -135,7 +135,7 @@ func (s *PublicMarketDataHistoryService) Do(ctx context.Context) ([]MarketDataHi
 	q.Set("end", s.end)
 
 	var data []MarketDataHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/market-data-history", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/market-data-history", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
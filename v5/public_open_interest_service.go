@@ -79,7 +79,7 @@ func (s *PublicOpenInterestService) Do(ctx context.Context) ([]OpenInterest, err
 	}
 
 	var data []OpenInterest
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/open-interest", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/open-interest", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -73,7 +73,7 @@ func (s *PublicOptSummaryService) Do(ctx context.Context) ([]OptSummary, error)
 	}
 
 	var data []OptSummary
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/opt-summary", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/opt-summary", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -79,7 +79,7 @@ func (s *PublicOptionTradesService) Do(ctx context.Context) ([]OptionTrade, erro
 	}
 
 	var data []OptionTrade
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/option-trades", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/option-trades", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
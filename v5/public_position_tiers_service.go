@@ -121,7 +121,7 @@ func (s *PublicPositionTiersService) Do(ctx context.Context) ([]PositionTier, er
 	}
 
 	var data []PositionTier
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/position-tiers", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/position-tiers", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -77,7 +77,7 @@ func (s *PublicPremiumHistoryService) Do(ctx context.Context) ([]PremiumHistory,
 	}
 
 	var data []PremiumHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/premium-history", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/premium-history", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
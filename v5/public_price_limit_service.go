@@ -37,7 +37,7 @@ func (s *PublicPriceLimitService) Do(ctx context.Context) ([]PriceLimit, error)
 	q.Set("instId", s.instId)
 
 	var data []PriceLimit
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/price-limit", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/price-limit", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
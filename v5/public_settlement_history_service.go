@@ -82,7 +82,7 @@ func (s *PublicSettlementHistoryService) Do(ctx context.Context) ([]SettlementHi
 	}
 
 	var data []SettlementHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/settlement-history", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/settlement-history", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
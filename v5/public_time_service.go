@@ -32,7 +32,7 @@ var errEmptySystemTime = errors.New("okx: empty system time response")
 // Do 获取系统时间（GET /api/v5/public/time）。
 func (s *PublicTimeService) Do(ctx context.Context) (*SystemTime, error) {
 	var data []SystemTime
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/time", nil, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/time", nil, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -37,7 +37,7 @@ func (s *PublicUnderlyingService) Do(ctx context.Context) ([]string, error) {
 	q.Set("instType", s.instType)
 
 	var raw [][]string
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/public/underlying", q, nil, false, &raw); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/public/underlying", q, nil, false, &raw); err != nil {
 		return nil, err
 	}
 
@@ -211,9 +211,20 @@ func tradeAccountRateLimitWSKeys() []routeKey {
 		{Method: requestGateMethodWS, Endpoint: wsOpGateKey(wsOpBatchCancelOrders)},
 		{Method: requestGateMethodWS, Endpoint: wsOpGateKey(wsOpAmendOrder)},
 		{Method: requestGateMethodWS, Endpoint: wsOpGateKey(wsOpBatchAmendOrders)},
+		{Method: requestGateMethodWS, Endpoint: wsOpGateKey(wsOpMassCancel)},
 	}
 }
 
+// isTradeAccountRateLimitedWSOp 判断 WS op 是否受账户级交易限速约束（首次发送前需 preflight）。
+func isTradeAccountRateLimitedWSOp(op string) bool {
+	for _, k := range tradeAccountRateLimitWSKeys() {
+		if k.Endpoint == wsOpGateKey(op) {
+			return true
+		}
+	}
+	return false
+}
+
 type semaphore struct {
 	ch chan struct{}
 }
@@ -98,13 +98,13 @@ func TestTradeAccountRateLimitService_Do_UpdatesGate(t *testing.T) {
 
 	ctx1, cancel1 := context.WithTimeout(context.Background(), time.Second)
 	t.Cleanup(cancel1)
-	if err := c.do(ctx1, http.MethodPost, "/api/v5/trade/order", nil, nil, true, nil); err != nil {
+	if err := c.do(ctx1, CapabilityTrade, http.MethodPost, "/api/v5/trade/order", nil, nil, true, nil); err != nil {
 		t.Fatalf("first trade/order error = %v", err)
 	}
 
 	ctx2, cancel2 := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	t.Cleanup(cancel2)
-	err := c.do(ctx2, http.MethodPost, "/api/v5/trade/order", nil, nil, true, nil)
+	err := c.do(ctx2, CapabilityTrade, http.MethodPost, "/api/v5/trade/order", nil, nil, true, nil)
 	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
 		t.Fatalf("second trade/order error = %v, want context deadline exceeded", err)
 	}
@@ -152,13 +152,13 @@ func TestTradeAccountRateLimitService_Do_AccRateLimitZero_FailSafe(t *testing.T)
 
 	ctx1, cancel1 := context.WithTimeout(context.Background(), time.Second)
 	t.Cleanup(cancel1)
-	if err := c.do(ctx1, http.MethodPost, "/api/v5/trade/order", nil, nil, true, nil); err != nil {
+	if err := c.do(ctx1, CapabilityTrade, http.MethodPost, "/api/v5/trade/order", nil, nil, true, nil); err != nil {
 		t.Fatalf("first trade/order error = %v", err)
 	}
 
 	ctx2, cancel2 := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	t.Cleanup(cancel2)
-	err := c.do(ctx2, http.MethodPost, "/api/v5/trade/order", nil, nil, true, nil)
+	err := c.do(ctx2, CapabilityTrade, http.MethodPost, "/api/v5/trade/order", nil, nil, true, nil)
 	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
 		t.Fatalf("second trade/order error = %v, want context deadline exceeded", err)
 	}
@@ -201,10 +201,11 @@ func TestTradeAccountRateLimitService_Do_UpdatesGate_WSCoversTradeOps(t *testing
 	_, okBatchCancel := g.routeLimiter[routeKey{Method: requestGateMethodWS, Endpoint: wsOpGateKey(wsOpBatchCancelOrders)}]
 	_, okAmend := g.routeLimiter[routeKey{Method: requestGateMethodWS, Endpoint: wsOpGateKey(wsOpAmendOrder)}]
 	_, okBatchAmend := g.routeLimiter[routeKey{Method: requestGateMethodWS, Endpoint: wsOpGateKey(wsOpBatchAmendOrders)}]
+	_, okMassCancel := g.routeLimiter[routeKey{Method: requestGateMethodWS, Endpoint: wsOpGateKey(wsOpMassCancel)}]
 	g.mu.RUnlock()
 
-	if !okOrder || !okBatchOrder || !okCancel || !okBatchCancel || !okAmend || !okBatchAmend {
-		t.Fatalf("missing ws op limiters: order=%v batch-order=%v cancel=%v batch-cancel=%v amend=%v batch-amend=%v", okOrder, okBatchOrder, okCancel, okBatchCancel, okAmend, okBatchAmend)
+	if !okOrder || !okBatchOrder || !okCancel || !okBatchCancel || !okAmend || !okBatchAmend || !okMassCancel {
+		t.Fatalf("missing ws op limiters: order=%v batch-order=%v cancel=%v batch-cancel=%v amend=%v batch-amend=%v mass-cancel=%v", okOrder, okBatchOrder, okCancel, okBatchCancel, okAmend, okBatchAmend, okMassCancel)
 	}
 }
 
@@ -55,7 +55,7 @@ func (s *RFQCancelAllAfterService) Do(ctx context.Context) (*RFQCancelAllAfterAc
 	req := rfqCancelAllAfterRequest{TimeOut: s.timeOut}
 
 	var data []RFQCancelAllAfterAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/cancel-all-after", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/cancel-all-after", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -21,7 +21,7 @@ var errEmptyRFQCancelAllQuotesResponse = errors.New("okx: empty rfq cancel all q
 // Do 取消所有报价单（POST /api/v5/rfq/cancel-all-quotes）。
 func (s *RFQCancelAllQuotesService) Do(ctx context.Context) (*RFQTsAck, error) {
 	var data []RFQTsAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/cancel-all-quotes", nil, nil, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/cancel-all-quotes", nil, nil, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
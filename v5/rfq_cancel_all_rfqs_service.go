@@ -21,7 +21,7 @@ var errEmptyRFQCancelAllRFQsResponse = errors.New("okx: empty rfq cancel all rfq
 // Do 取消所有询价单（POST /api/v5/rfq/cancel-all-rfqs）。
 func (s *RFQCancelAllRFQsService) Do(ctx context.Context) (*RFQTsAck, error) {
 	var data []RFQTsAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/cancel-all-rfqs", nil, nil, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/cancel-all-rfqs", nil, nil, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +56,7 @@ func (s *RFQCancelBatchQuotesService) Do(ctx context.Context) ([]RFQCancelQuoteA
 	}
 
 	var data []RFQCancelQuoteAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/cancel-batch-quotes", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/cancel-batch-quotes", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
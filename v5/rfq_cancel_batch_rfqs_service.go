@@ -58,7 +58,7 @@ func (s *RFQCancelBatchRFQsService) Do(ctx context.Context) ([]RFQCancelAck, err
 	}
 
 	var data []RFQCancelAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/cancel-batch-rfqs", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/cancel-batch-rfqs", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
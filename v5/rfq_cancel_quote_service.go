@@ -72,7 +72,7 @@ func (s *RFQCancelQuoteService) Do(ctx context.Context) (*RFQCancelQuoteAck, err
 	}
 
 	var data []RFQCancelQuoteAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/cancel-quote", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/cancel-quote", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +63,7 @@ func (s *RFQCancelRFQService) Do(ctx context.Context) (*RFQCancelAck, error) {
 	}
 
 	var data []RFQCancelAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/cancel-rfq", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/cancel-rfq", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +25,7 @@ func (c *Client) NewRFQCounterpartiesService() *RFQCounterpartiesService {
 // Do 获取报价方信息（GET /api/v5/rfq/counterparties）。
 func (s *RFQCounterpartiesService) Do(ctx context.Context) ([]RFQCounterparty, error) {
 	var data []RFQCounterparty
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rfq/counterparties", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rfq/counterparties", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -118,7 +118,7 @@ func (s *RFQCreateQuoteService) Do(ctx context.Context) (*Quote, error) {
 	}
 
 	var data []Quote
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/create-quote", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/create-quote", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +144,7 @@ func (s *RFQCreateRFQService) Do(ctx context.Context) (*RFQ, error) {
 	}
 
 	var data []RFQ
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/create-rfq", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/create-rfq", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
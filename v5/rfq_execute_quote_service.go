@@ -78,7 +78,7 @@ func (s *RFQExecuteQuoteService) Do(ctx context.Context) (*StrucBlockTrade, erro
 	}
 
 	var data []StrucBlockTrade
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/execute-quote", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/execute-quote", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
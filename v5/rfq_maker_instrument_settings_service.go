@@ -33,7 +33,7 @@ func (c *Client) NewRFQMakerInstrumentSettingsService() *RFQMakerInstrumentSetti
 // Do 获取可报价产品设置（GET /api/v5/rfq/maker-instrument-settings）。
 func (s *RFQMakerInstrumentSettingsService) Do(ctx context.Context) ([]RFQMakerInstrumentSetting, error) {
 	var data []RFQMakerInstrumentSetting
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rfq/maker-instrument-settings", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rfq/maker-instrument-settings", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
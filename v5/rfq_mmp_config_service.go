@@ -28,7 +28,7 @@ func (c *Client) NewRFQMMPConfigService() *RFQMMPConfigService {
 // Do 查看 MMP 配置（GET /api/v5/rfq/mmp-config）。
 func (s *RFQMMPConfigService) Do(ctx context.Context) ([]RFQMMPConfig, error) {
 	var data []RFQMMPConfig
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rfq/mmp-config", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rfq/mmp-config", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
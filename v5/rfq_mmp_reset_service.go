@@ -21,7 +21,7 @@ var errEmptyRFQMMPResetResponse = errors.New("okx: empty rfq mmp reset response"
 // Do 重设 MMP 状态（POST /api/v5/rfq/mmp-reset）。
 func (s *RFQMMPResetService) Do(ctx context.Context) (*RFQTsAck, error) {
 	var data []RFQTsAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/mmp-reset", nil, nil, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/mmp-reset", nil, nil, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +78,7 @@ func (s *RFQPublicTradesService) Do(ctx context.Context) ([]RFQPublicTrade, erro
 	}
 
 	var data []RFQPublicTrade
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rfq/public-trades", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rfq/public-trades", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
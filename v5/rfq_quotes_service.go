@@ -95,7 +95,7 @@ func (s *RFQQuotesService) Do(ctx context.Context) ([]Quote, error) {
 	}
 
 	var data []Quote
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rfq/quotes", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rfq/quotes", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -77,7 +77,7 @@ func (s *RFQRfqsService) Do(ctx context.Context) ([]RFQ, error) {
 	}
 
 	var data []RFQ
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rfq/rfqs", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rfq/rfqs", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
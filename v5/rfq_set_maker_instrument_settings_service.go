@@ -56,7 +56,7 @@ func (s *RFQSetMakerInstrumentSettingsService) Do(ctx context.Context) (*RFQSetM
 	}
 
 	var data []RFQSetMakerInstrumentSettingsAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/maker-instrument-settings", nil, s.settings, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/maker-instrument-settings", nil, s.settings, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
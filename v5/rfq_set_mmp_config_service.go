@@ -53,7 +53,7 @@ func (s *RFQSetMMPConfigService) Do(ctx context.Context) (*RFQMMPConfig, error)
 	}
 
 	var data []RFQMMPConfig
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/rfq/mmp-config", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/rfq/mmp-config", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
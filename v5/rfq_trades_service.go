@@ -95,7 +95,7 @@ func (s *RFQTradesService) Do(ctx context.Context) ([]StrucBlockTrade, error) {
 	}
 
 	var data []StrucBlockTrade
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rfq/trades", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rfq/trades", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -78,7 +78,7 @@ func (s *RubikLongShortAccountRatioContractTopTraderService) Do(ctx context.Cont
 	}
 
 	var data []RubikTsRatio
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/contracts/long-short-account-ratio-contract-top-trader", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/contracts/long-short-account-ratio-contract-top-trader", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -67,7 +67,7 @@ func (s *RubikLongShortAccountRatioService) Do(ctx context.Context) ([]RubikTsRa
 	}
 
 	var data []RubikTsRatio
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/contracts/long-short-account-ratio", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/contracts/long-short-account-ratio", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
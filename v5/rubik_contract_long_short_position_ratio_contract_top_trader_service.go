@@ -78,7 +78,7 @@ func (s *RubikLongShortPositionRatioContractTopTraderService) Do(ctx context.Con
 	}
 
 	var data []RubikTsRatio
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/contracts/long-short-position-ratio-contract-top-trader", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/contracts/long-short-position-ratio-contract-top-trader", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -78,7 +78,7 @@ func (s *RubikOpenInterestHistoryService) Do(ctx context.Context) ([]RubikOpenIn
 	}
 
 	var data []RubikOpenInterestHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/contracts/open-interest-history", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/contracts/open-interest-history", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -67,7 +67,7 @@ func (s *RubikContractsOpenInterestVolumeService) Do(ctx context.Context) ([]Rub
 	}
 
 	var data []RubikOpenInterestVolume
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/contracts/open-interest-volume", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/contracts/open-interest-volume", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -67,7 +67,7 @@ func (s *RubikMarginLoanRatioService) Do(ctx context.Context) ([]RubikTsRatio, e
 	}
 
 	var data []RubikTsRatio
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/margin/loan-ratio", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/margin/loan-ratio", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
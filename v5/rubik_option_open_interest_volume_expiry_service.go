@@ -47,7 +47,7 @@ func (s *RubikOptionOpenInterestVolumeExpiryService) Do(ctx context.Context) ([]
 	}
 
 	var data []RubikOptionOpenInterestVolumeExpiry
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/option/open-interest-volume-expiry", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/option/open-interest-volume-expiry", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -47,7 +47,7 @@ func (s *RubikOptionOpenInterestVolumeService) Do(ctx context.Context) ([]RubikO
 	}
 
 	var data []RubikOpenInterestVolume
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/option/open-interest-volume", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/option/open-interest-volume", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
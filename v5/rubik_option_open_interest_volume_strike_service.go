@@ -55,7 +55,7 @@ func (s *RubikOptionOpenInterestVolumeStrikeService) Do(ctx context.Context) ([]
 	}
 
 	var data []RubikOptionOpenInterestVolumeStrike
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/option/open-interest-volume-strike", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/option/open-interest-volume-strike", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -47,7 +47,7 @@ func (s *RubikOptionTakerBlockVolumeService) Do(ctx context.Context) (*RubikOpti
 	}
 
 	var data RubikOptionTakerBlockVolume
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/option/taker-block-volume", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/option/taker-block-volume", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return &data, nil
@@ -88,7 +88,7 @@ func (s *RubikTakerVolumeContractService) Do(ctx context.Context) ([]RubikTakerV
 	}
 
 	var data []RubikTakerVolume
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/taker-volume-contract", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/taker-volume-contract", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
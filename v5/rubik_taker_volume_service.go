@@ -75,7 +75,7 @@ func (s *RubikTakerVolumeService) Do(ctx context.Context) ([]RubikTakerVolume, e
 	}
 
 	var data []RubikTakerVolume
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/taker-volume", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/taker-volume", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -18,7 +18,7 @@ func (c *Client) NewRubikSupportCoinService() *RubikSupportCoinService {
 // Do 获取交易大数据支持币种（GET /api/v5/rubik/stat/trading-data/support-coin）。
 func (s *RubikSupportCoinService) Do(ctx context.Context) (*RubikSupportCoin, error) {
 	var data RubikSupportCoin
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/rubik/stat/trading-data/support-coin", nil, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/rubik/stat/trading-data/support-coin", nil, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return &data, nil
@@ -0,0 +1,448 @@
+package okx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SBE（Simple Binary Encoding）行情消息模板 ID。
+//
+// 说明：
+// - 所有整数均为小端序；价格/数量以 (mantissa int64, exponent int8) 表示，解码为与 JSON 行情一致的十进制 string；
+// - 解码遵循 SBE 前向兼容规则：按消息头/分组头的 blockLength 跳过未知的尾部字段，schema 小版本升级不影响解码。
+const (
+	SBETemplateBboTbt        uint16 = 1000 // bbo-tbt
+	SBETemplateBooksL2Tbt    uint16 = 1001 // books-l2-tbt（snapshot/update）
+	SBETemplateBooks50L2Tbt  uint16 = 1002 // books50-l2-tbt（snapshot/update）
+	SBETemplateTrades        uint16 = 1003 // trades
+	SBETemplateSnapshotDepth uint16 = 1006 // SnapshotDepthResponseEvent（REST market/books-sbe）
+)
+
+// SBE 定长块/分组头长度（字节）。
+const (
+	sbeMessageHeaderLen      = 8
+	sbeGroupHeaderLen        = 6 // blockLength uint16 + numInGroup uint32
+	sbeDepthLevelBlockLen    = 20
+	sbeTradeBlockLen         = 33
+	sbeSnapshotDepthBlockLen = 26
+	sbeDepthEventBlockLen    = 39
+	sbeBboEventBlockLen      = 66
+	sbeTradesEventBlockLen   = 10
+)
+
+const (
+	sbeNullInt64 = math.MinInt64
+
+	sbeDepthActionSnapshot uint8 = 0
+	sbeDepthActionUpdate   uint8 = 1
+
+	sbeTradeSideBuy  uint8 = 0
+	sbeTradeSideSell uint8 = 1
+)
+
+// SBEMessageHeader 为 SBE 标准消息头（8 字节）。
+type SBEMessageHeader struct {
+	BlockLength uint16
+	TemplateId  uint16
+	SchemaId    uint16
+	Version     uint16
+}
+
+// SBESnapshotDepth 为 SnapshotDepthResponseEvent（templateId=1006）。
+type SBESnapshotDepth struct {
+	InstIdCode int64
+	TsUs       int64
+	SeqId      int64
+
+	Asks []OrderBookLevel
+	Bids []OrderBookLevel
+}
+
+// SBEDepthEvent 为 books-l2-tbt / books50-l2-tbt 的 SBE 推送（Action 为 snapshot/update）。
+type SBEDepthEvent struct {
+	Channel    string
+	InstIdCode int64
+	TsUs       int64
+	PrevSeqId  int64
+	SeqId      int64
+	Checksum   int64
+	Action     string
+
+	Asks []OrderBookLevel
+	Bids []OrderBookLevel
+}
+
+// SBEBboEvent 为 bbo-tbt 的 SBE 推送（一侧为空时对应档位为零值）。
+type SBEBboEvent struct {
+	InstIdCode int64
+	TsUs       int64
+	SeqId      int64
+
+	Ask OrderBookLevel
+	Bid OrderBookLevel
+}
+
+// SBETrade 为 trades SBE 推送中的单笔成交。
+type SBETrade struct {
+	TradeId string
+	TsUs    int64
+	Px      string
+	Sz      string
+	Side    string
+}
+
+// SBETradesEvent 为 trades 的 SBE 推送。
+type SBETradesEvent struct {
+	InstIdCode int64
+	Trades     []SBETrade
+}
+
+// SBEMessage 为一条解码后的 SBE 消息；按 Header.TemplateId 仅有一个字段非 nil。
+//
+// 未知 templateId 不视为错误：所有消息字段均为 nil，调用方可按 Header 自行处理。
+type SBEMessage struct {
+	Header SBEMessageHeader
+
+	SnapshotDepth *SBESnapshotDepth
+	Depth         *SBEDepthEvent
+	Bbo           *SBEBboEvent
+	Trades        *SBETradesEvent
+}
+
+// DecodeSBEHeader 解码 SBE 消息头。
+func DecodeSBEHeader(b []byte) (SBEMessageHeader, error) {
+	if len(b) < sbeMessageHeaderLen {
+		return SBEMessageHeader{}, fmt.Errorf("okx: sbe message too short: %d bytes", len(b))
+	}
+	return SBEMessageHeader{
+		BlockLength: binary.LittleEndian.Uint16(b[0:]),
+		TemplateId:  binary.LittleEndian.Uint16(b[2:]),
+		SchemaId:    binary.LittleEndian.Uint16(b[4:]),
+		Version:     binary.LittleEndian.Uint16(b[6:]),
+	}, nil
+}
+
+// DecodeSBE 解码一条 SBE 消息（消息头 + 消息体）。
+func DecodeSBE(b []byte) (*SBEMessage, error) {
+	h, err := DecodeSBEHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	msg := &SBEMessage{Header: h}
+	d := sbeDecoder{buf: b, off: sbeMessageHeaderLen}
+
+	switch h.TemplateId {
+	case SBETemplateSnapshotDepth:
+		msg.SnapshotDepth, err = d.snapshotDepth(h)
+	case SBETemplateBooksL2Tbt:
+		msg.Depth, err = d.depthEvent(h, WSChannelBooksL2Tbt)
+	case SBETemplateBooks50L2Tbt:
+		msg.Depth, err = d.depthEvent(h, WSChannelBooks50L2Tbt)
+	case SBETemplateBboTbt:
+		msg.Bbo, err = d.bboEvent(h)
+	case SBETemplateTrades:
+		msg.Trades, err = d.tradesEvent(h)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// DecodeSBESnapshotDepth 解码 MarketBooksSBEService 返回的 SnapshotDepthResponseEvent。
+func DecodeSBESnapshotDepth(b []byte) (*SBESnapshotDepth, error) {
+	msg, err := DecodeSBE(b)
+	if err != nil {
+		return nil, err
+	}
+	if msg.SnapshotDepth == nil {
+		return nil, fmt.Errorf("okx: sbe templateId=%d is not SnapshotDepthResponseEvent", msg.Header.TemplateId)
+	}
+	return msg.SnapshotDepth, nil
+}
+
+// OrderBookData 将快照转换为可直接 Apply 到 WSOrderBookStore 的 snapshot 推送（TS 为毫秒）。
+func (s *SBESnapshotDepth) OrderBookData(channel, instId string) WSData[WSOrderBook] {
+	return WSData[WSOrderBook]{
+		Arg:    WSArg{Channel: channel, InstId: instId},
+		Action: "snapshot",
+		Data: []WSOrderBook{{
+			Asks:   s.Asks,
+			Bids:   s.Bids,
+			InstId: instId,
+			TS:     s.TsUs / 1000,
+			SeqId:  s.SeqId,
+		}},
+	}
+}
+
+// OrderBookData 将深度事件转换为 WSOrderBookStore 兼容的推送（TS 为毫秒）。
+func (e *SBEDepthEvent) OrderBookData(instId string) WSData[WSOrderBook] {
+	return WSData[WSOrderBook]{
+		Arg:    WSArg{Channel: e.Channel, InstId: instId},
+		Action: e.Action,
+		Data: []WSOrderBook{{
+			Asks:      e.Asks,
+			Bids:      e.Bids,
+			InstId:    instId,
+			TS:        e.TsUs / 1000,
+			Checksum:  e.Checksum,
+			PrevSeqId: e.PrevSeqId,
+			SeqId:     e.SeqId,
+		}},
+	}
+}
+
+// OrderBookData 将 bbo 事件转换为 bbo-tbt 推送（TS 为毫秒）。
+func (e *SBEBboEvent) OrderBookData(instId string) WSData[WSOrderBook] {
+	book := WSOrderBook{InstId: instId, TS: e.TsUs / 1000, SeqId: e.SeqId}
+	if e.Ask.Px != "" {
+		book.Asks = []OrderBookLevel{e.Ask}
+	}
+	if e.Bid.Px != "" {
+		book.Bids = []OrderBookLevel{e.Bid}
+	}
+	return WSData[WSOrderBook]{
+		Arg:  WSArg{Channel: WSChannelBboTbt, InstId: instId},
+		Data: []WSOrderBook{book},
+	}
+}
+
+// MarketTrades 将成交事件转换为 MarketTrade 列表（TS 为毫秒）。
+func (e *SBETradesEvent) MarketTrades(instId string) []MarketTrade {
+	out := make([]MarketTrade, 0, len(e.Trades))
+	for _, t := range e.Trades {
+		out = append(out, MarketTrade{
+			InstId:  instId,
+			TradeId: t.TradeId,
+			Px:      t.Px,
+			Sz:      t.Sz,
+			Side:    t.Side,
+			TS:      t.TsUs / 1000,
+		})
+	}
+	return out
+}
+
+// sbeDecoder 为按偏移顺序读取的小端解码器。
+type sbeDecoder struct {
+	buf []byte
+	off int
+}
+
+func (d *sbeDecoder) need(n int, what string) error {
+	if d.off+n > len(d.buf) {
+		return fmt.Errorf("okx: sbe truncated %s at offset %d: need %d bytes, have %d", what, d.off, n, len(d.buf)-d.off)
+	}
+	return nil
+}
+
+// block 返回当前消息/分组项的定长块并前移 blockLength（跳过未知尾部字段）；blockLength 小于已知长度时报错。
+func (d *sbeDecoder) block(blockLength uint16, known int, what string) ([]byte, error) {
+	if int(blockLength) < known {
+		return nil, fmt.Errorf("okx: sbe %s blockLength=%d, want >= %d", what, blockLength, known)
+	}
+	if err := d.need(int(blockLength), what); err != nil {
+		return nil, err
+	}
+	b := d.buf[d.off : d.off+int(blockLength)]
+	d.off += int(blockLength)
+	return b, nil
+}
+
+func (d *sbeDecoder) group(what string) (blockLength uint16, n int, err error) {
+	if err := d.need(sbeGroupHeaderLen, what+" group header"); err != nil {
+		return 0, 0, err
+	}
+	blockLength = binary.LittleEndian.Uint16(d.buf[d.off:])
+	n = int(binary.LittleEndian.Uint32(d.buf[d.off+2:]))
+	d.off += sbeGroupHeaderLen
+	if int64(n)*int64(blockLength) > int64(len(d.buf)-d.off) {
+		return 0, 0, fmt.Errorf("okx: sbe truncated %s group: %d entries of %d bytes", what, n, blockLength)
+	}
+	return blockLength, n, nil
+}
+
+func (d *sbeDecoder) levels(what string, pxExp, szExp int8) ([]OrderBookLevel, error) {
+	blockLength, n, err := d.group(what)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]OrderBookLevel, 0, n)
+	for i := 0; i < n; i++ {
+		b, err := d.block(blockLength, sbeDepthLevelBlockLen, what+" level")
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, OrderBookLevel{
+			Px:        sbeDecimal(sbeInt64(b, 0), pxExp),
+			Sz:        sbeDecimal(sbeInt64(b, 8), szExp),
+			LiqOrd:    "0",
+			NumOrders: strconv.FormatInt(int64(int32(binary.LittleEndian.Uint32(b[16:]))), 10),
+		})
+	}
+	return out, nil
+}
+
+// snapshotDepth 布局：tsUs int64, seqId int64, instIdCode int64, pxExponent int8, szExponent int8；分组 asks, bids。
+func (d *sbeDecoder) snapshotDepth(h SBEMessageHeader) (*SBESnapshotDepth, error) {
+	b, err := d.block(h.BlockLength, sbeSnapshotDepthBlockLen, "SnapshotDepthResponseEvent")
+	if err != nil {
+		return nil, err
+	}
+	out := &SBESnapshotDepth{
+		TsUs:       sbeInt64(b, 0),
+		SeqId:      sbeInt64(b, 8),
+		InstIdCode: sbeInt64(b, 16),
+	}
+	pxExp, szExp := int8(b[24]), int8(b[25])
+	if out.Asks, err = d.levels("asks", pxExp, szExp); err != nil {
+		return nil, err
+	}
+	if out.Bids, err = d.levels("bids", pxExp, szExp); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// depthEvent 布局：instIdCode, tsUs, prevSeqId, seqId int64, checksum int32, action uint8, pxExponent int8, szExponent int8；分组 asks, bids。
+func (d *sbeDecoder) depthEvent(h SBEMessageHeader, channel string) (*SBEDepthEvent, error) {
+	b, err := d.block(h.BlockLength, sbeDepthEventBlockLen, channel)
+	if err != nil {
+		return nil, err
+	}
+	out := &SBEDepthEvent{
+		Channel:    channel,
+		InstIdCode: sbeInt64(b, 0),
+		TsUs:       sbeInt64(b, 8),
+		PrevSeqId:  sbeInt64(b, 16),
+		SeqId:      sbeInt64(b, 24),
+		Checksum:   int64(int32(binary.LittleEndian.Uint32(b[32:]))),
+	}
+	switch b[36] {
+	case sbeDepthActionSnapshot:
+		out.Action = "snapshot"
+	case sbeDepthActionUpdate:
+		out.Action = "update"
+	default:
+		return nil, fmt.Errorf("okx: sbe %s invalid action %d", channel, b[36])
+	}
+	if out.PrevSeqId == sbeNullInt64 {
+		out.PrevSeqId = -1
+	}
+	pxExp, szExp := int8(b[37]), int8(b[38])
+	if out.Asks, err = d.levels("asks", pxExp, szExp); err != nil {
+		return nil, err
+	}
+	if out.Bids, err = d.levels("bids", pxExp, szExp); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// bboEvent 布局：instIdCode, tsUs, seqId int64, askPx, askSz int64, askOrdCount int32, bidPx, bidSz int64, bidOrdCount int32, pxExponent int8, szExponent int8。
+func (d *sbeDecoder) bboEvent(h SBEMessageHeader) (*SBEBboEvent, error) {
+	b, err := d.block(h.BlockLength, sbeBboEventBlockLen, WSChannelBboTbt)
+	if err != nil {
+		return nil, err
+	}
+	pxExp, szExp := int8(b[64]), int8(b[65])
+	level := func(off int) OrderBookLevel {
+		px := sbeInt64(b, off)
+		if px == sbeNullInt64 {
+			return OrderBookLevel{}
+		}
+		return OrderBookLevel{
+			Px:        sbeDecimal(px, pxExp),
+			Sz:        sbeDecimal(sbeInt64(b, off+8), szExp),
+			LiqOrd:    "0",
+			NumOrders: strconv.FormatInt(int64(int32(binary.LittleEndian.Uint32(b[off+16:]))), 10),
+		}
+	}
+	return &SBEBboEvent{
+		InstIdCode: sbeInt64(b, 0),
+		TsUs:       sbeInt64(b, 8),
+		SeqId:      sbeInt64(b, 16),
+		Ask:        level(24),
+		Bid:        level(44),
+	}, nil
+}
+
+// tradesEvent 布局：instIdCode int64, pxExponent int8, szExponent int8；分组 trades（tradeId, tsUs, px, sz int64, side uint8）。
+func (d *sbeDecoder) tradesEvent(h SBEMessageHeader) (*SBETradesEvent, error) {
+	b, err := d.block(h.BlockLength, sbeTradesEventBlockLen, WSChannelTrades)
+	if err != nil {
+		return nil, err
+	}
+	out := &SBETradesEvent{InstIdCode: sbeInt64(b, 0)}
+	pxExp, szExp := int8(b[8]), int8(b[9])
+
+	blockLength, n, err := d.group("trades")
+	if err != nil {
+		return nil, err
+	}
+	out.Trades = make([]SBETrade, 0, n)
+	for i := 0; i < n; i++ {
+		tb, err := d.block(blockLength, sbeTradeBlockLen, "trade")
+		if err != nil {
+			return nil, err
+		}
+		t := SBETrade{
+			TradeId: strconv.FormatInt(sbeInt64(tb, 0), 10),
+			TsUs:    sbeInt64(tb, 8),
+			Px:      sbeDecimal(sbeInt64(tb, 16), pxExp),
+			Sz:      sbeDecimal(sbeInt64(tb, 24), szExp),
+		}
+		switch tb[32] {
+		case sbeTradeSideBuy:
+			t.Side = "buy"
+		case sbeTradeSideSell:
+			t.Side = "sell"
+		default:
+			return nil, fmt.Errorf("okx: sbe trade invalid side %d", tb[32])
+		}
+		out.Trades = append(out.Trades, t)
+	}
+	return out, nil
+}
+
+func sbeInt64(b []byte, off int) int64 {
+	return int64(binary.LittleEndian.Uint64(b[off:]))
+}
+
+// sbeDecimal 将 mantissa*10^exponent 格式化为十进制字符串（保留 exponent 对应的小数位数，与 JSON 行情的精度一致）。
+//
+// 0 固定返回 "0"（与 JSON 深度推送删除档位的 sz="0" 一致）；null 值返回 ""。
+func sbeDecimal(mantissa int64, exponent int8) string {
+	switch mantissa {
+	case sbeNullInt64:
+		return ""
+	case 0:
+		return "0"
+	}
+	neg := mantissa < 0
+	u := uint64(mantissa)
+	if neg {
+		u = uint64(-mantissa)
+	}
+	digits := strconv.FormatUint(u, 10)
+
+	var s string
+	switch {
+	case exponent >= 0:
+		s = digits + strings.Repeat("0", int(exponent))
+	default:
+		scale := int(-exponent)
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		s = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
@@ -0,0 +1,215 @@
+package okx
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// sbeFixture 将按字段分行书写的十六进制夹具拼接为二进制消息（忽略空白）。
+func sbeFixture(t *testing.T, lines ...string) []byte {
+	t.Helper()
+	var sb strings.Builder
+	for _, l := range lines {
+		sb.WriteString(strings.Join(strings.Fields(l), ""))
+	}
+	b, err := hex.DecodeString(sb.String())
+	if err != nil {
+		t.Fatalf("invalid fixture: %v", err)
+	}
+	return b
+}
+
+// books-l2-tbt snapshot：instIdCode=10，2 档卖盘、1 档买盘，pxExponent=-2，szExponent=-3。
+var sbeFixtureDepthSnapshot = []string{
+	"2700 e903 0100 0100", // header: blockLength=39 templateId=1001 schemaId=1 version=1
+	"0a00000000000000",    // instIdCode=10
+	"40222018240a0600",    // tsUs=1700000000123456
+	"0000000000000080",    // prevSeqId=null
+	"6400000000000000",    // seqId=100
+	"c7cfffff",            // checksum=-12345
+	"00",                  // action=snapshot
+	"fe fd",               // pxExponent=-2 szExponent=-3
+	"1400 02000000",       // asks: blockLength=20 numInGroup=2
+	"823d400000000000 dc05000000000000 03000000", // 42100.50 x 1.500 (3 orders)
+	"b43d400000000000 d007000000000000 03000000", // 42101.00 x 2.000
+	"1400 01000000", // bids: blockLength=20 numInGroup=1
+	"5a3d400000000000 dc05000000000000 03000000", // 42100.10 x 1.500
+}
+
+// books-l2-tbt update：删除卖一（sz=0），买盘无变化。
+var sbeFixtureDepthUpdate = []string{
+	"2700 e903 0100 0100",
+	"0a00000000000000",
+	"40222018240a0600",
+	"6400000000000000", // prevSeqId=100
+	"6500000000000000", // seqId=101
+	"c7cfffff",
+	"01", // action=update
+	"fe fd",
+	"1400 01000000",
+	"823d400000000000 0000000000000000 00000000",
+	"1400 00000000",
+}
+
+func TestDecodeSBE_DepthEvent(t *testing.T) {
+	msg, err := DecodeSBE(sbeFixture(t, sbeFixtureDepthSnapshot...))
+	if err != nil {
+		t.Fatalf("DecodeSBE() error = %v", err)
+	}
+	if msg.Header != (SBEMessageHeader{BlockLength: 39, TemplateId: SBETemplateBooksL2Tbt, SchemaId: 1, Version: 1}) {
+		t.Fatalf("header = %#v", msg.Header)
+	}
+	e := msg.Depth
+	if e == nil || e.Channel != WSChannelBooksL2Tbt || e.InstIdCode != 10 || e.TsUs != 1700000000123456 ||
+		e.PrevSeqId != -1 || e.SeqId != 100 || e.Checksum != -12345 || e.Action != "snapshot" {
+		t.Fatalf("depth = %#v", e)
+	}
+	if fmt.Sprint(e.Asks) != "[{42100.50 1.500 0 3} {42101.00 2.000 0 3}]" || fmt.Sprint(e.Bids) != "[{42100.10 1.500 0 3}]" {
+		t.Fatalf("levels = %v / %v", e.Asks, e.Bids)
+	}
+
+	dm := e.OrderBookData("BTC-USDT")
+	store := NewWSOrderBookStore(WSChannelBooksL2Tbt, "BTC-USDT", WithWSOrderBookVerifyChecksum(false))
+	if err := store.Apply(&dm); err != nil {
+		t.Fatalf("Apply(snapshot) error = %v", err)
+	}
+
+	upd, err := DecodeSBE(sbeFixture(t, sbeFixtureDepthUpdate...))
+	if err != nil {
+		t.Fatalf("DecodeSBE(update) error = %v", err)
+	}
+	dm = upd.Depth.OrderBookData("BTC-USDT")
+	if err := store.Apply(&dm); err != nil {
+		t.Fatalf("Apply(update) error = %v", err)
+	}
+	snap := store.Snapshot()
+	if snap.SeqId != 101 || snap.TS != 1700000000123 || len(snap.Asks) != 1 || snap.Asks[0].Px != "42101.00" || len(snap.Bids) != 1 {
+		t.Fatalf("snapshot = %#v", snap)
+	}
+}
+
+func TestDecodeSBESnapshotDepth(t *testing.T) {
+	b := sbeFixture(t,
+		"1a00 ee03 0100 0100", // header: blockLength=26 templateId=1006
+		"40222018240a0600",    // tsUs
+		"6400000000000000",    // seqId=100
+		"0a00000000000000",    // instIdCode=10
+		"fe fd",
+		"1400 01000000",
+		"823d400000000000 dc05000000000000 03000000",
+		"1400 00000000",
+	)
+	s, err := DecodeSBESnapshotDepth(b)
+	if err != nil {
+		t.Fatalf("DecodeSBESnapshotDepth() error = %v", err)
+	}
+	if s.InstIdCode != 10 || s.SeqId != 100 || len(s.Asks) != 1 || s.Asks[0].Px != "42100.50" || len(s.Bids) != 0 {
+		t.Fatalf("snapshot = %#v", s)
+	}
+	dm := s.OrderBookData(WSChannelBooks, "BTC-USDT")
+	if dm.Action != "snapshot" || dm.Data[0].TS != 1700000000123 {
+		t.Fatalf("data = %#v", dm)
+	}
+
+	if _, err := DecodeSBESnapshotDepth(sbeFixture(t, sbeFixtureDepthSnapshot...)); err == nil {
+		t.Fatalf("expected template mismatch error")
+	}
+}
+
+func TestDecodeSBE_BboAndTrades(t *testing.T) {
+	bbo, err := DecodeSBE(sbeFixture(t,
+		"4200 e803 0100 0100", // header: blockLength=66 templateId=1000
+		"0a00000000000000",
+		"40222018240a0600",
+		"6500000000000000",                           // seqId=101
+		"823d400000000000 dc05000000000000 03000000", // ask
+		"0000000000000080 0000000000000000 00000000", // bid=null
+		"fe fd",
+	))
+	if err != nil {
+		t.Fatalf("DecodeSBE(bbo) error = %v", err)
+	}
+	if bbo.Bbo == nil || bbo.Bbo.Ask.Px != "42100.50" || bbo.Bbo.Bid.Px != "" {
+		t.Fatalf("bbo = %#v", bbo.Bbo)
+	}
+	dm := bbo.Bbo.OrderBookData("BTC-USDT")
+	if dm.Arg.Channel != WSChannelBboTbt || len(dm.Data[0].Asks) != 1 || len(dm.Data[0].Bids) != 0 || dm.Data[0].SeqId != 101 {
+		t.Fatalf("bbo data = %#v", dm)
+	}
+
+	trades, err := DecodeSBE(sbeFixture(t,
+		"0a00 eb03 0100 0100", // header: blockLength=10 templateId=1003
+		"0a00000000000000",
+		"fe fd",
+		"2100 02000000", // trades: blockLength=33 numInGroup=2
+		"2b02000000000000 40222018240a0600 823d400000000000 dc05000000000000 00", // 555 buy
+		"2c02000000000000 40222018240a0600 5a3d400000000000 d007000000000000 01", // 556 sell
+	))
+	if err != nil {
+		t.Fatalf("DecodeSBE(trades) error = %v", err)
+	}
+	got := trades.Trades.MarketTrades("BTC-USDT")
+	if len(got) != 2 || got[0].TradeId != "555" || got[0].Side != "buy" || got[0].Px != "42100.50" ||
+		got[1].Side != "sell" || got[1].Sz != "2.000" || got[1].TS != 1700000000123 || got[1].InstId != "BTC-USDT" {
+		t.Fatalf("trades = %#v", got)
+	}
+}
+
+func TestDecodeSBE_ForwardCompatAndErrors(t *testing.T) {
+	// 消息块/分组项比已知布局更长（新版本追加字段）：按 blockLength 跳过。
+	b := sbeFixture(t,
+		"2900 e903 0100 0200", // blockLength=41 version=2
+		"0a00000000000000 40222018240a0600 0000000000000080 6400000000000000 c7cfffff 00 fe fd",
+		"ffff",          // 新增字段
+		"1600 01000000", // asks: blockLength=22
+		"823d400000000000 dc05000000000000 03000000 ffff",
+		"1400 00000000",
+	)
+	msg, err := DecodeSBE(b)
+	if err != nil {
+		t.Fatalf("DecodeSBE() error = %v", err)
+	}
+	if msg.Depth == nil || len(msg.Depth.Asks) != 1 || msg.Depth.Asks[0].Sz != "1.500" {
+		t.Fatalf("depth = %#v", msg.Depth)
+	}
+
+	unknown, err := DecodeSBE(sbeFixture(t, "0000 3930 0100 0100"))
+	if err != nil || unknown.Header.TemplateId != 12345 || unknown.Depth != nil {
+		t.Fatalf("unknown template = %#v, %v", unknown, err)
+	}
+
+	full := sbeFixture(t, sbeFixtureDepthSnapshot...)
+	for _, n := range []int{4, 20, 47, 60, len(full) - 1} {
+		if _, err := DecodeSBE(full[:n]); err == nil {
+			t.Fatalf("DecodeSBE(truncated %d) expected error", n)
+		}
+	}
+	short := append([]byte(nil), full...)
+	short[0] = 10 // blockLength 小于已知布局
+	if _, err := DecodeSBE(short); err == nil {
+		t.Fatalf("expected blockLength error")
+	}
+}
+
+func TestSBEDecimal(t *testing.T) {
+	cases := []struct {
+		m    int64
+		e    int8
+		want string
+	}{
+		{4210050, -2, "42100.50"},
+		{5, -3, "0.005"},
+		{-5, -1, "-0.5"},
+		{12, 2, "1200"},
+		{0, -2, "0"},
+		{0, 3, "0"},
+		{sbeNullInt64, -2, ""},
+	}
+	for _, tc := range cases {
+		if got := sbeDecimal(tc.m, tc.e); got != tc.want {
+			t.Fatalf("sbeDecimal(%d, %d) = %q, want %q", tc.m, tc.e, got, tc.want)
+		}
+	}
+}
@@ -55,7 +55,7 @@ func (s *SprdCancelAllAfterService) Do(ctx context.Context) (*SprdCancelAllAfter
 	req := sprdCancelAllAfterRequest{TimeOut: s.timeOut}
 
 	var data []SprdCancelAllAfterAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/sprd/cancel-all-after", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/sprd/cancel-all-after", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +50,7 @@ func (s *SprdGetOrderService) Do(ctx context.Context) (*SprdOrder, error) {
 	}
 
 	var data []SprdOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/sprd/order", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/sprd/order", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
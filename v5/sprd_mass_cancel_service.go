@@ -40,7 +40,7 @@ func (s *SprdMassCancelService) Do(ctx context.Context) (*SprdMassCancelAck, err
 	req := sprdMassCancelRequest{SprdId: s.sprdId}
 
 	var data []SprdMassCancelAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/sprd/mass-cancel", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/sprd/mass-cancel", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
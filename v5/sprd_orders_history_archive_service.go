@@ -118,7 +118,7 @@ func (s *SprdOrdersHistoryArchiveService) Do(ctx context.Context) ([]SprdOrder,
 	}
 
 	var data []SprdOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/sprd/orders-history-archive", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/sprd/orders-history-archive", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -101,7 +101,7 @@ func (s *SprdOrdersHistoryService) Do(ctx context.Context) ([]SprdOrder, error)
 	}
 
 	var data []SprdOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/sprd/orders-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/sprd/orders-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
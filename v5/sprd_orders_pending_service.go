@@ -83,7 +83,7 @@ func (s *SprdOrdersPendingService) Do(ctx context.Context) ([]SprdOrder, error)
 	}
 
 	var data []SprdOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/sprd/orders-pending", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/sprd/orders-pending", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
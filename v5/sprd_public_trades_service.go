@@ -45,7 +45,7 @@ func (s *SprdPublicTradesService) Do(ctx context.Context) ([]SprdPublicTrade, er
 	}
 
 	var data []SprdPublicTrade
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/sprd/public-trades", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/sprd/public-trades", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -91,7 +91,7 @@ func (s *SprdSpreadsService) Do(ctx context.Context) ([]SprdSpread, error) {
 	}
 
 	var data []SprdSpread
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/sprd/spreads", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/sprd/spreads", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
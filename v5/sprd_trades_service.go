@@ -98,7 +98,7 @@ func (s *SprdTradesService) Do(ctx context.Context) ([]SprdTrade, error) {
 	}
 
 	var data []SprdTrade
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/sprd/trades", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/sprd/trades", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
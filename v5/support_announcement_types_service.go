@@ -24,7 +24,7 @@ func (c *Client) NewSupportAnnouncementTypesService() *SupportAnnouncementTypesS
 // Do 获取公告类型（GET /api/v5/support/announcement-types）。
 func (s *SupportAnnouncementTypesService) Do(ctx context.Context) ([]SupportAnnouncementType, error) {
 	var data []SupportAnnouncementType
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/support/announcement-types", nil, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/support/announcement-types", nil, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -86,7 +86,7 @@ func (s *SupportAnnouncementsService) Do(ctx context.Context) (*SupportAnnouncem
 	}
 
 	var data []SupportAnnouncementsPage
-	if err := s.c.doWithHeaders(ctx, http.MethodGet, "/api/v5/support/announcements", q, nil, s.signed, header, &data); err != nil {
+	if err := s.c.doWithHeaders(ctx, CapabilityRead, http.MethodGet, "/api/v5/support/announcements", q, nil, s.signed, header, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
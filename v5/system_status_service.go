@@ -33,7 +33,7 @@ func (s *SystemStatusService) Do(ctx context.Context) ([]SystemStatus, error) {
 	}
 
 	var data []SystemStatus
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/system/status", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/system/status", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
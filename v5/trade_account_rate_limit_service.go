@@ -31,7 +31,7 @@ var errEmptyTradeAccountRateLimitResponse = errors.New("okx: empty trade account
 // Do 获取账户限速信息（GET /api/v5/trade/account-rate-limit）。
 func (s *TradeAccountRateLimitService) Do(ctx context.Context) (*TradeAccountRateLimit, error) {
 	var data []TradeAccountRateLimit
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/trade/account-rate-limit", nil, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/account-rate-limit", nil, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -160,7 +160,7 @@ func (s *AmendAlgoOrderService) Do(ctx context.Context) (*TradeAlgoOrderAck, err
 	}
 
 	var data []TradeAlgoOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/amend-algos", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/amend-algos", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -129,6 +129,9 @@ func (s *AmendOrderService) Do(ctx context.Context) (*TradeOrderAck, error) {
 	if s.newSz == "" && s.newPx == "" && s.newPxUsd == "" && s.newPxVol == "" {
 		return nil, errAmendOrderMissingChange
 	}
+	if err := s.c.checkPreTradeRisk("/api/v5/trade/amend-order", riskOrder{instId: s.instId, ordId: s.ordId, clOrdId: s.clOrdId, px: s.newPx, sz: s.newSz, amend: true}); err != nil {
+		return nil, err
+	}
 
 	req := amendOrderRequest{
 		InstId:      s.instId,
@@ -149,7 +152,7 @@ func (s *AmendOrderService) Do(ctx context.Context) (*TradeOrderAck, error) {
 		header = make(http.Header)
 		header.Set("expTime", s.expTimeHeader)
 	}
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/amend-order", nil, req, true, header, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/amend-order", nil, req, true, header, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -77,13 +77,21 @@ func (s *BatchAmendOrdersService) Do(ctx context.Context) ([]TradeOrderAck, erro
 		req = append(req, o)
 	}
 
+	risk := make([]riskOrder, 0, len(req))
+	for _, o := range req {
+		risk = append(risk, riskOrderFromBatchAmend(o))
+	}
+	if err := s.c.checkPreTradeRisk("/api/v5/trade/amend-batch-orders", risk...); err != nil {
+		return nil, err
+	}
+
 	var data []TradeOrderAck
 	var header http.Header
 	if s.expTimeHeader != "" {
 		header = make(http.Header)
 		header.Set("expTime", s.expTimeHeader)
 	}
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/amend-batch-orders", nil, req, true, header, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/amend-batch-orders", nil, req, true, header, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +57,7 @@ func (s *BatchCancelOrdersService) Do(ctx context.Context) ([]TradeOrderAck, err
 	}
 
 	var data []TradeOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/cancel-batch-orders", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/cancel-batch-orders", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +59,7 @@ func (s *CancelAlgoOrdersService) Do(ctx context.Context) ([]TradeAlgoOrderAck,
 	}
 
 	var data []TradeAlgoOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/cancel-algos", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/cancel-algos", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +79,7 @@ func (s *CancelAllAfterService) Do(ctx context.Context) (*TradeCancelAllAfterAck
 	}
 
 	var data []TradeCancelAllAfterAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/cancel-all-after", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/cancel-all-after", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +69,7 @@ func (s *CancelOrderService) Do(ctx context.Context) (*TradeOrderAck, error) {
 	}
 
 	var data []TradeOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/cancel-order", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/cancel-order", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
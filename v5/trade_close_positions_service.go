@@ -115,6 +115,14 @@ func (s *ClosePositionsService) Do(ctx context.Context) ([]TradeClosePositionAck
 	if s.instId == "" || s.mgnMode == "" {
 		return nil, errClosePositionsMissingRequired
 	}
+	if err := s.c.checkPreTradeRisk("/api/v5/trade/close-position", riskOrder{
+		instId:  s.instId,
+		tdMode:  s.mgnMode,
+		posSide: s.posSide,
+		closing: true,
+	}); err != nil {
+		return nil, err
+	}
 
 	req := closePositionsRequest{
 		InstId:  s.instId,
@@ -127,7 +135,7 @@ func (s *ClosePositionsService) Do(ctx context.Context) ([]TradeClosePositionAck
 	}
 
 	var data []TradeClosePositionAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/close-position", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/close-position", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
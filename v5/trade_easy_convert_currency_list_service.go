@@ -33,7 +33,7 @@ func (s *EasyConvertCurrencyListService) Do(ctx context.Context) ([]EasyConvertC
 	}
 
 	var data []EasyConvertCurrencyList
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/trade/easy-convert-currency-list", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/easy-convert-currency-list", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
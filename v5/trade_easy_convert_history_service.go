@@ -55,7 +55,7 @@ func (s *EasyConvertHistoryService) Do(ctx context.Context) ([]EasyConvertHistor
 	}
 
 	var data []EasyConvertHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/trade/easy-convert-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/easy-convert-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -95,7 +95,7 @@ func (s *EasyConvertService) Do(ctx context.Context) ([]EasyConvertAck, error) {
 	}
 
 	var data []EasyConvertAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/easy-convert", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/easy-convert", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
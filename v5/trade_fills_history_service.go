@@ -122,7 +122,7 @@ func (s *TradeFillsHistoryService) Do(ctx context.Context) ([]TradeFill, error)
 	}
 
 	var data []TradeFill
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/trade/fills-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/fills-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -116,7 +116,7 @@ func (s *TradeFillsService) Do(ctx context.Context) ([]TradeFill, error) {
 	}
 
 	var data []TradeFill
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/trade/fills", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/fills", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
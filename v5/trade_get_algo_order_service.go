@@ -51,7 +51,7 @@ func (s *GetAlgoOrderService) Do(ctx context.Context) (*TradeAlgoOrder, error) {
 	}
 
 	var data []TradeAlgoOrder
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodGet, "/api/v5/trade/order-algo", q, nil, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/order-algo", q, nil, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +63,7 @@ func (s *GetOrderService) Do(ctx context.Context) (*TradeOrder, error) {
 	}
 
 	var data []TradeOrder
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodGet, "/api/v5/trade/order", q, nil, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/order", q, nil, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
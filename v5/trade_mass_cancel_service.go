@@ -77,7 +77,7 @@ func (s *MassCancelService) Do(ctx context.Context) (*TradeMassCancelAck, error)
 	}
 
 	var data []TradeMassCancelAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/mass-cancel", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/mass-cancel", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
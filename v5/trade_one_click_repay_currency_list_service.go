@@ -33,7 +33,7 @@ func (s *OneClickRepayCurrencyListService) Do(ctx context.Context) ([]OneClickRe
 	}
 
 	var data []OneClickRepayCurrencyList
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/trade/one-click-repay-currency-list", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/one-click-repay-currency-list", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -53,7 +53,7 @@ func (s *OneClickRepayHistoryService) Do(ctx context.Context) ([]OneClickRepayHi
 	}
 
 	var data []OneClickRepayHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/trade/one-click-repay-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/one-click-repay-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -53,7 +53,7 @@ func (s *OneClickRepayHistoryV2Service) Do(ctx context.Context) ([]OneClickRepay
 	}
 
 	var data []OneClickRepayHistoryV2Item
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/trade/one-click-repay-history-v2", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/one-click-repay-history-v2", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -86,7 +86,7 @@ func (s *OneClickRepayService) Do(ctx context.Context) ([]OneClickRepayAck, erro
 	}
 
 	var data []OneClickRepayAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/one-click-repay", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/trade/one-click-repay", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
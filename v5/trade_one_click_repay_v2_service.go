@@ -92,7 +92,7 @@ func (s *OneClickRepayV2Service) Do(ctx context.Context) (*OneClickRepayV2Ack, e
 	}
 
 	var data []OneClickRepayV2Ack
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/one-click-repay-v2", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityFinance, http.MethodPost, "/api/v5/trade/one-click-repay-v2", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
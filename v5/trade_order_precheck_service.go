@@ -116,7 +116,7 @@ func (s *OrderPrecheckService) Do(ctx context.Context) ([]OrderPrecheckResult, e
 	}
 
 	var data []OrderPrecheckResult
-	if err := s.c.do(ctx, http.MethodPost, "/api/v5/trade/order-precheck", nil, s.req, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodPost, "/api/v5/trade/order-precheck", nil, s.req, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
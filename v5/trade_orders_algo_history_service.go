@@ -113,7 +113,7 @@ func (s *AlgoOrdersHistoryService) Do(ctx context.Context) ([]TradeAlgoOrder, er
 	}
 
 	var data []TradeAlgoOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/trade/orders-algo-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/orders-algo-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -132,7 +132,7 @@ func (s *OrdersHistoryArchiveService) Do(ctx context.Context) ([]TradeOrder, err
 	}
 
 	var data []TradeOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/trade/orders-history-archive", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/orders-history-archive", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
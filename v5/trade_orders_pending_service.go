@@ -96,7 +96,7 @@ func (s *OrdersPendingService) Do(ctx context.Context) ([]TradeOrder, error) {
 	}
 
 	var data []TradeOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/trade/orders-pending", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/trade/orders-pending", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
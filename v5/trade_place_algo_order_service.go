@@ -271,9 +271,30 @@ func (s *PlaceAlgoOrderService) Do(ctx context.Context) (*TradeAlgoOrderAck, err
 	if s.req.Sz != "" && s.req.CloseFraction != "" {
 		return nil, errPlaceAlgoOrderSzAndCloseFractionConflict
 	}
+	if err := s.c.checkPreTradeRisk("/api/v5/trade/order-algo", riskOrder{
+		instId:      s.req.InstId,
+		tdMode:      s.req.TdMode,
+		side:        s.req.Side,
+		posSide:     s.req.PosSide,
+		px:          s.req.OrderPx,
+		sz:          s.req.Sz,
+		tgtCcy:      s.req.TgtCcy,
+		ordType:     s.req.OrdType,
+		triggerPx:   s.req.TriggerPx,
+		tpTriggerPx: s.req.TpTriggerPx,
+		tpOrdPx:     s.req.TpOrdPx,
+		slTriggerPx: s.req.SlTriggerPx,
+		slOrdPx:     s.req.SlOrdPx,
+		reduceOnly:  s.req.ReduceOnly != nil && *s.req.ReduceOnly,
+		market:      algoOrderMarket(s.req),
+		algo:        true,
+		closing:     s.req.CloseFraction != "",
+	}); err != nil {
+		return nil, err
+	}
 
 	var data []TradeAlgoOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/order-algo", nil, s.req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/order-algo", nil, s.req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -300,3 +321,14 @@ func (s *PlaceAlgoOrderService) Do(ctx context.Context) (*TradeAlgoOrderAck, err
 	}
 	return &data[0], nil
 }
+
+// algoOrderMarket 报告策略委托触发后是否以市价成交（委托价为 "-1" 或未设置）。
+func algoOrderMarket(r placeAlgoOrderRequest) bool {
+	switch r.OrdType {
+	case "trigger":
+		return r.OrderPx == "" || r.OrderPx == "-1"
+	case "conditional", "oco":
+		return (r.TpOrdPx == "" || r.TpOrdPx == "-1") && (r.SlOrdPx == "" || r.SlOrdPx == "-1")
+	}
+	return false
+}
@@ -188,6 +188,20 @@ func (s *PlaceOrderService) Do(ctx context.Context) (*TradeOrderAck, error) {
 	if countNonEmptyStrings(s.px, s.pxUsd, s.pxVol) > 1 {
 		return nil, errPlaceOrderTooManyPx
 	}
+	if err := s.c.checkPreTradeRisk("/api/v5/trade/order", riskOrder{
+		instId:     s.instId,
+		tdMode:     s.tdMode,
+		side:       s.side,
+		posSide:    s.posSide,
+		px:         s.px,
+		sz:         s.sz,
+		tgtCcy:     s.tgtCcy,
+		ordType:    s.ordType,
+		reduceOnly: s.reduceOnly != nil && *s.reduceOnly,
+		market:     s.ordType == "market",
+	}); err != nil {
+		return nil, err
+	}
 
 	req := placeOrderRequest{
 		InstId:  s.instId,
@@ -217,7 +231,7 @@ func (s *PlaceOrderService) Do(ctx context.Context) (*TradeOrderAck, error) {
 		header = make(http.Header)
 		header.Set("expTime", s.expTimeHeader)
 	}
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/trade/order", nil, req, true, header, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/trade/order", nil, req, true, header, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +57,9 @@ type TradeOrder struct {
 
 	UTime int64 `json:"uTime,string"`
 	CTime int64 `json:"cTime,string"`
+
+	// Recovered 为 true 表示该条由 WS 断线补偿（WithWSGapFill）经 REST 查询补发，而非实时推送。
+	Recovered bool `json:"-"`
 }
 
 // TradeFill 表示成交明细（精简版）。
@@ -52,7 +52,7 @@ func (s *TradingBotGridAdjustInvestmentService) Do(ctx context.Context) (*Tradin
 	}
 
 	var data []TradingBotAlgoIdAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/grid/adjust-investment", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/grid/adjust-investment", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
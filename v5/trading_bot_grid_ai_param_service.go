@@ -65,7 +65,7 @@ func (s *TradingBotGridAIParamService) Do(ctx context.Context) ([]TradingBotGrid
 	}
 
 	var data []TradingBotGridAIParam
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/grid/ai-param", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/grid/ai-param", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
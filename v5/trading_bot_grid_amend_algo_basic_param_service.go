@@ -71,7 +71,7 @@ func (s *TradingBotGridAmendAlgoBasicParamService) Do(ctx context.Context) (*Tra
 	}
 
 	var raw json.RawMessage
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/grid/amend-algo-basic-param", nil, s.r, true, nil, &raw)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/grid/amend-algo-basic-param", nil, s.r, true, nil, &raw)
 	if err != nil {
 		return nil, err
 	}
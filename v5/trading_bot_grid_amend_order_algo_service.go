@@ -105,7 +105,7 @@ func (s *TradingBotGridAmendOrderAlgoService) Do(ctx context.Context) (*TradingB
 	}
 
 	var data []TradingBotOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/grid/amend-order-algo", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/grid/amend-order-algo", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
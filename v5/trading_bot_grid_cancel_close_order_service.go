@@ -46,7 +46,7 @@ func (s *TradingBotGridCancelCloseOrderService) Do(ctx context.Context) (*Tradin
 	}
 
 	var data []TradingBotGridCloseOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/grid/cancel-close-order", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/grid/cancel-close-order", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
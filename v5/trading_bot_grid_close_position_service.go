@@ -62,7 +62,7 @@ func (s *TradingBotGridClosePositionService) Do(ctx context.Context) (*TradingBo
 	}
 
 	var data []TradingBotGridCloseOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/grid/close-position", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/grid/close-position", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
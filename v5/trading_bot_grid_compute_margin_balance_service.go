@@ -52,7 +52,7 @@ func (s *TradingBotGridComputeMarginBalanceService) Do(ctx context.Context) (*Tr
 	}
 
 	var data []TradingBotGridComputeMarginBalanceResult
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/grid/compute-margin-balance", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityRead, http.MethodPost, "/api/v5/tradingBot/grid/compute-margin-balance", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
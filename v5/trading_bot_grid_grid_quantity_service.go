@@ -77,7 +77,7 @@ func (s *TradingBotGridGridQuantityService) Do(ctx context.Context) (*TradingBot
 	}
 
 	var data []TradingBotGridMaxGridQty
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/grid/grid-quantity", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/grid/grid-quantity", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -62,7 +62,7 @@ func (s *TradingBotGridMarginBalanceService) Do(ctx context.Context) (*TradingBo
 	}
 
 	var data []TradingBotOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/grid/margin-balance", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/grid/margin-balance", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
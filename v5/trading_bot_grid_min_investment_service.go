@@ -121,7 +121,7 @@ func (s *TradingBotGridMinInvestmentService) Do(ctx context.Context) (*TradingBo
 	}
 
 	var data []TradingBotGridMinInvestmentResult
-	if err := s.c.do(ctx, http.MethodPost, "/api/v5/tradingBot/grid/min-investment", nil, s.r, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodPost, "/api/v5/tradingBot/grid/min-investment", nil, s.r, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
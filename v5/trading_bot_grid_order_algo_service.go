@@ -192,7 +192,7 @@ func (s *TradingBotGridOrderAlgoService) Do(ctx context.Context) (*TradingBotOrd
 	}
 
 	var data []TradingBotOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/grid/order-algo", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/grid/order-algo", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
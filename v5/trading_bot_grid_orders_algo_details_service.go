@@ -46,7 +46,7 @@ func (s *TradingBotGridOrdersAlgoDetailsService) Do(ctx context.Context) (*Tradi
 	q.Set("algoId", s.algoId)
 
 	var data []TradingBotGridOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/grid/orders-algo-details", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/grid/orders-algo-details", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -91,7 +91,7 @@ func (s *TradingBotGridOrdersAlgoHistoryService) Do(ctx context.Context) ([]Trad
 	}
 
 	var data []TradingBotGridOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/grid/orders-algo-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/grid/orders-algo-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
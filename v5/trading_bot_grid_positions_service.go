@@ -43,7 +43,7 @@ func (s *TradingBotGridPositionsService) Do(ctx context.Context) ([]TradingBotGr
 	q.Set("algoId", s.algoId)
 
 	var data []TradingBotGridPosition
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/grid/positions", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/grid/positions", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -58,7 +58,7 @@ func (s *TradingBotGridStopOrderAlgoService) Do(ctx context.Context) ([]TradingB
 	}
 
 	var data []TradingBotOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/grid/stop-order-algo", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/grid/stop-order-algo", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +88,7 @@ func (s *TradingBotGridSubOrdersService) Do(ctx context.Context) ([]TradingBotGr
 	}
 
 	var data []TradingBotGridSubOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/grid/sub-orders", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/grid/sub-orders", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
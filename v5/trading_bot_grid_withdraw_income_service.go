@@ -40,7 +40,7 @@ func (s *TradingBotGridWithdrawIncomeService) Do(ctx context.Context) (*TradingB
 	}
 
 	var data []TradingBotGridWithdrawIncomeAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/grid/withdraw-income", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/grid/withdraw-income", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +78,7 @@ func (s *TradingBotPublicRSIBackTestingService) Do(ctx context.Context) (*Tradin
 	}
 
 	var data []TradingBotPublicRSIBackTestingResult
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/public/rsi-back-testing", q, nil, false, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/public/rsi-back-testing", q, nil, false, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -46,7 +46,7 @@ func (s *TradingBotRecurringAmendOrderAlgoService) Do(ctx context.Context) (*Tra
 	}
 
 	var data []TradingBotOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/recurring/amend-order-algo", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/recurring/amend-order-algo", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +122,7 @@ func (s *TradingBotRecurringOrderAlgoService) Do(ctx context.Context) (*TradingB
 	}
 
 	var data []TradingBotOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/recurring/order-algo", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/recurring/order-algo", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +39,7 @@ func (s *TradingBotRecurringOrdersAlgoDetailsService) Do(ctx context.Context) (*
 	q.Set("algoId", s.algoId)
 
 	var data []TradingBotRecurringOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/recurring/orders-algo-details", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/recurring/orders-algo-details", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
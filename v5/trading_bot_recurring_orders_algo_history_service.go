@@ -62,7 +62,7 @@ func (s *TradingBotRecurringOrdersAlgoHistoryService) Do(ctx context.Context) ([
 	}
 
 	var data []TradingBotRecurringOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/recurring/orders-algo-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/recurring/orders-algo-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
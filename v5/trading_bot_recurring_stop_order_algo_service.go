@@ -55,7 +55,7 @@ func (s *TradingBotRecurringStopOrderAlgoService) Do(ctx context.Context) ([]Tra
 	}
 
 	var data []TradingBotOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/recurring/stop-order-algo", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/recurring/stop-order-algo", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +73,7 @@ func (s *TradingBotRecurringSubOrdersService) Do(ctx context.Context) ([]Trading
 	}
 
 	var data []TradingBotRecurringSubOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/recurring/sub-orders", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/recurring/sub-orders", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
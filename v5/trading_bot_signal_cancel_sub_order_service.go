@@ -52,7 +52,7 @@ func (s *TradingBotSignalCancelSubOrderService) Do(ctx context.Context) (*Tradin
 	}
 
 	var data []TradingBotSignalCancelSubOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/signal/cancel-sub-order", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/signal/cancel-sub-order", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
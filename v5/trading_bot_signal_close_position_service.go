@@ -46,7 +46,7 @@ func (s *TradingBotSignalClosePositionService) Do(ctx context.Context) (*Trading
 	}
 
 	var data []TradingBotAlgoIdAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/signal/close-position", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/signal/close-position", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +46,7 @@ func (s *TradingBotSignalCreateSignalService) Do(ctx context.Context) (*TradingB
 	}
 
 	var data []TradingBotSignalCreateAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/signal/create-signal", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/signal/create-signal", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
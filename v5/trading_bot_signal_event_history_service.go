@@ -64,7 +64,7 @@ func (s *TradingBotSignalEventHistoryService) Do(ctx context.Context) ([]Trading
 	}
 
 	var data []TradingBotSignalEventHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/signal/event-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/signal/event-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -46,7 +46,7 @@ func (s *TradingBotSignalOrdersAlgoDetailsService) Do(ctx context.Context) (*Tra
 	q.Set("algoId", s.algoId)
 
 	var data []TradingBotSignalOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/signal/orders-algo-details", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/signal/orders-algo-details", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	if len(data) == 0 {
@@ -71,7 +71,7 @@ func (s *TradingBotSignalOrdersAlgoHistoryService) Do(ctx context.Context) ([]Tr
 	}
 
 	var data []TradingBotSignalOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/signal/orders-algo-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/signal/orders-algo-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -73,7 +73,7 @@ func (s *TradingBotSignalOrdersAlgoPendingService) Do(ctx context.Context) ([]Tr
 	}
 
 	var data []TradingBotSignalOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/signal/orders-algo-pending", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/signal/orders-algo-pending", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
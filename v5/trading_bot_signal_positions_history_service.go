@@ -73,7 +73,7 @@ func (s *TradingBotSignalPositionsHistoryService) Do(ctx context.Context) ([]Tra
 	}
 
 	var data []TradingBotSignalPositionsHistory
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/signal/positions-history", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/signal/positions-history", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
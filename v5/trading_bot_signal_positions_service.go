@@ -43,7 +43,7 @@ func (s *TradingBotSignalPositionsService) Do(ctx context.Context) ([]TradingBot
 	q.Set("algoId", s.algoId)
 
 	var data []TradingBotSignalPosition
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/signal/positions", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/signal/positions", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -56,7 +56,7 @@ func (s *TradingBotSignalSetInstrumentsService) Do(ctx context.Context) (*Tradin
 	}
 
 	var data []TradingBotAlgoIdAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/signal/set-instruments", nil, s.r, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/signal/set-instruments", nil, s.r, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
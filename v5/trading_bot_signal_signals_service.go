@@ -73,7 +73,7 @@ func (s *TradingBotSignalSignalsService) Do(ctx context.Context) ([]TradingBotSi
 	}
 
 	var data []TradingBotSignal
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/signal/signals", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/signal/signals", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
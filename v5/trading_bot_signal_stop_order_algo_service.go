@@ -55,7 +55,7 @@ func (s *TradingBotSignalStopOrderAlgoService) Do(ctx context.Context) ([]Tradin
 	}
 
 	var data []TradingBotOrderAck
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/tradingBot/signal/stop-order-algo", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/signal/stop-order-algo", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
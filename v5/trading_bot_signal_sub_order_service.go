@@ -74,5 +74,5 @@ func (s *TradingBotSignalSubOrderService) Do(ctx context.Context) error {
 		return errTradingBotSignalSubOrderMissingRequired
 	}
 
-	return s.c.do(ctx, http.MethodPost, "/api/v5/tradingBot/signal/sub-order", nil, s.r, true, nil)
+	return s.c.do(ctx, CapabilityTrade, http.MethodPost, "/api/v5/tradingBot/signal/sub-order", nil, s.r, true, nil)
 }
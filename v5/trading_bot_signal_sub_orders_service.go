@@ -136,7 +136,7 @@ func (s *TradingBotSignalSubOrdersService) Do(ctx context.Context) ([]TradingBot
 	}
 
 	var data []TradingBotSignalSubOrder
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/tradingBot/signal/sub-orders", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/tradingBot/signal/sub-orders", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -33,7 +33,7 @@ func (s *UsersEntrustSubaccountListService) Do(ctx context.Context) ([]UsersEntr
 	}
 
 	var data []UsersEntrustSubaccount
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/users/entrust-subaccount-list", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/users/entrust-subaccount-list", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
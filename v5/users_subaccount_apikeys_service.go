@@ -47,7 +47,7 @@ func (s *UsersSubaccountAPIKeysService) Do(ctx context.Context) ([]UsersSubaccou
 	}
 
 	var data []UsersSubaccountAPIKeyInfo
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/users/subaccount/apikey", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/users/subaccount/apikey", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
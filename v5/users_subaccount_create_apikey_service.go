@@ -88,7 +88,7 @@ func (s *UsersSubaccountCreateAPIKeyService) Do(ctx context.Context) (*UsersSuba
 	}
 
 	var data []UsersSubaccountAPIKeyCreateResult
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/users/subaccount/apikey", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilitySubAccountAdmin, http.MethodPost, "/api/v5/users/subaccount/apikey", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +79,7 @@ func (s *UsersSubaccountCreateSubaccountService) Do(ctx context.Context) (*Users
 	}
 
 	var data []UsersSubaccountCreateSubaccountResult
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/users/subaccount/create-subaccount", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilitySubAccountAdmin, http.MethodPost, "/api/v5/users/subaccount/create-subaccount", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
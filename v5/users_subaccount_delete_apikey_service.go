@@ -57,7 +57,7 @@ func (s *UsersSubaccountDeleteAPIKeyService) Do(ctx context.Context) (*UsersSuba
 	}
 
 	var data []UsersSubaccountDeleteAPIKeyResult
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/users/subaccount/delete-apikey", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilitySubAccountAdmin, http.MethodPost, "/api/v5/users/subaccount/delete-apikey", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
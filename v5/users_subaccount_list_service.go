@@ -76,7 +76,7 @@ func (s *UsersSubaccountListService) Do(ctx context.Context) ([]UsersSubaccount,
 	}
 
 	var data []UsersSubaccount
-	if err := s.c.do(ctx, http.MethodGet, "/api/v5/users/subaccount/list", q, nil, true, &data); err != nil {
+	if err := s.c.do(ctx, CapabilityRead, http.MethodGet, "/api/v5/users/subaccount/list", q, nil, true, &data); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -84,7 +84,7 @@ func (s *UsersSubaccountModifyAPIKeyService) Do(ctx context.Context) (*UsersSuba
 	}
 
 	var data []UsersSubaccountAPIKeyInfo
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/users/subaccount/modify-apikey", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilitySubAccountAdmin, http.MethodPost, "/api/v5/users/subaccount/modify-apikey", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
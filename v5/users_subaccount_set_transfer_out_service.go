@@ -53,7 +53,7 @@ func (s *UsersSubaccountSetTransferOutService) Do(ctx context.Context) ([]UsersS
 	}
 
 	var data []UsersSubaccountTransferOutPermission
-	requestID, err := s.c.doWithHeadersAndRequestID(ctx, http.MethodPost, "/api/v5/users/subaccount/set-transfer-out", nil, req, true, nil, &data)
+	requestID, err := s.c.doWithHeadersAndRequestID(ctx, CapabilitySubAccountAdmin, http.MethodPost, "/api/v5/users/subaccount/set-transfer-out", nil, req, true, nil, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,442 @@
+package okx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// wsChannelRoute 为频道注册表中的一条路由：匹配 channel、判断是否已设置 handler，并解析/分发推送。
+type wsChannelRoute struct {
+	pattern string
+	prefix  bool
+	key     string
+
+	// enabled 判断 handler 是否已设置（调用方持有 typedMu 读锁）。
+	enabled func(w *WSClient) bool
+	handle  func(w *WSClient, channel string, message []byte)
+}
+
+// parseWSChannelPattern 解析频道匹配模式：以 * 结尾表示前缀匹配（如 "candle*"），否则为精确匹配。
+func parseWSChannelPattern(pattern string) (key string, prefix bool) {
+	if p, ok := strings.CutSuffix(pattern, "*"); ok {
+		return p, true
+	}
+	return pattern, false
+}
+
+func wsChannelPatternMatch(pattern, channel string) bool {
+	key, prefix := parseWSChannelPattern(pattern)
+	if prefix {
+		return strings.HasPrefix(channel, key)
+	}
+	return channel == key
+}
+
+func newWSChannelRoute(pattern string, enabled func(w *WSClient) bool, handle func(w *WSClient, channel string, message []byte)) *wsChannelRoute {
+	key, prefix := parseWSChannelPattern(pattern)
+	return &wsChannelRoute{pattern: pattern, prefix: prefix, key: key, enabled: enabled, handle: handle}
+}
+
+func (r *wsChannelRoute) matches(channel string) bool {
+	if r.prefix {
+		return strings.HasPrefix(channel, r.key)
+	}
+	return channel == r.key
+}
+
+// wsChannelRegistry 按频道名（精确）或前缀索引路由。
+type wsChannelRegistry struct {
+	exact  map[string][]*wsChannelRoute
+	prefix []*wsChannelRoute
+	all    []*wsChannelRoute
+}
+
+func newWSChannelRegistry(routes ...*wsChannelRoute) *wsChannelRegistry {
+	r := &wsChannelRegistry{exact: make(map[string][]*wsChannelRoute)}
+	for _, route := range routes {
+		r.add(route)
+	}
+	return r
+}
+
+func (r *wsChannelRegistry) add(route *wsChannelRoute) {
+	if route.prefix {
+		r.prefix = append(r.prefix, route)
+	} else {
+		r.exact[route.key] = append(r.exact[route.key], route)
+	}
+	r.all = append(r.all, route)
+}
+
+// anyEnabled 判断是否存在已设置 handler 的路由（调用方持有 typedMu 读锁）。
+func (r *wsChannelRegistry) anyEnabled(w *WSClient) bool {
+	for _, route := range r.all {
+		if route.enabled(w) {
+			return true
+		}
+	}
+	return false
+}
+
+// match 将 channel 匹配且已启用的路由追加到 dst（调用方持有 typedMu 读锁）。
+func (r *wsChannelRegistry) match(w *WSClient, channel string, dst []*wsChannelRoute) []*wsChannelRoute {
+	for _, route := range r.exact[channel] {
+		if route.enabled(w) {
+			dst = append(dst, route)
+		}
+	}
+	for _, route := range r.prefix {
+		if route.matches(channel) && route.enabled(w) {
+			dst = append(dst, route)
+		}
+	}
+	return dst
+}
+
+// WithWSChannelHandler 为任意频道注册逐消息回调（可用于 SDK 尚未建模的 OKX 频道）。
+//
+// pattern 为精确频道名，或以 * 结尾的前缀（如 "candle*"）；data 通过 WSParseChannelData[T] 解码。
+// 与内置 typed handler 相同：默认在独立 worker goroutine 中执行，如需在 WS read goroutine 中执行，可使用 WithWSTypedHandlerInline。
+func WithWSChannelHandler[T any](pattern string, handler func(data WSData[T])) WSOption {
+	return func(c *WSClient) {
+		OnWSChannel(c, pattern, handler)
+	}
+}
+
+// OnWSChannel 设置 pattern 对应频道推送的逐消息回调（可在 Start 前或运行中设置；传 nil 表示清空）。
+//
+// 同一 pattern 重复设置会替换之前的回调；与内置频道重叠时两者都会收到推送。
+func OnWSChannel[T any](w *WSClient, pattern string, handler func(data WSData[T])) {
+	if w == nil || pattern == "" {
+		return
+	}
+	w.typedMu.Lock()
+	defer w.typedMu.Unlock()
+	if handler == nil {
+		delete(w.channelRoutes, pattern)
+		return
+	}
+	if w.channelRoutes == nil {
+		w.channelRoutes = make(map[string]*wsChannelRoute)
+	}
+	w.channelRoutes[pattern] = newWSChannelRoute(pattern, wsChannelAlwaysEnabled, func(w *WSClient, channel string, message []byte) {
+		dm, ok, err := WSParseChannelData[T](message, pattern)
+		if !w.wsParseGuard(channel, ok, err) {
+			return
+		}
+		w.dispatchTyped(wsTypedTask{kind: wsTypedKindChannel, call: func() { handler(*dm) }})
+	})
+}
+
+func wsChannelAlwaysEnabled(*WSClient) bool { return true }
+
+// wsBuiltinRoute 为内置频道构造路由：解析后按 task 投递到 typed 分发（data 为空时忽略）。
+func wsBuiltinRoute[T any](pattern string, parse func([]byte) (*WSData[T], bool, error), enabled func(w *WSClient) bool, task func(dm *WSData[T]) wsTypedTask) *wsChannelRoute {
+	return newWSChannelRoute(pattern, enabled, func(w *WSClient, channel string, message []byte) {
+		dm, ok, err := parse(message)
+		if !w.wsParseGuard(channel, ok, err) || len(dm.Data) == 0 {
+			return
+		}
+		if w.conflator != nil && w.conflator.channels[channel] {
+			w.dispatchConflated(wsConflationKey(channel, &dm.Arg), task(dm))
+			return
+		}
+		w.dispatchTyped(task(dm))
+	})
+}
+
+func wsCandleTask(dm *WSData[Candle]) wsTypedTask {
+	out := make([]WSCandle, 0, len(dm.Data))
+	for _, c := range dm.Data {
+		out = append(out, WSCandle{Arg: dm.Arg, Candle: c})
+	}
+	return wsTypedTask{kind: wsTypedKindCandles, candles: out}
+}
+
+func wsPriceCandleTask(dm *WSData[PriceCandle]) wsTypedTask {
+	out := make([]WSPriceCandle, 0, len(dm.Data))
+	for _, c := range dm.Data {
+		out = append(out, WSPriceCandle{Arg: dm.Arg, Candle: c})
+	}
+	return wsTypedTask{kind: wsTypedKindPriceCandles, priceCandles: out}
+}
+
+// wsBuiltinChannels 为 SDK 内置 typed handler 的频道注册表（新增内置频道只需在此追加一条路由）。
+var wsBuiltinChannels = newWSChannelRegistry(wsBuiltinChannelRoutes()...)
+
+func wsBuiltinChannelRoutes() []*wsChannelRoute {
+	routes := []*wsChannelRoute{
+		wsBuiltinRoute(WSChannelOrders, WSParseOrders,
+			func(w *WSClient) bool { return w.ordersHandler != nil || w.hasConsumers(wsTypedKindOrders) },
+			func(dm *WSData[TradeOrder]) wsTypedTask { return wsTypedTask{kind: wsTypedKindOrders, orders: dm.Data} }),
+		wsBuiltinRoute(WSChannelFills, WSParseFills,
+			func(w *WSClient) bool { return w.fillsHandler != nil || w.hasConsumers(wsTypedKindFills) },
+			func(dm *WSData[WSFill]) wsTypedTask { return wsTypedTask{kind: wsTypedKindFills, fills: dm.Data} }),
+		wsBuiltinRoute(WSChannelAccount, WSParseAccount,
+			func(w *WSClient) bool { return w.accountHandler != nil || w.hasConsumers(wsTypedKindAccount) },
+			func(dm *WSData[AccountBalance]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindAccount, balances: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelPositions, WSParsePositions,
+			func(w *WSClient) bool { return w.positionsHandler != nil || w.hasConsumers(wsTypedKindPositions) },
+			func(dm *WSData[AccountPosition]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindPositions, positions: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelBalanceAndPosition, WSParseBalanceAndPosition,
+			func(w *WSClient) bool {
+				return w.balanceAndPositionHandler != nil || w.hasConsumers(wsTypedKindBalanceAndPosition)
+			},
+			func(dm *WSData[WSBalanceAndPosition]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindBalanceAndPosition, balPos: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelLiquidationWarning, WSParseLiquidationWarning,
+			func(w *WSClient) bool { return w.liquidationWarningHandler != nil },
+			func(dm *WSData[WSLiquidationWarning]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindLiquidationWarning, liquidationWarnings: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelAccountGreeks, WSParseAccountGreeks,
+			func(w *WSClient) bool { return w.accountGreeksHandler != nil },
+			func(dm *WSData[AccountGreeks]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindAccountGreeks, accountGreeks: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelOrdersAlgo, WSParseOrdersAlgo,
+			func(w *WSClient) bool { return w.ordersAlgoHandler != nil || w.hasConsumers(wsTypedKindOrdersAlgo) },
+			func(dm *WSData[TradeAlgoOrder]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindOrdersAlgo, ordersAlgo: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelAlgoAdvance, WSParseAlgoAdvance,
+			func(w *WSClient) bool { return w.algoAdvanceHandler != nil },
+			func(dm *WSData[TradeAlgoOrder]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindAlgoAdvance, algoAdvance: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelGridOrdersSpot, WSParseGridOrdersSpot,
+			func(w *WSClient) bool { return w.gridOrdersSpotHandler != nil },
+			func(dm *WSData[WSGridOrder]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindGridOrdersSpot, gridOrdersSpot: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelGridOrdersContract, WSParseGridOrdersContract,
+			func(w *WSClient) bool { return w.gridOrdersContractHandler != nil },
+			func(dm *WSData[WSGridOrder]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindGridOrdersContract, gridOrdersContract: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelGridPositions, WSParseGridPositions,
+			func(w *WSClient) bool { return w.gridPositionsHandler != nil },
+			func(dm *WSData[WSGridPosition]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindGridPositions, gridPositions: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelGridSubOrders, WSParseGridSubOrders,
+			func(w *WSClient) bool { return w.gridSubOrdersHandler != nil },
+			func(dm *WSData[WSGridSubOrder]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindGridSubOrders, gridSubOrders: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelAlgoRecurringBuy, WSParseAlgoRecurringBuy,
+			func(w *WSClient) bool { return w.algoRecurringBuyHandler != nil },
+			func(dm *WSData[WSRecurringBuyOrder]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindAlgoRecurringBuy, algoRecurringBuy: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelCopytradingLeadNotification, WSParseCopytradingLeadNotification,
+			func(w *WSClient) bool { return w.copyTradingLeadNotificationHandler != nil },
+			func(dm *WSData[WSCopyTradingLeadNotification]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindCopyTradingLeadNotification, copyTradingLeadNotification: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelRFQs, WSParseRFQs,
+			func(w *WSClient) bool { return w.rfqsHandler != nil },
+			func(dm *WSData[WSRFQ]) wsTypedTask { return wsTypedTask{kind: wsTypedKindRFQs, rfqs: dm.Data} }),
+		wsBuiltinRoute(WSChannelQuotes, WSParseQuotes,
+			func(w *WSClient) bool { return w.quotesHandler != nil },
+			func(dm *WSData[WSQuote]) wsTypedTask { return wsTypedTask{kind: wsTypedKindQuotes, quotes: dm.Data} }),
+		wsBuiltinRoute(WSChannelStrucBlockTrades, WSParseStrucBlockTrades,
+			func(w *WSClient) bool { return w.strucBlockTradesHandler != nil },
+			func(dm *WSData[WSStrucBlockTrade]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindStrucBlockTrades, strucBlockTrades: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelPublicStrucBlockTrades, WSParsePublicStrucBlockTrades,
+			func(w *WSClient) bool { return w.publicStrucBlockTradesHandler != nil },
+			func(dm *WSData[WSPublicStrucBlockTrade]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindPublicStrucBlockTrades, publicStrucBlockTrades: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelPublicBlockTrades, WSParsePublicBlockTrades,
+			func(w *WSClient) bool { return w.publicBlockTradesHandler != nil },
+			func(dm *WSData[BlockTrade]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindPublicBlockTrades, publicBlockTrades: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelBlockTickers, WSParseBlockTickers,
+			func(w *WSClient) bool { return w.blockTickersHandler != nil },
+			func(dm *WSData[WSBlockTicker]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindBlockTickers, blockTickers: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelDepositInfo, WSParseDepositInfo,
+			func(w *WSClient) bool { return w.depositInfoHandler != nil },
+			func(dm *WSData[WSDepositInfo]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindDepositInfo, depositInfo: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelWithdrawalInfo, WSParseWithdrawalInfo,
+			func(w *WSClient) bool { return w.withdrawalInfoHandler != nil },
+			func(dm *WSData[WSWithdrawalInfo]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindWithdrawalInfo, withdrawalInfo: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelSprdOrders, WSParseSprdOrders,
+			func(w *WSClient) bool { return w.sprdOrdersHandler != nil },
+			func(dm *WSData[SprdOrder]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindSprdOrders, sprdOrders: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelSprdTrades, WSParseSprdTrades,
+			func(w *WSClient) bool { return w.sprdTradesHandler != nil },
+			func(dm *WSData[WSSprdTrade]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindSprdTrades, sprdTrades: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelTickers, WSParseTickers,
+			func(w *WSClient) bool { return w.tickersHandler != nil },
+			func(dm *WSData[MarketTicker]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindTickers, tickers: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelTrades, WSParseTrades,
+			func(w *WSClient) bool { return w.tradesHandler != nil },
+			func(dm *WSData[MarketTrade]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindTrades, trades: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelTradesAll, WSParseTradesAll,
+			func(w *WSClient) bool { return w.tradesAllHandler != nil },
+			func(dm *WSData[MarketTrade]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindTradesAll, tradesAll: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelStatus, WSParseStatus,
+			func(w *WSClient) bool { return w.statusHandler != nil },
+			func(dm *WSData[SystemStatus]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindStatus, statuses: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelOpenInterest, WSParseOpenInterest,
+			func(w *WSClient) bool { return w.openInterestHandler != nil },
+			func(dm *WSData[OpenInterest]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindOpenInterest, openInterests: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelFundingRate, WSParseFundingRate,
+			func(w *WSClient) bool { return w.fundingRateHandler != nil },
+			func(dm *WSData[FundingRate]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindFundingRate, fundingRates: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelMarkPrice, WSParseMarkPrice,
+			func(w *WSClient) bool { return w.markPriceHandler != nil },
+			func(dm *WSData[MarkPrice]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindMarkPrice, markPrices: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelIndexTickers, WSParseIndexTickers,
+			func(w *WSClient) bool { return w.indexTickersHandler != nil },
+			func(dm *WSData[IndexTicker]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindIndexTickers, indexTickers: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelPriceLimit, WSParsePriceLimit,
+			func(w *WSClient) bool { return w.priceLimitHandler != nil },
+			func(dm *WSData[PriceLimit]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindPriceLimit, priceLimits: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelOptSummary, WSParseOptSummary,
+			func(w *WSClient) bool { return w.optSummaryHandler != nil },
+			func(dm *WSData[OptSummary]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindOptSummary, optSummaries: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelInstruments, WSParseInstruments,
+			func(w *WSClient) bool { return w.instrumentsHandler != nil },
+			func(dm *WSData[Instrument]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindInstruments, instruments: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelEstimatedPrice, WSParseEstimatedPrice,
+			func(w *WSClient) bool { return w.estimatedPriceHandler != nil },
+			func(dm *WSData[EstimatedPrice]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindEstimatedPrice, estimatedPrices: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelADLWarning, WSParseADLWarning,
+			func(w *WSClient) bool { return w.adlWarningHandler != nil },
+			func(dm *WSData[WSADLWarning]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindADLWarning, adlWarnings: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelEconomicCalendar, WSParseEconomicCalendar,
+			func(w *WSClient) bool { return w.economicCalendarHandler != nil },
+			func(dm *WSData[EconomicCalendarEvent]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindEconomicCalendar, economicCalendarEvents: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelLiquidationOrders, WSParseLiquidationOrders,
+			func(w *WSClient) bool { return w.liquidationOrdersHandler != nil },
+			func(dm *WSData[LiquidationOrder]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindLiquidationOrders, liquidationOrders: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelOptionTrades, WSParseOptionTrades,
+			func(w *WSClient) bool { return w.optionTradesHandler != nil },
+			func(dm *WSData[WSOptionTrade]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindOptionTrades, optionTrades: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelCallAuctionDetails, WSParseCallAuctionDetails,
+			func(w *WSClient) bool { return w.callAuctionDetailsHandler != nil },
+			func(dm *WSData[WSCallAuctionDetails]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindCallAuctionDetails, callAuctionDetails: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelSprdPublicTrades, WSParseSprdPublicTrades,
+			func(w *WSClient) bool { return w.sprdPublicTradesHandler != nil },
+			func(dm *WSData[WSSprdPublicTrade]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindSprdPublicTrades, sprdPublicTrades: dm.Data}
+			}),
+		wsBuiltinRoute(WSChannelSprdTickers, WSParseSprdTickers,
+			func(w *WSClient) bool { return w.sprdTickersHandler != nil },
+			func(dm *WSData[MarketSprdTicker]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindSprdTickers, sprdTickers: dm.Data}
+			}),
+
+		wsBuiltinRoute("candle*", WSParseCandles, wsCandlesEnabled, wsCandleTask),
+		wsBuiltinRoute(wsChannelPrefixSprdCandle+"*", WSParseSprdCandles, wsCandlesEnabled, wsCandleTask),
+		wsBuiltinRoute(wsChannelPrefixMarkPriceCandle+"*", WSParseMarkPriceCandles, wsPriceCandlesEnabled, wsPriceCandleTask),
+		wsBuiltinRoute(wsChannelPrefixIndexCandle+"*", WSParseIndexCandles, wsPriceCandlesEnabled, wsPriceCandleTask),
+	}
+
+	for _, channel := range []string{
+		WSChannelBooks, WSChannelBooksELP, WSChannelBooks5, WSChannelBboTbt, WSChannelBooksL2Tbt, WSChannelBooks50L2Tbt,
+		WSChannelSprdBboTbt, WSChannelSprdBooks5, WSChannelSprdBooksL2Tbt,
+	} {
+		routes = append(routes, wsBuiltinRoute(channel, WSParseOrderBook,
+			func(w *WSClient) bool { return w.orderBookHandler != nil },
+			func(dm *WSData[WSOrderBook]) wsTypedTask {
+				return wsTypedTask{kind: wsTypedKindOrderBook, orderBooks: []WSData[WSOrderBook]{*dm}}
+			}))
+	}
+	return routes
+}
+
+func wsCandlesEnabled(w *WSClient) bool { return w.candlesHandler != nil }
+
+func wsPriceCandlesEnabled(w *WSClient) bool { return w.priceCandlesHandler != nil }
+
+// onDataMessage 按频道注册表（内置频道 + OnWSChannel 注册的频道）解析并分发数据推送。
+func (w *WSClient) onDataMessage(message []byte) {
+	w.typedMu.RLock()
+	active := len(w.channelRoutes) > 0 || wsBuiltinChannels.anyEnabled(w)
+	w.typedMu.RUnlock()
+	if !active {
+		return
+	}
+
+	var probe struct {
+		Arg WSArg `json:"arg"`
+	}
+	if err := json.Unmarshal(message, &probe); err != nil {
+		w.onError(fmt.Errorf("okx: ws probe unmarshal failed: %w", err))
+		return
+	}
+	channel := probe.Arg.Channel
+	if channel == "" {
+		return
+	}
+
+	var buf [4]*wsChannelRoute
+	w.typedMu.RLock()
+	routes := wsBuiltinChannels.match(w, channel, buf[:0])
+	for _, route := range w.channelRoutes {
+		if route.matches(channel) {
+			routes = append(routes, route)
+		}
+	}
+	w.typedMu.RUnlock()
+
+	for _, route := range routes {
+		route.handle(w, channel, message)
+	}
+}
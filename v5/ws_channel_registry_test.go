@@ -0,0 +1,84 @@
+package okx
+
+import "testing"
+
+func TestWSChannelPatternMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		channel string
+		want    bool
+	}{
+		{"tickers", "tickers", true},
+		{"tickers", "tickers2", false},
+		{"candle*", "candle1m", true},
+		{"candle*", "mark-price-candle1m", false},
+		{"*", "anything", true},
+	}
+	for _, tc := range cases {
+		if got := wsChannelPatternMatch(tc.pattern, tc.channel); got != tc.want {
+			t.Fatalf("wsChannelPatternMatch(%q, %q) = %v, want %v", tc.pattern, tc.channel, got, tc.want)
+		}
+	}
+}
+
+func TestWSParseChannelData_Prefix(t *testing.T) {
+	msg := []byte(`{"arg":{"channel":"candle1m","instId":"BTC-USDT"},"data":[["1","2","3","4","5","6","7","8","0"]]}`)
+	dm, ok, err := WSParseChannelData[Candle](msg, "candle*")
+	if err != nil || !ok || len(dm.Data) != 1 {
+		t.Fatalf("dm=%v ok=%v err=%v", dm, ok, err)
+	}
+	if _, ok, _ := WSParseChannelData[Candle](msg, "candle"); ok {
+		t.Fatalf("exact pattern should not match candle1m")
+	}
+}
+
+func TestOnWSChannel_CustomChannel(t *testing.T) {
+	type fooItem struct {
+		ID string `json:"id"`
+	}
+
+	var got []WSData[fooItem]
+	var tickers []MarketTicker
+	w := &WSClient{}
+	WithWSChannelHandler("foo-*", func(dm WSData[fooItem]) { got = append(got, dm) })(w)
+	WithWSTickersHandler(func(tk MarketTicker) { tickers = append(tickers, tk) })(w)
+
+	w.onDataMessage([]byte(`{"arg":{"channel":"foo-bar","instId":"X"},"data":[{"id":"1"},{"id":"2"}]}`))
+	w.onDataMessage([]byte(`{"arg":{"channel":"baz"},"data":[{"id":"3"}]}`))
+	w.onDataMessage([]byte(`{"arg":{"channel":"tickers","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","last":"1"}]}`))
+
+	if len(got) != 1 || got[0].Arg.Channel != "foo-bar" || got[0].Arg.InstId != "X" || len(got[0].Data) != 2 || got[0].Data[1].ID != "2" {
+		t.Fatalf("got = %#v", got)
+	}
+	if len(tickers) != 1 || tickers[0].InstId != "BTC-USDT" {
+		t.Fatalf("tickers = %#v", tickers)
+	}
+
+	// 内置频道可叠加自定义 handler；传 nil 清空。
+	var raw int
+	OnWSChannel(w, WSChannelTickers, func(dm WSData[MarketTicker]) { raw += len(dm.Data) })
+	w.onDataMessage([]byte(`{"arg":{"channel":"tickers","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","last":"2"}]}`))
+	if raw != 1 || len(tickers) != 2 {
+		t.Fatalf("raw=%d tickers=%d", raw, len(tickers))
+	}
+	OnWSChannel[fooItem](w, "foo-*", nil)
+	w.onDataMessage([]byte(`{"arg":{"channel":"foo-bar"},"data":[{"id":"4"}]}`))
+	if len(got) != 1 {
+		t.Fatalf("handler not cleared, got = %d", len(got))
+	}
+}
+
+func TestWSBuiltinChannels_CoverOrderBookAndCandles(t *testing.T) {
+	var books, candles, priceCandles int
+	w := &WSClient{}
+	w.OnOrderBook(func(WSData[WSOrderBook]) { books++ })
+	w.OnCandles(func(WSCandle) { candles++ })
+	w.OnPriceCandles(func(WSPriceCandle) { priceCandles++ })
+
+	w.onDataMessage([]byte(`{"arg":{"channel":"books5","instId":"BTC-USDT"},"data":[{"asks":[],"bids":[],"ts":"1"}]}`))
+	w.onDataMessage([]byte(`{"arg":{"channel":"sprd-candle1m","sprdId":"S"},"data":[["1","2","3","4","5","6","0"]]}`))
+	w.onDataMessage([]byte(`{"arg":{"channel":"index-candle1m","instId":"BTC-USD"},"data":[["1","2","3","4","5","0"]]}`))
+	if books != 1 || candles != 1 || priceCandles != 1 {
+		t.Fatalf("books=%d candles=%d priceCandles=%d", books, candles, priceCandles)
+	}
+}
@@ -0,0 +1,303 @@
+package okx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// wsCheckpointVersion 为 WSCheckpoint 的文件格式版本。
+const wsCheckpointVersion = 1
+
+// WSCursor 为单个频道（按 channel+instId）最后见到的推送位置。
+type WSCursor struct {
+	Channel string `json:"channel"`
+	InstId  string `json:"instId,omitempty"`
+	// SeqId 为深度类频道的 seqId。
+	SeqId int64 `json:"seqId,omitempty"`
+	// TradeId 为 trades/fills 等频道最后一条记录的 tradeId。
+	TradeId string `json:"tradeId,omitempty"`
+	// UTime / TS 为最后见到的 uTime / ts（毫秒）。
+	UTime int64 `json:"uTime,omitempty"`
+	TS    int64 `json:"ts,omitempty"`
+}
+
+// WSCheckpoint 为 WSClient 的订阅与推送位置快照（见 Checkpoint / Restore）。
+type WSCheckpoint struct {
+	Version  int    `json:"version"`
+	Endpoint string `json:"endpoint,omitempty"`
+	// SavedAt 为生成快照的本地时间（毫秒）。
+	SavedAt int64 `json:"savedAt"`
+
+	// Args 为期望订阅集合（按 channel 排序）。
+	Args []WSArg `json:"args"`
+	// Cursors 为各频道最后见到的位置（WithWSCursorTracking 时记录）。
+	Cursors []WSCursor `json:"cursors,omitempty"`
+
+	// OrdersUTime / FillsTS 为已送达 OnOrders / OnFills 的最大 uTime / 成交 ts（WithWSGapFill 时记录），作为私有频道 REST 补偿起点。
+	OrdersUTime int64 `json:"ordersUTime,omitempty"`
+	FillsTS     int64 `json:"fillsTs,omitempty"`
+}
+
+// WithWSCursorTracking 开启按 channel+instId 记录最后见到的 seqId/tradeId/uTime/ts（写入 Checkpoint 的 Cursors；默认关闭）。
+//
+// 开启后 read goroutine 会对每条数据推送做一次轻量 JSON 解析。
+func WithWSCursorTracking() WSOption {
+	return func(c *WSClient) {
+		if c.cursors == nil {
+			c.cursors = newWSCursorTracker()
+		}
+	}
+}
+
+type wsCursorTracker struct {
+	mu      sync.Mutex
+	cursors map[string]*WSCursor
+}
+
+func newWSCursorTracker() *wsCursorTracker {
+	return &wsCursorTracker{cursors: make(map[string]*WSCursor)}
+}
+
+func wsCursorKey(channel, instId string) string {
+	return channel + "|" + instId
+}
+
+// observe 从一条数据推送中更新各 instId 的位置。
+func (t *wsCursorTracker) observe(msg []byte) {
+	if len(msg) == 0 || msg[0] != '{' {
+		return
+	}
+	var probe struct {
+		Arg  WSArg             `json:"arg"`
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &probe); err != nil || probe.Arg.Channel == "" || len(probe.Data) == 0 {
+		return
+	}
+	defaultId := probe.Arg.InstId
+	if defaultId == "" {
+		defaultId = probe.Arg.SprdId
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, raw := range probe.Data {
+		if len(raw) == 0 || raw[0] != '{' {
+			continue
+		}
+		var item struct {
+			InstId  string          `json:"instId"`
+			SprdId  string          `json:"sprdId"`
+			SeqId   json.RawMessage `json:"seqId"`
+			TradeId string          `json:"tradeId"`
+			UTime   json.RawMessage `json:"uTime"`
+			TS      json.RawMessage `json:"ts"`
+		}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			continue
+		}
+		id := item.InstId
+		if id == "" {
+			id = item.SprdId
+		}
+		if id == "" {
+			id = defaultId
+		}
+		t.updateLocked(probe.Arg.Channel, id, item.SeqId, item.TradeId, item.UTime, item.TS)
+	}
+}
+
+func (t *wsCursorTracker) updateLocked(channel, instId string, seqId json.RawMessage, tradeId string, uTime, ts json.RawMessage) {
+	k := wsCursorKey(channel, instId)
+	c := t.cursors[k]
+	if c == nil {
+		c = &WSCursor{Channel: channel, InstId: instId}
+		t.cursors[k] = c
+	}
+	if v := parseJSONInt64(seqId); v > c.SeqId {
+		c.SeqId = v
+	}
+	if tradeId != "" {
+		c.TradeId = tradeId
+	}
+	if v := parseJSONInt64(uTime); v > c.UTime {
+		c.UTime = v
+	}
+	if v := parseJSONInt64(ts); v > c.TS {
+		c.TS = v
+	}
+}
+
+func parseJSONInt64(raw json.RawMessage) int64 {
+	if len(raw) == 0 {
+		return 0
+	}
+	v, err := strconv.ParseInt(unquoteJSONNumber(raw), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+func (t *wsCursorTracker) snapshot() []WSCursor {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]WSCursor, 0, len(t.cursors))
+	for _, c := range t.cursors {
+		out = append(out, *c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Channel != out[j].Channel {
+			return out[i].Channel < out[j].Channel
+		}
+		return out[i].InstId < out[j].InstId
+	})
+	return out
+}
+
+func (t *wsCursorTracker) restore(cursors []WSCursor) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, c := range cursors {
+		t.cursors[wsCursorKey(c.Channel, c.InstId)] = &c
+	}
+}
+
+// Checkpoint 返回当前期望订阅集合与各频道位置的快照（并发安全，可在运行中定期调用）。
+func (w *WSClient) Checkpoint() *WSCheckpoint {
+	args := w.snapshotDesired()
+	sort.Slice(args, func(i, j int) bool { return args[i].key() < args[j].key() })
+	cp := &WSCheckpoint{
+		Version:  wsCheckpointVersion,
+		Endpoint: w.endpoint,
+		SavedAt:  time.Now().UnixMilli(),
+		Args:     args,
+	}
+	if w.cursors != nil {
+		cp.Cursors = w.cursors.snapshot()
+	}
+	if g := w.gapFill; g != nil {
+		g.mu.Lock()
+		cp.OrdersUTime, cp.FillsTS = g.lastUTime, g.lastFillTS
+		g.mu.Unlock()
+	}
+	return cp
+}
+
+// SaveCheckpoint 将 Checkpoint 写入 path（先写临时文件再 rename，避免进程中途退出留下半截文件）。
+func (w *WSClient) SaveCheckpoint(path string) error {
+	b, err := json.MarshalIndent(w.Checkpoint(), "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// LoadWSCheckpoint 读取 SaveCheckpoint 写入的文件。
+func LoadWSCheckpoint(path string) (*WSCheckpoint, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cp WSCheckpoint
+	if err := json.Unmarshal(b, &cp); err != nil {
+		return nil, fmt.Errorf("okx: ws checkpoint decode: %w", err)
+	}
+	if cp.Version != wsCheckpointVersion {
+		return nil, fmt.Errorf("okx: ws checkpoint unsupported version=%d", cp.Version)
+	}
+	return &cp, nil
+}
+
+// Restore 从快照恢复期望订阅集合与频道位置（须在 Start 之前调用）；Start 后首次连接即按恢复的集合订阅。
+//
+// 私有 WS 若快照中有 orders/fills 的位置（OrdersUTime/FillsTS，或 Cursors 中 orders/fills 频道的 uTime/ts），
+// 首次连接重订阅成功后即从该位置触发 REST 补偿（同 WithWSGapFill；未配置时按默认配置开启）。
+// 补偿起点上的记录可能再次以 Recovered=true 补发，回调需按 ordId+uTime / tradeId 幂等处理。
+//
+// 快照中的 Cursors 会被保留（按需开启位置记录），后续 Checkpoint 在其基础上继续更新。
+func (w *WSClient) Restore(cp *WSCheckpoint) error {
+	if cp == nil {
+		return errors.New("okx: ws restore requires checkpoint")
+	}
+	if cp.Version != wsCheckpointVersion {
+		return fmt.Errorf("okx: ws checkpoint unsupported version=%d", cp.Version)
+	}
+	if w.started.Load() {
+		return errors.New("okx: ws restore must be called before Start")
+	}
+	for _, a := range cp.Args {
+		if a.Channel == "" {
+			return errors.New("okx: ws restore requires channel")
+		}
+	}
+
+	w.mu.Lock()
+	for _, a := range cp.Args {
+		w.desired[a.key()] = a
+	}
+	w.mu.Unlock()
+
+	if len(cp.Cursors) > 0 {
+		if w.cursors == nil {
+			w.cursors = newWSCursorTracker()
+		}
+		w.cursors.restore(cp.Cursors)
+	}
+
+	if !w.needLogin {
+		return nil
+	}
+	ordersSince, fillsSince := cp.OrdersUTime, cp.FillsTS
+	for _, c := range cp.Cursors {
+		switch c.Channel {
+		case WSChannelOrders:
+			ordersSince = max(ordersSince, c.UTime)
+		case WSChannelFills:
+			fillsSince = max(fillsSince, c.TS)
+		}
+	}
+	if ordersSince <= 0 && fillsSince <= 0 {
+		return nil
+	}
+	if w.gapFill == nil {
+		w.gapFill = newWSGapFiller(WSGapFillConfig{})
+	}
+	g := w.gapFill
+	g.mu.Lock()
+	g.lastUTime = max(g.lastUTime, ordersSince)
+	g.lastFillTS = max(g.lastFillTS, fillsSince)
+	g.mu.Unlock()
+	g.restored.Store(true)
+	return nil
+}
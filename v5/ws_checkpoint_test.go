@@ -0,0 +1,189 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWSClient_Checkpoint_SaveLoadRoundTrip(t *testing.T) {
+	w := NewClient().NewWSPublic(WithWSCursorTracking())
+	if err := w.Subscribe(WSArg{Channel: WSChannelTrades, InstId: "BTC-USDT"}, WSArg{Channel: WSChannelBooks, InstId: "BTC-USDT"}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	w.cursors.observe([]byte(`{"arg":{"channel":"books","instId":"BTC-USDT"},"action":"update","data":[{"asks":[],"bids":[],"ts":"1700000000100","seqId":123456}]}`))
+	w.cursors.observe([]byte(`{"arg":{"channel":"books","instId":"BTC-USDT"},"action":"update","data":[{"asks":[],"bids":[],"ts":"1700000000200","seqId":123460}]}`))
+	w.cursors.observe([]byte(`{"arg":{"channel":"trades","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","tradeId":"9","ts":"1700000000300"},{"instId":"BTC-USDT","tradeId":"10","ts":"1700000000301"}]}`))
+	// K 线为数组数据，忽略。
+	w.cursors.observe([]byte(`{"arg":{"channel":"candle1m","instId":"BTC-USDT"},"data":[["1700000000000","1","1","1","1","1","1","1","0"]]}`))
+
+	path := filepath.Join(t.TempDir(), "ws.checkpoint")
+	if err := w.SaveCheckpoint(path); err != nil {
+		t.Fatalf("SaveCheckpoint() error = %v", err)
+	}
+	cp, err := LoadWSCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadWSCheckpoint() error = %v", err)
+	}
+	if cp.Version != wsCheckpointVersion || cp.Endpoint != w.endpoint || len(cp.Args) != 2 || cp.Args[0].Channel != WSChannelBooks {
+		t.Fatalf("checkpoint = %#v", cp)
+	}
+	want := []WSCursor{
+		{Channel: "books", InstId: "BTC-USDT", SeqId: 123460, TS: 1700000000200},
+		{Channel: "trades", InstId: "BTC-USDT", TradeId: "10", TS: 1700000000301},
+	}
+	if fmt.Sprint(cp.Cursors) != fmt.Sprint(want) {
+		t.Fatalf("cursors = %#v", cp.Cursors)
+	}
+
+	// 恢复到新客户端：订阅集合与位置延续（未显式开启 tracking 时按需开启）。
+	w2 := NewClient().NewWSPublic()
+	if err := w2.Restore(cp); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	cp2 := w2.Checkpoint()
+	if fmt.Sprint(cp2.Args) != fmt.Sprint(cp.Args) || fmt.Sprint(cp2.Cursors) != fmt.Sprint(want) {
+		t.Fatalf("restored checkpoint = %#v", cp2)
+	}
+	if w2.gapFill != nil {
+		t.Fatalf("public client should not enable gap fill")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version":99}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadWSCheckpoint(path); err == nil {
+		t.Fatalf("expected version error")
+	}
+	if err := w2.Restore(&WSCheckpoint{Version: wsCheckpointVersion, Args: []WSArg{{}}}); err == nil {
+		t.Fatalf("expected channel error")
+	}
+	if err := w2.Restore(nil); err == nil {
+		t.Fatalf("expected nil error")
+	}
+}
+
+func TestWSClient_Restore_PrivateTriggersCatchUpOnFirstConnect(t *testing.T) {
+	const t1 = int64(1700000000000)
+
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	var mu sync.Mutex
+	var subscribed []WSArg
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v5/trade/orders-pending":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"code":"0","msg":"","data":[
+				{"instType":"SPOT","instId":"BTC-USDT","ordId":"A","state":"live","uTime":"%d","cTime":"%d"}]}`, t1+10, t1+10)
+			return
+		case "/api/v5/trade/orders-history":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"code":"0","msg":"","data":[
+				{"instType":"SPOT","instId":"BTC-USDT","ordId":"OLD","state":"filled","uTime":"%d","cTime":"%d"}]}`, t1-10, t1-10)
+			return
+		case "/api/v5/trade/fills":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"code":"0","msg":"","data":[]}`)
+			return
+		}
+
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+		if _, _, err := c.ReadMessage(); err != nil {
+			return
+		}
+		_ = c.WriteMessage(websocket.TextMessage, []byte(`{"event":"login","code":"0","msg":"","connId":"x"}`))
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req wsOpRequest
+			if err := json.Unmarshal(msg, &req); err != nil || req.Op != "subscribe" {
+				continue
+			}
+			mu.Lock()
+			subscribed = append(subscribed, req.Args...)
+			mu.Unlock()
+			for _, a := range req.Args {
+				b, _ := json.Marshal(WSEvent{ID: req.ID, Event: req.Op, Arg: &a})
+				_ = c.WriteMessage(websocket.TextMessage, b)
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	doneCh := make(chan WSGapFillResult, 1)
+	var ordersMu sync.Mutex
+	var orders []TradeOrder
+	client := NewClient(
+		WithBaseURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+		WithCredentials(Credentials{APIKey: "k", SecretKey: "s", Passphrase: "p"}),
+	)
+	ws := client.NewWSPrivate(
+		WithWSURL("ws"+srv.URL[len("http"):]),
+		WithWSGapFill(WSGapFillConfig{OnDone: func(r WSGapFillResult) { doneCh <- r }}),
+		WithWSOrdersHandler(func(o TradeOrder) {
+			ordersMu.Lock()
+			defer ordersMu.Unlock()
+			orders = append(orders, o)
+		}),
+	)
+	cp := &WSCheckpoint{
+		Version:     wsCheckpointVersion,
+		Args:        []WSArg{{Channel: WSChannelOrders, InstType: "SPOT"}},
+		OrdersUTime: t1,
+	}
+	if err := ws.Restore(cp); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ws.Start(ctx, nil, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(ws.Close)
+	if err := ws.Restore(cp); err == nil {
+		t.Fatalf("expected restore after start error")
+	}
+
+	var res WSGapFillResult
+	select {
+	case res = <-doneCh:
+	case <-time.After(3 * time.Second):
+		t.Fatalf("timeout waiting catch-up")
+	}
+	if res.Err != nil || res.OrdersSince != t1 || res.Orders != 1 {
+		t.Fatalf("result = %#v", res)
+	}
+	mu.Lock()
+	if len(subscribed) != 1 || subscribed[0].Channel != WSChannelOrders {
+		t.Fatalf("subscribed = %#v", subscribed)
+	}
+	mu.Unlock()
+	waitFor(t, "recovered order", func() bool {
+		ordersMu.Lock()
+		defer ordersMu.Unlock()
+		return len(orders) == 1 && orders[0].OrdId == "A" && orders[0].Recovered
+	})
+	if got := ws.Checkpoint().OrdersUTime; got != t1+10 {
+		t.Fatalf("OrdersUTime = %d", got)
+	}
+}
@@ -3,7 +3,6 @@ package okx
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -24,6 +23,9 @@ const (
 	wsPublicDemoURL   = "wss://wspap.okx.com:8443/ws/v5/public"
 	wsPrivateDemoURL  = "wss://wspap.okx.com:8443/ws/v5/private"
 	wsBusinessDemoURL = "wss://wspap.okx.com:8443/ws/v5/business"
+
+	wsPublicSBEURL     = "wss://ws.okx.com:8443/ws/v5/public-sbe"
+	wsPublicSBEDemoURL = "wss://wspap.okx.com:8443/ws/v5/public-sbe"
 )
 
 const defaultWSReadLimitBytes int64 = 16 << 20 // 16MiB：避免过小上限导致 "read limit exceeded" 断线
@@ -36,6 +38,7 @@ const (
 	wsKindPublic
 	wsKindPrivate
 	wsKindBusiness
+	wsKindPublicSBE
 )
 
 // WSMessageHandler 处理 WS 原始消息（text/binary 的 payload）。
@@ -119,7 +122,7 @@ func (p WSQueueFullPolicy) String() string {
 // WSQueueFullError 表示 WS 异步 handler 队列满导致的背压事件。
 // 可用于告警与判定“状态机可能不完整/需要对账”。
 type WSQueueFullError struct {
-	Queue    string // "raw" or "typed"
+	Queue    string // "raw"、"typed"、"typed:<class>"（WithWSPriorityDispatch）或 "consumer:<name>"（ConsumeWS 消费者）
 	Kind     string // typed kind
 	Policy   WSQueueFullPolicy
 	QueueLen int
@@ -292,23 +295,34 @@ type WSClient struct {
 	header    http.Header
 	dialer    *websocket.Dialer
 	needLogin bool
+	// headerLogin 表示握手时通过 header 登录（public-sbe），不发送 op=login。
+	headerLogin bool
 
 	heartbeat       time.Duration
 	resubscribeWait time.Duration
 	readLimitBytes  int64
 	writeTimeout    time.Duration
-	lastRecv        atomic.Int64
-	lastPing        atomic.Int64
-	dialAttempts    atomic.Uint64
-	connects        atomic.Uint64
-	reconnects      atomic.Uint64
-	subscribeOK     atomic.Uint64
-	subscribeErr    atomic.Uint64
-	unsubscribeOK   atomic.Uint64
-	unsubscribeErr  atomic.Uint64
-	typedDropped    atomic.Uint64
-	rawDropped      atomic.Uint64
-	lastError       atomic.Value
+
+	// subscribeFrameBytes 为 subscribe/unsubscribe 单帧上限；opRate* 为连接级 op 限速（0 为默认，<0 为禁用）。
+	subscribeFrameBytes int
+	opRateRPS           float64
+	opRateBurst         int
+	opLimitMu           sync.Mutex
+	opLimitConn         *websocket.Conn
+	opLimiter           *tokenBucketLimiter
+	opThrottled         atomic.Uint64
+	lastRecv            atomic.Int64
+	lastPing            atomic.Int64
+	dialAttempts        atomic.Uint64
+	connects            atomic.Uint64
+	reconnects          atomic.Uint64
+	subscribeOK         atomic.Uint64
+	subscribeErr        atomic.Uint64
+	unsubscribeOK       atomic.Uint64
+	unsubscribeErr      atomic.Uint64
+	typedDropped        atomic.Uint64
+	rawDropped          atomic.Uint64
+	lastError           atomic.Value
 
 	handler      WSMessageHandler
 	errHandler   WSErrorHandler
@@ -363,12 +377,39 @@ type WSClient struct {
 	sprdPublicTradesHandler            func(trade WSSprdPublicTrade)
 	sprdTickersHandler                 func(ticker MarketSprdTicker)
 	opReplyHandler                     func(reply WSOpReply, raw []byte)
+	sbeHandler                         func(msg SBEMessage)
+	sbeInstIdResolver                  func(instIdCode int64) (string, bool)
+	subscribers                        []wsSubscriber
+	consumers                          map[wsTypedKind][]wsTypedConsumer
+	subscriberOwned                    map[string]bool
+	subscribePending                   map[string]*wsSubscribePending
+	channelRoutes                      map[string]*wsChannelRoute
+
+	dryRun *dryRun
+
+	makeBeforeBreak bool
+	handoverActive  atomic.Bool
+	handoverNext    *wsHandover
+	overlap         atomic.Pointer[wsOverlap]
+	handovers       atomic.Uint64
+	overlapDropped  atomic.Uint64
+
+	recorder *WSRecorder
+	latency  *wsLatencyRecorder
+	gapFill  *wsGapFiller
+
+	redundant      *WSRedundantFeed
+	dedupDelivered atomic.Uint64
+	dedupDropped   atomic.Uint64
 
 	typedAsync           bool
 	typedBuffer          int
 	typedQueue           chan wsTypedTask
 	typedQueueFullPolicy WSQueueFullPolicy
 	typedQueueFullWarnAt atomic.Int64
+	dispatchQueues       []*wsDispatchQueue
+	conflator            *wsConflator
+	cursors              *wsCursorTracker
 
 	rawAsync           bool
 	rawBuffer          int
@@ -553,7 +594,9 @@ func (w *WSClient) Start(ctx context.Context, handler WSMessageHandler, errHandl
 		w.closeConn()
 	}()
 
-	if w.typedAsync && w.typedQueue == nil {
+	if w.typedAsync && w.dispatchQueues != nil {
+		w.startDispatchQueues(runCtx)
+	} else if w.typedAsync && w.typedQueue == nil {
 		buf := w.typedBuffer
 		if buf <= 0 {
 			buf = 1024
@@ -616,11 +659,14 @@ func (w *WSClient) Subscribe(args ...WSArg) error {
 	if conn == nil {
 		return nil
 	}
-	return w.writeJSON(conn, wsOpRequest{ID: w.nextOpID(), Op: "subscribe", Args: send})
+	_, err := w.writeArgsOp(context.Background(), conn, "subscribe", send, false, w.writeJSON)
+	return err
 }
 
 type wsOpWaiter struct {
-	op        string
+	op string
+	// ids 为该 waiter 覆盖的请求 id（args 拆批发送时有多个）。
+	ids       []string
 	remaining map[string]struct{}
 	done      chan error
 }
@@ -634,10 +680,26 @@ type wsOpRespResult struct {
 type wsOpRespWaiter struct {
 	op   string
 	done chan wsOpRespResult
+	// sentAt 为请求写出时间（UnixNano；用于往返耗时统计）。
+	sentAt atomic.Int64
 }
 
 // doOpAndWaitRaw 发送业务 op 请求并等待对应响应（用于 WS 下单/撤单/改单等）。
-func (w *WSClient) doOpAndWaitRaw(ctx context.Context, op string, args any) (*WSOpReply, []byte, error) {
+// cp 为该 op 所需的权限，由各 op 方法在构造请求处标注。
+func (w *WSClient) doOpAndWaitRaw(ctx context.Context, cp Capability, op string, args any) (*WSOpReply, []byte, error) {
+	if w.c != nil && op != "" {
+		if err := w.c.checkCapability(cp, requestGateMethodWS, op); err != nil {
+			return nil, nil, err
+		}
+	}
+	if args != nil {
+		if reply, raw, ok, err := w.dryRunWS(ctx, op, args); ok {
+			if err != nil {
+				return nil, nil, err
+			}
+			return reply, raw, nil
+		}
+	}
 	if !w.started.Load() {
 		return nil, nil, errors.New("okx: ws client not started")
 	}
@@ -648,7 +710,7 @@ func (w *WSClient) doOpAndWaitRaw(ctx context.Context, op string, args any) (*WS
 		return nil, nil, errors.New("okx: ws op requires args")
 	}
 
-	if w.c != nil && (op == wsOpOrder || op == wsOpBatchOrders || op == wsOpCancelOrder || op == wsOpBatchCancelOrders || op == wsOpAmendOrder || op == wsOpBatchAmendOrders) {
+	if w.c != nil && isTradeAccountRateLimitedWSOp(op) {
 		if err := w.c.ensureTradeAccountRateLimit(ctx); err != nil {
 			return nil, nil, &RequestStateError{
 				Stage:       RequestStagePreflight,
@@ -694,6 +756,7 @@ func (w *WSClient) doOpAndWaitRaw(ctx context.Context, op string, args any) (*WS
 		}
 	}
 
+	waiter.sentAt.Store(time.Now().UnixNano())
 	if err := w.writeJSON(conn, req); err != nil {
 		if release != nil {
 			release()
@@ -739,10 +802,8 @@ func (w *WSClient) SubscribeAndWait(ctx context.Context, args ...WSArg) error {
 		return err
 	}
 
-	id := w.nextOpID()
-	waiter := w.registerWaiter(id, "subscribe", send)
-	if err := w.writeJSON(conn, wsOpRequest{ID: id, Op: "subscribe", Args: send}); err != nil {
-		w.removeWaiter(id)
+	waiter, err := w.writeArgsOp(ctx, conn, "subscribe", send, true, w.writeJSON)
+	if err != nil {
 		return err
 	}
 
@@ -758,7 +819,7 @@ func (w *WSClient) SubscribeAndWait(ctx context.Context, args ...WSArg) error {
 		w.mu.Unlock()
 		return nil
 	case <-ctx.Done():
-		w.removeWaiter(id)
+		w.removeWaiter(waiter.ids[0])
 		return ctx.Err()
 	}
 }
@@ -781,7 +842,8 @@ func (w *WSClient) Unsubscribe(args ...WSArg) error {
 	if conn == nil {
 		return nil
 	}
-	return w.writeJSON(conn, wsOpRequest{ID: w.nextOpID(), Op: "unsubscribe", Args: send})
+	_, err := w.writeArgsOp(context.Background(), conn, "unsubscribe", send, false, w.writeJSON)
+	return err
 }
 
 // UnsubscribeAndWait 发送取消订阅请求并等待 unsubscribe/error event 返回（推荐用于判定取消订阅是否成功）。
@@ -803,10 +865,8 @@ func (w *WSClient) UnsubscribeAndWait(ctx context.Context, args ...WSArg) error
 		return err
 	}
 
-	id := w.nextOpID()
-	waiter := w.registerWaiter(id, "unsubscribe", send)
-	if err := w.writeJSON(conn, wsOpRequest{ID: id, Op: "unsubscribe", Args: send}); err != nil {
-		w.removeWaiter(id)
+	waiter, err := w.writeArgsOp(ctx, conn, "unsubscribe", send, true, w.writeJSON)
+	if err != nil {
 		return err
 	}
 
@@ -822,7 +882,7 @@ func (w *WSClient) UnsubscribeAndWait(ctx context.Context, args ...WSArg) error
 		w.mu.Unlock()
 		return nil
 	case <-ctx.Done():
-		w.removeWaiter(id)
+		w.removeWaiter(waiter.ids[0])
 		return ctx.Err()
 	}
 }
@@ -849,15 +909,7 @@ func (w *WSClient) run(ctx context.Context) {
 			continue
 		}
 
-		limit := w.readLimitBytes
-		if limit <= 0 {
-			limit = defaultWSReadLimitBytes
-		}
-		conn.SetReadLimit(limit)
-
-		conn.SetPingHandler(func(appData string) error {
-			return w.writeControl(conn, websocket.PongMessage, []byte(appData), 5*time.Second)
-		})
+		w.prepareConn(conn)
 
 		if w.needLogin {
 			if err := w.login(ctx, conn); err != nil {
@@ -870,15 +922,15 @@ func (w *WSClient) run(ctx context.Context) {
 
 		if n := w.connects.Add(1); n > 1 {
 			w.reconnects.Add(1)
+		} else {
+			w.markGapFillStart()
 		}
 		w.setConn(conn)
 
 		var resubscribeWaiter *wsOpWaiter
 		if args := w.snapshotDesired(); len(args) > 0 {
-			id := w.nextOpID()
-			resubscribeWaiter = w.registerWaiter(id, "subscribe", args)
-			if err := w.writeJSON(conn, wsOpRequest{ID: id, Op: "subscribe", Args: args}); err != nil {
-				w.removeWaiter(id)
+			resubscribeWaiter, err = w.writeArgsOp(ctx, conn, "subscribe", args, true, w.writeJSON)
+			if err != nil {
 				w.onError(err)
 				w.closeConn()
 				w.sleepBackoff(ctx)
@@ -886,7 +938,19 @@ func (w *WSClient) run(ctx context.Context) {
 			}
 		}
 
-		if err := w.readLoop(ctx, conn, resubscribeWaiter); err != nil {
+		err = w.readLoop(ctx, conn, resubscribeWaiter)
+		for {
+			// make-before-break：旧连接已被切换关闭，直接接管新连接（不重新拨号）。
+			h := w.takeHandover()
+			if h == nil {
+				break
+			}
+			conn = h.conn
+			w.replayHandoverPending(h.pending)
+			w.handoverActive.Store(false)
+			err = w.readLoop(ctx, conn, nil)
+		}
+		if err != nil {
 			w.onError(err)
 		}
 
@@ -895,6 +959,18 @@ func (w *WSClient) run(ctx context.Context) {
 	}
 }
 
+func (w *WSClient) prepareConn(conn *websocket.Conn) {
+	limit := w.readLimitBytes
+	if limit <= 0 {
+		limit = defaultWSReadLimitBytes
+	}
+	conn.SetReadLimit(limit)
+
+	conn.SetPingHandler(func(appData string) error {
+		return w.writeControl(conn, websocket.PongMessage, []byte(appData), 5*time.Second)
+	})
+}
+
 func (w *WSClient) dial(ctx context.Context) (*websocket.Conn, error) {
 	d := w.dialer
 	if d == nil {
@@ -908,6 +984,9 @@ func (w *WSClient) dial(ctx context.Context) (*websocket.Conn, error) {
 	if w.header != nil {
 		header = w.header.Clone()
 	}
+	if w.headerLogin {
+		w.setLoginHeader(header)
+	}
 
 	conn, _, err := d.DialContext(ctx, w.endpoint, header)
 	if err != nil {
@@ -935,6 +1014,9 @@ func (w *WSClient) login(ctx context.Context, conn *websocket.Conn) error {
 			Sign:       sig,
 		}},
 	}
+	if err := w.waitOpLimit(ctx, conn); err != nil {
+		return err
+	}
 	if err := w.writeJSON(conn, req); err != nil {
 		return err
 	}
@@ -943,17 +1025,23 @@ func (w *WSClient) login(ctx context.Context, conn *websocket.Conn) error {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
-		_, msg, err := conn.ReadMessage()
+		msgType, msg, err := conn.ReadMessage()
 		if err != nil {
 			return err
 		}
 
 		w.touchRecv()
+		// 登录成功后才计入 connects：此处的帧归属即将建立的连接。
+		w.recordFrame(w.recordConnID()+1, msgType, msg)
 		if w.handleHeartbeatMessage(conn, msg) {
 			continue
 		}
 
-		w.dispatchRaw(msg)
+		if w.redundant != nil {
+			w.redundant.forward(w, msgType, msg)
+		} else {
+			w.dispatchRaw(msg)
+		}
 
 		ev, ok, err := WSParseEvent(msg)
 		if err != nil || !ok {
@@ -974,7 +1062,7 @@ func (w *WSClient) login(ctx context.Context, conn *websocket.Conn) error {
 }
 
 func (w *WSClient) validateLoginCredentials() error {
-	if w == nil || !w.needLogin {
+	if w == nil || (!w.needLogin && !w.headerLogin) {
 		return nil
 	}
 	if w.c == nil || w.c.creds == nil || w.c.creds.APIKey == "" || w.c.creds.SecretKey == "" || w.c.creds.Passphrase == "" {
@@ -984,6 +1072,7 @@ func (w *WSClient) validateLoginCredentials() error {
 }
 
 func (w *WSClient) readLoop(ctx context.Context, conn *websocket.Conn, resubscribeWaiter *wsOpWaiter) error {
+	connID := w.recordConnID()
 	if resubscribeWaiter != nil {
 		timeout := w.resubscribeWait
 		if timeout <= 0 {
@@ -996,7 +1085,7 @@ func (w *WSClient) readLoop(ctx context.Context, conn *websocket.Conn, resubscri
 		if err := ctx.Err(); err != nil {
 			return err
 		}
-		_, msg, err := conn.ReadMessage()
+		msgType, msg, err := conn.ReadMessage()
 		if err != nil {
 			if resubscribeWaiter != nil {
 				var netErr net.Error
@@ -1008,24 +1097,25 @@ func (w *WSClient) readLoop(ctx context.Context, conn *websocket.Conn, resubscri
 		}
 
 		w.touchRecv()
+		w.recordFrame(connID, msgType, msg)
 		if w.handleHeartbeatMessage(conn, msg) {
 			continue
 		}
+		if w.dropDuplicate(msg) {
+			continue
+		}
+		if w.latency != nil {
+			w.observePushLatency(msg, time.Now())
+		}
+		if w.cursors != nil {
+			w.cursors.observe(msg)
+		}
 
-		w.dispatchRaw(msg)
-
-		ev, ok, err := WSParseEvent(msg)
-		if err != nil || !ok {
-			if r, ok2, err2 := WSParseOpReply(msg); err2 == nil && ok2 {
-				w.onOpReply(*r, msg)
-			} else {
-				w.onDataMessage(msg)
-			}
-		} else {
-			w.onEvent(*ev)
-			if ev.Event == "notice" && ev.Code == "64008" {
+		if ev := w.deliverFrame(msgType, msg); ev != nil && ev.Event == "notice" && ev.Code == "64008" {
+			if !w.makeBeforeBreak {
 				return errors.New("okx: ws notice 64008 reconnect")
 			}
+			w.startHandover(ctx, conn)
 		}
 
 		if resubscribeWaiter != nil {
@@ -1036,27 +1126,65 @@ func (w *WSClient) readLoop(ctx context.Context, conn *websocket.Conn, resubscri
 				}
 				resubscribeWaiter = nil
 				_ = conn.SetReadDeadline(time.Time{})
+				w.onResubscribed(ctx)
 			default:
 			}
 		}
 	}
 }
 
+// handleFrame 按 WebSocket 帧类型分发一帧：二进制帧为 SBE 推送，文本帧交给 handleMessage。
+//
+// 冗余组成员（WSRedundantFeed）只在本连接处理订阅确认等等待者，handler 由组分发 goroutine 回调。
+func (w *WSClient) handleFrame(msgType int, msg []byte) *WSEvent {
+	if msgType == websocket.BinaryMessage {
+		if w.redundant != nil {
+			return nil
+		}
+		if w.kind == wsKindPublicSBE {
+			w.handleSBEMessage(msg)
+		} else {
+			w.onError(errors.New("okx: ws unexpected binary frame"))
+		}
+		return nil
+	}
+	return w.handleMessage(msg)
+}
+
+// handleMessage 分发一条非心跳文本消息（event/op 回包/数据推送），若为 event 则返回该 event。
+func (w *WSClient) handleMessage(msg []byte) *WSEvent {
+	ev, ok, err := WSParseEvent(msg)
+	if err != nil || !ok {
+		if r, ok2, err2 := WSParseOpReply(msg); err2 == nil && ok2 {
+			w.onOpReply(*r, msg)
+		} else if w.redundant == nil {
+			w.onDataMessage(msg)
+			w.dispatchSubscribers(msg)
+		}
+		return nil
+	}
+	w.onEvent(*ev)
+	return ev
+}
+
 func (w *WSClient) onEvent(ev WSEvent) {
 	w.notifyWaiter(ev)
 	w.notifyOpWaiterError(ev)
-	if w.eventHandler != nil && ev.Event != "" {
+	if w.eventHandler != nil && ev.Event != "" && w.redundant == nil {
 		w.safeEventHandlerCall(ev)
 	}
 }
 
 func (w *WSClient) onOpReply(reply WSOpReply, raw []byte) {
+	if w.latency != nil {
+		w.observeOpGatewayLatency(reply, time.Now())
+	}
 	w.notifyOpWaiter(reply, raw)
 
 	w.typedMu.RLock()
 	h := w.opReplyHandler
 	w.typedMu.RUnlock()
-	if h != nil {
+	if h != nil && w.redundant == nil {
 		rawCopy := raw
 		if w.typedAsync {
 			rawCopy = append([]byte(nil), raw...)
@@ -1069,593 +1197,6 @@ func (w *WSClient) onOpReply(reply WSOpReply, raw []byte) {
 	}
 }
 
-func (w *WSClient) onDataMessage(message []byte) {
-	w.typedMu.RLock()
-	ordersH := w.ordersHandler
-	fillsH := w.fillsHandler
-	accountH := w.accountHandler
-	positionsH := w.positionsHandler
-	balPosH := w.balanceAndPositionHandler
-	liqWarningH := w.liquidationWarningHandler
-	accountGreeksH := w.accountGreeksHandler
-	ordersAlgoH := w.ordersAlgoHandler
-	algoAdvanceH := w.algoAdvanceHandler
-	gridOrdersSpotH := w.gridOrdersSpotHandler
-	gridOrdersContractH := w.gridOrdersContractHandler
-	gridPositionsH := w.gridPositionsHandler
-	gridSubOrdersH := w.gridSubOrdersHandler
-	algoRecurringBuyH := w.algoRecurringBuyHandler
-	copyTradingLeadNotificationH := w.copyTradingLeadNotificationHandler
-	rfqsH := w.rfqsHandler
-	quotesH := w.quotesHandler
-	strucBlockTradesH := w.strucBlockTradesHandler
-	publicStrucBlockTradesH := w.publicStrucBlockTradesHandler
-	publicBlockTradesH := w.publicBlockTradesHandler
-	blockTickersH := w.blockTickersHandler
-	depInfoH := w.depositInfoHandler
-	wdInfoH := w.withdrawalInfoHandler
-	sprdOrdersH := w.sprdOrdersHandler
-	sprdTradesH := w.sprdTradesHandler
-	tickersH := w.tickersHandler
-	tradesH := w.tradesHandler
-	tradesAllH := w.tradesAllHandler
-	orderBookH := w.orderBookHandler
-	statusH := w.statusHandler
-	openInterestH := w.openInterestHandler
-	fundingRateH := w.fundingRateHandler
-	markPriceH := w.markPriceHandler
-	indexTickersH := w.indexTickersHandler
-	priceLimitH := w.priceLimitHandler
-	optSummaryH := w.optSummaryHandler
-	instrumentsH := w.instrumentsHandler
-	estimatedPriceH := w.estimatedPriceHandler
-	adlWarningH := w.adlWarningHandler
-	economicCalendarH := w.economicCalendarHandler
-	liquidationOrdersH := w.liquidationOrdersHandler
-	optionTradesH := w.optionTradesHandler
-	callAuctionDetailsH := w.callAuctionDetailsHandler
-	candlesH := w.candlesHandler
-	priceCandlesH := w.priceCandlesHandler
-	sprdPublicTradesH := w.sprdPublicTradesHandler
-	sprdTickersH := w.sprdTickersHandler
-	w.typedMu.RUnlock()
-
-	if ordersH == nil &&
-		fillsH == nil &&
-		accountH == nil &&
-		positionsH == nil &&
-		balPosH == nil &&
-		liqWarningH == nil &&
-		accountGreeksH == nil &&
-		ordersAlgoH == nil &&
-		algoAdvanceH == nil &&
-		gridOrdersSpotH == nil &&
-		gridOrdersContractH == nil &&
-		gridPositionsH == nil &&
-		gridSubOrdersH == nil &&
-		algoRecurringBuyH == nil &&
-		copyTradingLeadNotificationH == nil &&
-		rfqsH == nil &&
-		quotesH == nil &&
-		strucBlockTradesH == nil &&
-		publicStrucBlockTradesH == nil &&
-		publicBlockTradesH == nil &&
-		blockTickersH == nil &&
-		depInfoH == nil &&
-		wdInfoH == nil &&
-		sprdOrdersH == nil &&
-		sprdTradesH == nil &&
-		tickersH == nil &&
-		tradesH == nil &&
-		tradesAllH == nil &&
-		orderBookH == nil &&
-		statusH == nil &&
-		openInterestH == nil &&
-		fundingRateH == nil &&
-		markPriceH == nil &&
-		indexTickersH == nil &&
-		priceLimitH == nil &&
-		optSummaryH == nil &&
-		instrumentsH == nil &&
-		estimatedPriceH == nil &&
-		adlWarningH == nil &&
-		economicCalendarH == nil &&
-		liquidationOrdersH == nil &&
-		optionTradesH == nil &&
-		callAuctionDetailsH == nil &&
-		candlesH == nil &&
-		priceCandlesH == nil &&
-		sprdPublicTradesH == nil &&
-		sprdTickersH == nil {
-		return
-	}
-
-	var probe struct {
-		Arg WSArg `json:"arg"`
-	}
-	if err := json.Unmarshal(message, &probe); err != nil {
-		w.onError(fmt.Errorf("okx: ws probe unmarshal failed: %w", err))
-		return
-	}
-	if probe.Arg.Channel == "" {
-		return
-	}
-
-	switch probe.Arg.Channel {
-	case WSChannelOrders:
-		if ordersH == nil {
-			return
-		}
-		dm, ok, err := WSParseOrders(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindOrders, orders: dm.Data})
-	case WSChannelFills:
-		if fillsH == nil {
-			return
-		}
-		dm, ok, err := WSParseFills(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindFills, fills: dm.Data})
-	case WSChannelAccount:
-		if accountH == nil {
-			return
-		}
-		dm, ok, err := WSParseAccount(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindAccount, balances: dm.Data})
-	case WSChannelPositions:
-		if positionsH == nil {
-			return
-		}
-		dm, ok, err := WSParsePositions(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindPositions, positions: dm.Data})
-	case WSChannelBalanceAndPosition:
-		if balPosH == nil {
-			return
-		}
-		dm, ok, err := WSParseBalanceAndPosition(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindBalanceAndPosition, balPos: dm.Data})
-	case WSChannelLiquidationWarning:
-		if liqWarningH == nil {
-			return
-		}
-		dm, ok, err := WSParseLiquidationWarning(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindLiquidationWarning, liquidationWarnings: dm.Data})
-	case WSChannelAccountGreeks:
-		if accountGreeksH == nil {
-			return
-		}
-		dm, ok, err := WSParseAccountGreeks(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindAccountGreeks, accountGreeks: dm.Data})
-	case WSChannelOrdersAlgo:
-		if ordersAlgoH == nil {
-			return
-		}
-		dm, ok, err := WSParseOrdersAlgo(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindOrdersAlgo, ordersAlgo: dm.Data})
-	case WSChannelAlgoAdvance:
-		if algoAdvanceH == nil {
-			return
-		}
-		dm, ok, err := WSParseAlgoAdvance(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindAlgoAdvance, algoAdvance: dm.Data})
-	case WSChannelGridOrdersSpot:
-		if gridOrdersSpotH == nil {
-			return
-		}
-		dm, ok, err := WSParseGridOrdersSpot(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindGridOrdersSpot, gridOrdersSpot: dm.Data})
-	case WSChannelGridOrdersContract:
-		if gridOrdersContractH == nil {
-			return
-		}
-		dm, ok, err := WSParseGridOrdersContract(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindGridOrdersContract, gridOrdersContract: dm.Data})
-	case WSChannelGridPositions:
-		if gridPositionsH == nil {
-			return
-		}
-		dm, ok, err := WSParseGridPositions(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindGridPositions, gridPositions: dm.Data})
-	case WSChannelGridSubOrders:
-		if gridSubOrdersH == nil {
-			return
-		}
-		dm, ok, err := WSParseGridSubOrders(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindGridSubOrders, gridSubOrders: dm.Data})
-	case WSChannelAlgoRecurringBuy:
-		if algoRecurringBuyH == nil {
-			return
-		}
-		dm, ok, err := WSParseAlgoRecurringBuy(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindAlgoRecurringBuy, algoRecurringBuy: dm.Data})
-	case WSChannelCopytradingLeadNotification:
-		if copyTradingLeadNotificationH == nil {
-			return
-		}
-		dm, ok, err := WSParseCopytradingLeadNotification(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindCopyTradingLeadNotification, copyTradingLeadNotification: dm.Data})
-	case WSChannelRFQs:
-		if rfqsH == nil {
-			return
-		}
-		dm, ok, err := WSParseRFQs(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindRFQs, rfqs: dm.Data})
-	case WSChannelQuotes:
-		if quotesH == nil {
-			return
-		}
-		dm, ok, err := WSParseQuotes(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindQuotes, quotes: dm.Data})
-	case WSChannelStrucBlockTrades:
-		if strucBlockTradesH == nil {
-			return
-		}
-		dm, ok, err := WSParseStrucBlockTrades(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindStrucBlockTrades, strucBlockTrades: dm.Data})
-	case WSChannelPublicStrucBlockTrades:
-		if publicStrucBlockTradesH == nil {
-			return
-		}
-		dm, ok, err := WSParsePublicStrucBlockTrades(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindPublicStrucBlockTrades, publicStrucBlockTrades: dm.Data})
-	case WSChannelPublicBlockTrades:
-		if publicBlockTradesH == nil {
-			return
-		}
-		dm, ok, err := WSParsePublicBlockTrades(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindPublicBlockTrades, publicBlockTrades: dm.Data})
-	case WSChannelBlockTickers:
-		if blockTickersH == nil {
-			return
-		}
-		dm, ok, err := WSParseBlockTickers(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindBlockTickers, blockTickers: dm.Data})
-	case WSChannelDepositInfo:
-		if depInfoH == nil {
-			return
-		}
-		dm, ok, err := WSParseDepositInfo(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindDepositInfo, depositInfo: dm.Data})
-	case WSChannelWithdrawalInfo:
-		if wdInfoH == nil {
-			return
-		}
-		dm, ok, err := WSParseWithdrawalInfo(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindWithdrawalInfo, withdrawalInfo: dm.Data})
-	case WSChannelSprdOrders:
-		if sprdOrdersH == nil {
-			return
-		}
-		dm, ok, err := WSParseSprdOrders(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindSprdOrders, sprdOrders: dm.Data})
-	case WSChannelSprdTrades:
-		if sprdTradesH == nil {
-			return
-		}
-		dm, ok, err := WSParseSprdTrades(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindSprdTrades, sprdTrades: dm.Data})
-	case WSChannelTickers:
-		if tickersH == nil {
-			return
-		}
-		dm, ok, err := WSParseTickers(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindTickers, tickers: dm.Data})
-	case WSChannelTrades:
-		if tradesH == nil {
-			return
-		}
-		dm, ok, err := WSParseTrades(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindTrades, trades: dm.Data})
-	case WSChannelTradesAll:
-		if tradesAllH == nil {
-			return
-		}
-		dm, ok, err := WSParseTradesAll(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindTradesAll, tradesAll: dm.Data})
-	case WSChannelStatus:
-		if statusH == nil {
-			return
-		}
-		dm, ok, err := WSParseStatus(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindStatus, statuses: dm.Data})
-	case WSChannelOpenInterest:
-		if openInterestH == nil {
-			return
-		}
-		dm, ok, err := WSParseOpenInterest(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindOpenInterest, openInterests: dm.Data})
-	case WSChannelFundingRate:
-		if fundingRateH == nil {
-			return
-		}
-		dm, ok, err := WSParseFundingRate(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindFundingRate, fundingRates: dm.Data})
-	case WSChannelMarkPrice:
-		if markPriceH == nil {
-			return
-		}
-		dm, ok, err := WSParseMarkPrice(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindMarkPrice, markPrices: dm.Data})
-	case WSChannelIndexTickers:
-		if indexTickersH == nil {
-			return
-		}
-		dm, ok, err := WSParseIndexTickers(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindIndexTickers, indexTickers: dm.Data})
-	case WSChannelPriceLimit:
-		if priceLimitH == nil {
-			return
-		}
-		dm, ok, err := WSParsePriceLimit(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindPriceLimit, priceLimits: dm.Data})
-	case WSChannelOptSummary:
-		if optSummaryH == nil {
-			return
-		}
-		dm, ok, err := WSParseOptSummary(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindOptSummary, optSummaries: dm.Data})
-	case WSChannelInstruments:
-		if instrumentsH == nil {
-			return
-		}
-		dm, ok, err := WSParseInstruments(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindInstruments, instruments: dm.Data})
-	case WSChannelEstimatedPrice:
-		if estimatedPriceH == nil {
-			return
-		}
-		dm, ok, err := WSParseEstimatedPrice(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindEstimatedPrice, estimatedPrices: dm.Data})
-	case WSChannelADLWarning:
-		if adlWarningH == nil {
-			return
-		}
-		dm, ok, err := WSParseADLWarning(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindADLWarning, adlWarnings: dm.Data})
-	case WSChannelEconomicCalendar:
-		if economicCalendarH == nil {
-			return
-		}
-		dm, ok, err := WSParseEconomicCalendar(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindEconomicCalendar, economicCalendarEvents: dm.Data})
-	case WSChannelLiquidationOrders:
-		if liquidationOrdersH == nil {
-			return
-		}
-		dm, ok, err := WSParseLiquidationOrders(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindLiquidationOrders, liquidationOrders: dm.Data})
-	case WSChannelOptionTrades:
-		if optionTradesH == nil {
-			return
-		}
-		dm, ok, err := WSParseOptionTrades(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindOptionTrades, optionTrades: dm.Data})
-	case WSChannelCallAuctionDetails:
-		if callAuctionDetailsH == nil {
-			return
-		}
-		dm, ok, err := WSParseCallAuctionDetails(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindCallAuctionDetails, callAuctionDetails: dm.Data})
-	case WSChannelSprdPublicTrades:
-		if sprdPublicTradesH == nil {
-			return
-		}
-		dm, ok, err := WSParseSprdPublicTrades(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindSprdPublicTrades, sprdPublicTrades: dm.Data})
-	case WSChannelSprdTickers:
-		if sprdTickersH == nil {
-			return
-		}
-		dm, ok, err := WSParseSprdTickers(message)
-		if !w.wsParseGuard(probe.Arg.Channel, ok, err) || len(dm.Data) == 0 {
-			return
-		}
-		w.dispatchTyped(wsTypedTask{kind: wsTypedKindSprdTickers, sprdTickers: dm.Data})
-	default:
-		channel := probe.Arg.Channel
-
-		if orderBookH != nil && isOrderBookChannel(channel) {
-			dm, ok, err := WSParseOrderBook(message)
-			if !w.wsParseGuard(channel, ok, err) || len(dm.Data) == 0 {
-				return
-			}
-			w.dispatchTyped(wsTypedTask{kind: wsTypedKindOrderBook, orderBooks: []WSData[WSOrderBook]{*dm}})
-			return
-		}
-
-		if candlesH != nil {
-			switch {
-			case isCandleChannel(channel):
-				dm, ok, err := WSParseCandles(message)
-				if !w.wsParseGuard(channel, ok, err) || len(dm.Data) == 0 {
-					return
-				}
-				out := make([]WSCandle, 0, len(dm.Data))
-				for _, c := range dm.Data {
-					cc := c
-					out = append(out, WSCandle{Arg: dm.Arg, Candle: cc})
-				}
-				w.dispatchTyped(wsTypedTask{kind: wsTypedKindCandles, candles: out})
-				return
-			case isSprdCandleChannel(channel):
-				dm, ok, err := WSParseSprdCandles(message)
-				if !w.wsParseGuard(channel, ok, err) || len(dm.Data) == 0 {
-					return
-				}
-				out := make([]WSCandle, 0, len(dm.Data))
-				for _, c := range dm.Data {
-					cc := c
-					out = append(out, WSCandle{Arg: dm.Arg, Candle: cc})
-				}
-				w.dispatchTyped(wsTypedTask{kind: wsTypedKindCandles, candles: out})
-				return
-			default:
-				// continue
-			}
-		}
-
-		if priceCandlesH != nil {
-			switch {
-			case isMarkPriceCandleChannel(channel):
-				dm, ok, err := WSParseMarkPriceCandles(message)
-				if !w.wsParseGuard(channel, ok, err) || len(dm.Data) == 0 {
-					return
-				}
-				out := make([]WSPriceCandle, 0, len(dm.Data))
-				for _, c := range dm.Data {
-					cc := c
-					out = append(out, WSPriceCandle{Arg: dm.Arg, Candle: cc})
-				}
-				w.dispatchTyped(wsTypedTask{kind: wsTypedKindPriceCandles, priceCandles: out})
-				return
-			case isIndexCandleChannel(channel):
-				dm, ok, err := WSParseIndexCandles(message)
-				if !w.wsParseGuard(channel, ok, err) || len(dm.Data) == 0 {
-					return
-				}
-				out := make([]WSPriceCandle, 0, len(dm.Data))
-				for _, c := range dm.Data {
-					cc := c
-					out = append(out, WSPriceCandle{Arg: dm.Arg, Candle: cc})
-				}
-				w.dispatchTyped(wsTypedTask{kind: wsTypedKindPriceCandles, priceCandles: out})
-				return
-			default:
-				// continue
-			}
-		}
-
-		return
-	}
-}
-
 func (w *WSClient) wsParseGuard(channel string, ok bool, err error) bool {
 	if w == nil {
 		return false
@@ -1675,18 +1216,23 @@ func (w *WSClient) writeJSON(conn *websocket.Conn, v any) error {
 	if w == nil || conn == nil {
 		return errors.New("okx: ws write requires conn")
 	}
+	err := w.writeJSONConn(conn, v)
+	if err != nil {
+		w.closeConn()
+	}
+	return err
+}
+
+// writeJSONConn 写入 JSON 消息；与 writeJSON 不同，失败时不关闭当前连接（用于尚未接管的连接）。
+func (w *WSClient) writeJSONConn(conn *websocket.Conn, v any) error {
 	timeout := w.writeTimeout
 
 	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
 	if timeout > 0 {
 		_ = conn.SetWriteDeadline(time.Now().Add(timeout))
 	}
-	err := conn.WriteJSON(v)
-	w.writeMu.Unlock()
-	if err != nil {
-		w.closeConn()
-	}
-	return err
+	return conn.WriteJSON(v)
 }
 
 func (w *WSClient) writeText(conn *websocket.Conn, message string) error {
@@ -1903,6 +1449,9 @@ func (w *WSClient) closeConn() {
 	w.mu.Lock()
 	conn := w.conn
 	w.conn = nil
+	if h := w.handoverNext; h != nil && h.conn == conn {
+		w.handoverNext = nil
+	}
 	w.notifyConnChangeLocked()
 	w.mu.Unlock()
 	if conn != nil {
@@ -1956,8 +1505,14 @@ func (w *WSClient) nextOpID() string {
 }
 
 func (w *WSClient) registerWaiter(id string, op string, args []WSArg) *wsOpWaiter {
+	return w.registerWaiterIDs([]string{id}, op, args)
+}
+
+// registerWaiterIDs 注册一个覆盖多个请求 id（拆批发送）的 waiter。
+func (w *WSClient) registerWaiterIDs(ids []string, op string, args []WSArg) *wsOpWaiter {
 	waiter := &wsOpWaiter{
 		op:        op,
+		ids:       ids,
 		remaining: make(map[string]struct{}, len(args)),
 		done:      make(chan error, 1),
 	}
@@ -1966,17 +1521,30 @@ func (w *WSClient) registerWaiter(id string, op string, args []WSArg) *wsOpWaite
 	}
 
 	w.waitMu.Lock()
-	w.waiters[id] = waiter
+	for _, id := range ids {
+		w.waiters[id] = waiter
+	}
 	w.waitMu.Unlock()
 	return waiter
 }
 
+// removeWaiter 移除 id 对应的 waiter（含其全部批次 id）。
 func (w *WSClient) removeWaiter(id string) {
 	w.waitMu.Lock()
-	delete(w.waiters, id)
+	w.deleteWaiterLocked(id)
 	w.waitMu.Unlock()
 }
 
+func (w *WSClient) deleteWaiterLocked(id string) {
+	waiter := w.waiters[id]
+	if waiter == nil {
+		return
+	}
+	for _, other := range waiter.ids {
+		delete(w.waiters, other)
+	}
+}
+
 func (w *WSClient) notifyWaiter(ev WSEvent) {
 	if ev.ID == "" {
 		return
@@ -1996,7 +1564,7 @@ func (w *WSClient) notifyWaiter(ev WSEvent) {
 		case "unsubscribe":
 			w.unsubscribeErr.Add(1)
 		}
-		delete(w.waiters, ev.ID)
+		w.deleteWaiterLocked(ev.ID)
 		w.waitMu.Unlock()
 		select {
 		case waiter.done <- fmt.Errorf("okx: ws op=%s id=%s code=%s msg=%s", waiter.op, ev.ID, ev.Code, ev.Msg):
@@ -2015,7 +1583,7 @@ func (w *WSClient) notifyWaiter(ev WSEvent) {
 		w.waitMu.Unlock()
 		return
 	}
-	delete(w.waiters, ev.ID)
+	w.deleteWaiterLocked(ev.ID)
 	w.waitMu.Unlock()
 
 	switch waiter.op {
@@ -2076,6 +1644,13 @@ func (w *WSClient) notifyOpWaiter(reply WSOpReply, raw []byte) {
 	delete(w.opWaiters, reply.ID)
 	w.opWaitMu.Unlock()
 
+	if w.latency != nil {
+		if sent := waiter.sentAt.Load(); sent != 0 {
+			now := time.Now()
+			w.observeLatency(WSLatencyKindOpRoundTrip, waiter.op, now.Sub(time.Unix(0, sent)), now)
+		}
+	}
+
 	select {
 	case waiter.done <- wsOpRespResult{reply: &reply, raw: raw}:
 	default:
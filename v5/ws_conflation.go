@@ -0,0 +1,96 @@
+package okx
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// wsDefaultConflationChannels 为 WithWSConflation 未指定频道时的默认频道（每条推送均为全量最新值）。
+var wsDefaultConflationChannels = []string{
+	WSChannelTickers, WSChannelMarkPrice, WSChannelIndexTickers, WSChannelBooks5, WSChannelBboTbt,
+}
+
+// wsIncrementalChannels 为增量推送频道：合并会丢失增量，WithWSConflation 会忽略这些频道。
+var wsIncrementalChannels = map[string]bool{
+	WSChannelBooks:          true,
+	WSChannelBooksELP:       true,
+	WSChannelBooksL2Tbt:     true,
+	WSChannelBooks50L2Tbt:   true,
+	WSChannelSprdBooksL2Tbt: true,
+}
+
+// WithWSConflation 对指定频道的内置 typed handler 启用合并分发：按 channel+instId 仅保留最新一条未消费的推送，
+// handler 就绪时送达该最新值（被覆盖的推送计入 WSStats.Conflated）。
+//
+// 未指定 channels 时默认合并 tickers、mark-price、index-tickers、books5、bbo-tbt。
+// 适用于只关心最新值的全量推送；增量深度频道（books、books-l2-tbt 等）会被忽略。
+// 与 WSQueueFullDrop 的任意丢弃不同，合并后每个 key 始终能拿到最新值，且同一 key 在队列中最多占用一个位置。
+func WithWSConflation(channels ...string) WSOption {
+	return func(c *WSClient) {
+		if len(channels) == 0 {
+			channels = wsDefaultConflationChannels
+		}
+		cf := &wsConflator{channels: make(map[string]bool), pending: make(map[string]wsTypedTask)}
+		for _, ch := range channels {
+			if ch != "" && !wsIncrementalChannels[ch] {
+				cf.channels[ch] = true
+			}
+		}
+		c.conflator = cf
+	}
+}
+
+type wsConflator struct {
+	channels map[string]bool
+
+	mu      sync.Mutex
+	pending map[string]wsTypedTask
+
+	conflated atomic.Uint64
+}
+
+func wsConflationKey(channel string, arg *WSArg) string {
+	if arg == nil {
+		return channel
+	}
+	id := arg.InstId
+	if id == "" {
+		id = arg.SprdId
+	}
+	return channel + "|" + id
+}
+
+// take 取出 key 当前最新的待分发任务。
+func (c *wsConflator) take(key string) (wsTypedTask, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	task, ok := c.pending[key]
+	delete(c.pending, key)
+	return task, ok
+}
+
+func (c *wsConflator) pendingLen() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.pending)
+}
+
+// dispatchConflated 合并分发：key 已有未消费任务时仅替换为最新值；否则记录并向 typed 队列投递一个占位任务，
+// 占位任务被执行时再取出该 key 的最新值。
+func (w *WSClient) dispatchConflated(key string, task wsTypedTask) {
+	c := w.conflator
+	c.mu.Lock()
+	if _, ok := c.pending[key]; ok {
+		c.pending[key] = task
+		c.mu.Unlock()
+		c.conflated.Add(1)
+		return
+	}
+	c.pending[key] = task
+	c.mu.Unlock()
+
+	if !w.dispatchTypedTask(wsTypedTask{kind: task.kind, conflateKey: key}) {
+		// 占位任务未入队（drop/disconnect 或已关闭）：清除该 key，下一条推送重新投递。
+		c.take(key)
+	}
+}
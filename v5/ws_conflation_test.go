@@ -0,0 +1,113 @@
+package okx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestWSClient_Conflation_DeliversLatestPerKey(t *testing.T) {
+	w := &WSClient{
+		typedAsync: true,
+		typedQueue: make(chan wsTypedTask, 4),
+	}
+	WithWSConflation()(w)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var mu sync.Mutex
+	var got []string
+	w.OnTickers(func(tk MarketTicker) {
+		select {
+		case started <- struct{}{}:
+			<-release
+		default:
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, tk.InstId+"@"+tk.Last)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go w.typedDispatchLoop(ctx)
+
+	ticker := func(instId, last string) []byte {
+		return []byte(fmt.Sprintf(`{"arg":{"channel":"tickers","instId":"%s"},"data":[{"instId":"%s","last":"%s"}]}`, instId, instId, last))
+	}
+	w.onDataMessage(ticker("BTC-USDT", "1"))
+	<-started
+	// handler 阻塞中：同一 key 只保留最新值。
+	for i := 2; i <= 4; i++ {
+		w.onDataMessage(ticker("BTC-USDT", fmt.Sprint(i)))
+	}
+	w.onDataMessage(ticker("ETH-USDT", "1"))
+
+	st := w.Stats()
+	if st.Conflated != 2 || st.ConflationPending != 2 || st.TypedQueueLen != 2 {
+		t.Fatalf("stats: conflated=%d pending=%d queue=%d", st.Conflated, st.ConflationPending, st.TypedQueueLen)
+	}
+	close(release)
+
+	waitFor(t, "conflated tickers", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == 3
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	if fmt.Sprint(got) != "[BTC-USDT@1 BTC-USDT@4 ETH-USDT@1]" {
+		t.Fatalf("got = %v", got)
+	}
+	if w.Stats().ConflationPending != 0 {
+		t.Fatalf("pending not drained")
+	}
+}
+
+func TestWSClient_Conflation_DroppedPlaceholderClearsKey(t *testing.T) {
+	w := &WSClient{
+		typedAsync:           true,
+		typedQueue:           make(chan wsTypedTask, 1),
+		typedQueueFullPolicy: WSQueueFullDrop,
+	}
+	WithWSConflation(WSChannelMarkPrice)(w)
+
+	var got []string
+	w.OnMarkPrice(func(p MarkPrice) { got = append(got, p.InstId+"@"+p.MarkPx) })
+
+	// 队列被其他任务占满：占位任务被丢弃后，key 不应永久挂起。
+	w.typedQueue <- wsTypedTask{kind: wsTypedKindCandles}
+	w.onDataMessage([]byte(`{"arg":{"channel":"mark-price","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","markPx":"1"}]}`))
+	if st := w.Stats(); st.ConflationPending != 0 || st.TypedDropped != 1 {
+		t.Fatalf("stats: pending=%d dropped=%d", st.ConflationPending, st.TypedDropped)
+	}
+
+	<-w.typedQueue
+	w.onDataMessage([]byte(`{"arg":{"channel":"mark-price","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","markPx":"2"}]}`))
+	w.handleTyped(<-w.typedQueue)
+	if fmt.Sprint(got) != "[BTC-USDT@2]" {
+		t.Fatalf("got = %v", got)
+	}
+}
+
+func TestWithWSConflation_IgnoresIncrementalChannels(t *testing.T) {
+	w := &WSClient{}
+	WithWSConflation(WSChannelBooks, WSChannelBooksL2Tbt, WSChannelBooks5, "")(w)
+	if len(w.conflator.channels) != 1 || !w.conflator.channels[WSChannelBooks5] {
+		t.Fatalf("channels = %#v", w.conflator.channels)
+	}
+
+	// 未 Start（同步分发）时直接送达。
+	var books int
+	w.OnOrderBook(func(WSData[WSOrderBook]) { books++ })
+	w.onDataMessage([]byte(`{"arg":{"channel":"books5","instId":"BTC-USDT"},"data":[{"asks":[],"bids":[],"ts":"1"}]}`))
+	w.onDataMessage([]byte(`{"arg":{"channel":"books","instId":"BTC-USDT"},"data":[{"asks":[],"bids":[],"ts":"1"}]}`))
+	if books != 2 || w.Stats().Conflated != 0 {
+		t.Fatalf("books = %d", books)
+	}
+
+	if got := wsConflationKey(WSChannelBboTbt, &WSArg{SprdId: "S"}); got != "bbo-tbt|S" {
+		t.Fatalf("key = %q", got)
+	}
+}
@@ -0,0 +1,291 @@
+package okx
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// WSConsumerStats 为单个消费者的运行状态。
+type WSConsumerStats struct {
+	Name      string
+	Channel   string
+	Policy    WSQueueFullPolicy
+	QueueLen  int
+	QueueCap  int
+	Delivered uint64
+	Dropped   uint64
+	Panics    uint64
+	Closed    bool
+}
+
+// wsConsumerChannels 为支持 ConsumeWS 的频道及其 typed 分发类型。
+var wsConsumerChannels = map[string]wsTypedKind{
+	WSChannelOrders:             wsTypedKindOrders,
+	WSChannelFills:              wsTypedKindFills,
+	WSChannelAccount:            wsTypedKindAccount,
+	WSChannelPositions:          wsTypedKindPositions,
+	WSChannelBalanceAndPosition: wsTypedKindBalanceAndPosition,
+	WSChannelOrdersAlgo:         wsTypedKindOrdersAlgo,
+}
+
+// wsConsumerTypes 为各 typed 分发类型对应的元素类型（用于校验 ConsumeWS 的 T）。
+var wsConsumerTypes = map[wsTypedKind]any{
+	wsTypedKindOrders:             TradeOrder{},
+	wsTypedKindFills:              WSFill{},
+	wsTypedKindAccount:            AccountBalance{},
+	wsTypedKindPositions:          AccountPosition{},
+	wsTypedKindBalanceAndPosition: WSBalanceAndPosition{},
+	wsTypedKindOrdersAlgo:         TradeAlgoOrder{},
+}
+
+// wsTypedConsumer 为注册在 WSClient 上的 typed 消费者。
+type wsTypedConsumer interface {
+	offerAny(v any)
+	Stats() WSConsumerStats
+	closeLocal()
+}
+
+// WSConsumer 为 ConsumeWS 注册的单个消费者：拥有独立的队列、worker goroutine 与队列满策略。
+type WSConsumer[T any] struct {
+	w       *WSClient
+	kind    wsTypedKind
+	channel string
+	name    string
+	handler func(T)
+	policy  WSQueueFullPolicy
+	limit   int
+
+	mu        sync.Mutex
+	queue     []T
+	wake      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+	panics    atomic.Uint64
+	warnAt    atomic.Int64
+}
+
+// ConsumeWS 在 w 上为 channel 的 typed 推送注册一个消费者：handler 在该消费者独立的 goroutine 中按序执行，
+// 慢消费者或 panic 不会影响其他消费者、OnXxx 回调与 WS read goroutine（投递从不阻塞）。
+//
+// 约定：
+// - 支持 orders、fills、account、positions、balance_and_position、orders-algo；T 需与该频道的 OnXxx 回调参数类型一致；
+// - 消费者注册在 WSClient 内部，与 OnXxx 回调并存且互不替换；断线补偿（WithWSGapFill）补发的记录同样送达；
+// - 队列参数复用 WithSubscriptionBuffer / WithSubscriptionQueueFullPolicy，buffer 为队列告警阈值（默认 1024）；
+// - 默认 block：不丢数据，队列超过阈值后继续缓存并经 error handler 告警（WSQueueFullError），不阻塞分发；
+// - drop 丢弃该消费者超过阈值的数据（订单/成交等交易频道慎用）；disconnect 摘除并关闭该消费者（不断开 WS 连接）；
+// - 仅负责分发，不发送订阅；调用 Close 摘除消费者并释放 goroutine。
+func ConsumeWS[T any](w *WSClient, channel, name string, handler func(T), opts ...SubscriptionOption) (*WSConsumer[T], error) {
+	if w == nil {
+		return nil, errors.New("okx: ws consume requires client")
+	}
+	if handler == nil {
+		return nil, errors.New("okx: ws consume requires handler")
+	}
+	kind, ok := wsConsumerChannels[channel]
+	if !ok {
+		return nil, fmt.Errorf("okx: ws consume unsupported channel %q", channel)
+	}
+	if _, ok := wsConsumerTypes[kind].(T); !ok {
+		return nil, fmt.Errorf("okx: ws consume channel %q does not deliver %T", channel, *new(T))
+	}
+
+	cfg := subscriptionConfig{buffer: defaultWSSubscriptionBuffer, policy: WSQueueFullBlock}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	c := &WSConsumer[T]{
+		w:       w,
+		kind:    kind,
+		channel: channel,
+		name:    name,
+		handler: handler,
+		policy:  cfg.policy,
+		limit:   cfg.buffer,
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	w.typedMu.Lock()
+	if w.consumers == nil {
+		w.consumers = make(map[wsTypedKind][]wsTypedConsumer)
+	}
+	w.consumers[kind] = append(w.consumers[kind], c)
+	w.typedMu.Unlock()
+
+	go c.loop()
+	return c, nil
+}
+
+// ConsumerStats 返回 ConsumeWS 注册的各消费者的运行状态（按频道、注册顺序）。
+func (w *WSClient) ConsumerStats() []WSConsumerStats {
+	if w == nil {
+		return nil
+	}
+	w.typedMu.RLock()
+	defer w.typedMu.RUnlock()
+	var out []WSConsumerStats
+	for _, kind := range []wsTypedKind{
+		wsTypedKindOrders, wsTypedKindFills, wsTypedKindAccount,
+		wsTypedKindPositions, wsTypedKindBalanceAndPosition, wsTypedKindOrdersAlgo,
+	} {
+		for _, c := range w.consumers[kind] {
+			out = append(out, c.Stats())
+		}
+	}
+	return out
+}
+
+// hasConsumers 判断 kind 是否有消费者（调用方持有 typedMu 读锁）。
+func (w *WSClient) hasConsumers(kind wsTypedKind) bool {
+	return len(w.consumers[kind]) > 0
+}
+
+func (w *WSClient) removeConsumer(kind wsTypedKind, c wsTypedConsumer) {
+	w.typedMu.Lock()
+	defer w.typedMu.Unlock()
+	cs := w.consumers[kind]
+	out := make([]wsTypedConsumer, 0, len(cs))
+	for _, other := range cs {
+		if other != c {
+			out = append(out, other)
+		}
+	}
+	// 复制而非原地修改：分发可能正在遍历旧切片。
+	w.consumers[kind] = out
+}
+
+// publishWSConsumers 将 v 投递给各消费者（在 typed 分发 goroutine 中执行；投递不阻塞）。
+func publishWSConsumers(cs []wsTypedConsumer, v any) {
+	for _, c := range cs {
+		c.offerAny(v)
+	}
+}
+
+// Name 返回消费者名称。
+func (c *WSConsumer[T]) Name() string {
+	return c.name
+}
+
+// Done 返回消费者关闭后的信号通道。
+func (c *WSConsumer[T]) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close 摘除并关闭消费者（队列中尚未处理的数据被丢弃）。
+func (c *WSConsumer[T]) Close() {
+	c.w.removeConsumer(c.kind, c)
+	c.closeLocal()
+}
+
+// Stats 返回消费者的运行状态（QueueCap 为告警阈值；block 策略下 QueueLen 可能超过该值）。
+func (c *WSConsumer[T]) Stats() WSConsumerStats {
+	c.mu.Lock()
+	n := len(c.queue)
+	c.mu.Unlock()
+	s := WSConsumerStats{
+		Name:      c.name,
+		Channel:   c.channel,
+		Policy:    c.policy,
+		QueueLen:  n,
+		QueueCap:  c.limit,
+		Delivered: c.delivered.Load(),
+		Dropped:   c.dropped.Load(),
+		Panics:    c.panics.Load(),
+	}
+	select {
+	case <-c.done:
+		s.Closed = true
+	default:
+	}
+	return s
+}
+
+func (c *WSConsumer[T]) closeLocal() {
+	c.closeOnce.Do(func() { close(c.done) })
+}
+
+func (c *WSConsumer[T]) offerAny(v any) {
+	if t, ok := v.(T); ok {
+		c.offer(t)
+	}
+}
+
+// offer 将 v 放入消费者队列；从不阻塞调用方。
+func (c *WSConsumer[T]) offer(v T) {
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+
+	var over *WSQueueFullError
+	c.mu.Lock()
+	n := len(c.queue)
+	if n >= c.limit {
+		qfe := &WSQueueFullError{Queue: "consumer:" + c.name, Kind: c.channel, Policy: c.policy, QueueLen: n, QueueCap: c.limit}
+		switch c.policy {
+		case WSQueueFullDisconnect:
+			c.mu.Unlock()
+			c.dropped.Add(1)
+			c.w.onError(qfe)
+			c.Close()
+			return
+		case WSQueueFullDrop:
+			c.mu.Unlock()
+			c.dropped.Add(1)
+			c.w.warnQueueFull(&c.warnAt, qfe)
+			return
+		default: // WSQueueFullBlock：超过阈值后继续缓存并告警
+			over = qfe
+		}
+	}
+	c.queue = append(c.queue, v)
+	c.mu.Unlock()
+	if over != nil {
+		c.w.warnQueueFull(&c.warnAt, over)
+	}
+
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (c *WSConsumer[T]) loop() {
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-c.wake:
+		}
+
+		c.mu.Lock()
+		batch := c.queue
+		c.queue = nil
+		c.mu.Unlock()
+		for _, v := range batch {
+			select {
+			case <-c.done:
+				return
+			default:
+			}
+			c.call(v)
+		}
+	}
+}
+
+func (c *WSConsumer[T]) call(v T) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.panics.Add(1)
+			c.w.onError(fmt.Errorf("okx: ws consumer panic channel=%s name=%s: %v", c.channel, c.name, r))
+		}
+	}()
+	c.handler(v)
+	c.delivered.Add(1)
+}
@@ -0,0 +1,274 @@
+package okx
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConsumeWS_SlowAndPanickingConsumersAreIsolated(t *testing.T) {
+	var errMu sync.Mutex
+	var errs []error
+	w := &WSClient{errHandler: func(err error) {
+		errMu.Lock()
+		defer errMu.Unlock()
+		errs = append(errs, err)
+	}}
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	slow, err := ConsumeWS(w, WSChannelOrders, "ui", func(TradeOrder) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+	}, WithSubscriptionBuffer(1), WithSubscriptionQueueFullPolicy(WSQueueFullDrop))
+	if err != nil {
+		t.Fatalf("ConsumeWS() error = %v", err)
+	}
+	var mu sync.Mutex
+	var fast []string
+	if _, err := ConsumeWS(w, WSChannelOrders, "risk", func(o TradeOrder) {
+		mu.Lock()
+		defer mu.Unlock()
+		fast = append(fast, o.OrdId)
+	}); err != nil {
+		t.Fatalf("ConsumeWS() error = %v", err)
+	}
+	bad, err := ConsumeWS(w, WSChannelOrders, "oms", func(o TradeOrder) {
+		if o.OrdId == "1" {
+			panic("boom")
+		}
+	})
+	if err != nil {
+		t.Fatalf("ConsumeWS() error = %v", err)
+	}
+
+	// 之后设置的 OnOrders 与消费者并存，不会替换消费者。
+	var handled []string
+	w.OnOrders(func(o TradeOrder) { handled = append(handled, o.OrdId) })
+
+	push := func(ordId string) {
+		w.onDataMessage([]byte(`{"arg":{"channel":"orders","instType":"ANY"},"data":[{"instId":"BTC-USDT","ordId":"` + ordId + `","state":"live"}]}`))
+	}
+	push("1")
+	<-started
+	for _, id := range []string{"2", "3", "4", "5"} {
+		push(id)
+	}
+	waitFor(t, "fast consumer", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(fast) == 5
+	})
+	waitFor(t, "panicking consumer", func() bool { return bad.Stats().Delivered == 4 })
+	if len(handled) != 5 {
+		t.Fatalf("OnOrders handled = %v", handled)
+	}
+
+	st := slow.Stats()
+	// 1 条处理中 + 1 条在队列，其余丢弃。
+	if st.Dropped != 3 || st.QueueCap != 1 || st.Policy != WSQueueFullDrop || st.Channel != WSChannelOrders || st.Closed {
+		t.Fatalf("slow stats = %#v", st)
+	}
+	if bs := bad.Stats(); bs.Panics != 1 || bs.Closed {
+		t.Fatalf("bad stats = %#v", bs)
+	}
+
+	errMu.Lock()
+	var qfe *WSQueueFullError
+	var sawPanic, sawFull bool
+	for _, err := range errs {
+		if errors.As(err, &qfe) && qfe.Queue == "consumer:ui" {
+			sawFull = true
+		}
+		if strings.Contains(err.Error(), "consumer panic channel=orders name=oms") {
+			sawPanic = true
+		}
+	}
+	errMu.Unlock()
+	if !sawFull || !sawPanic {
+		t.Fatalf("errors = %v", errs)
+	}
+	close(release)
+
+	if st := w.ConsumerStats(); len(st) != 3 {
+		t.Fatalf("ConsumerStats() = %#v", st)
+	}
+	slow.Close()
+	if st := w.ConsumerStats(); len(st) != 2 || st[0].Name != "risk" {
+		t.Fatalf("ConsumerStats() after close = %#v", st)
+	}
+}
+
+func TestConsumeWS_DefaultBlockDoesNotDrop(t *testing.T) {
+	var errMu sync.Mutex
+	var errs []error
+	w := &WSClient{errHandler: func(err error) {
+		errMu.Lock()
+		defer errMu.Unlock()
+		errs = append(errs, err)
+	}}
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var got []string
+	c, err := ConsumeWS(w, WSChannelFills, "oms", func(f WSFill) {
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, f.TradeId)
+	}, WithSubscriptionBuffer(1))
+	if err != nil {
+		t.Fatalf("ConsumeWS() error = %v", err)
+	}
+	if c.Stats().Policy != WSQueueFullBlock {
+		t.Fatalf("default policy = %q, want block", c.Stats().Policy)
+	}
+
+	// 超过阈值后继续缓存并告警，不阻塞分发。
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, id := range []string{"1", "2", "3", "4"} {
+			w.onDataMessage([]byte(`{"arg":{"channel":"fills"},"data":[{"instId":"BTC-USDT","tradeId":"` + id + `"}]}`))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("publish blocked on a slow consumer")
+	}
+	errMu.Lock()
+	var qfe *WSQueueFullError
+	if len(errs) == 0 || !errors.As(errs[0], &qfe) || qfe.Queue != "consumer:oms" {
+		t.Fatalf("errors = %v, want consumer queue warning", errs)
+	}
+	errMu.Unlock()
+
+	close(release)
+	waitFor(t, "all fills", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == 4
+	})
+	mu.Lock()
+	if got[0] != "1" || got[3] != "4" {
+		t.Fatalf("got = %v, want in order", got)
+	}
+	mu.Unlock()
+	if st := c.Stats(); st.Dropped != 0 || st.Delivered != 4 {
+		t.Fatalf("stats = %#v", st)
+	}
+	c.Close()
+}
+
+func TestConsumeWS_HungConsumerDoesNotStallSiblings(t *testing.T) {
+	w := &WSClient{}
+	hang := make(chan struct{})
+	t.Cleanup(func() { close(hang) })
+
+	// 默认策略与显式 block 的消费者挂起时，其余消费者与 OnOrders 仍按序收到数据。
+	if _, err := ConsumeWS(w, WSChannelOrders, "hung", func(TradeOrder) { <-hang }, WithSubscriptionBuffer(1)); err != nil {
+		t.Fatalf("ConsumeWS() error = %v", err)
+	}
+	if _, err := ConsumeWS(w, WSChannelOrders, "hung-block", func(TradeOrder) { <-hang },
+		WithSubscriptionBuffer(1), WithSubscriptionQueueFullPolicy(WSQueueFullBlock)); err != nil {
+		t.Fatalf("ConsumeWS() error = %v", err)
+	}
+	got := make(chan string, 64)
+	if _, err := ConsumeWS(w, WSChannelOrders, "risk", func(o TradeOrder) { got <- o.OrdId }); err != nil {
+		t.Fatalf("ConsumeWS() error = %v", err)
+	}
+	handled := make(chan string, 64)
+	w.OnOrders(func(o TradeOrder) { handled <- o.OrdId })
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 32; i++ {
+			w.onDataMessage([]byte(`{"arg":{"channel":"orders","instType":"ANY"},"data":[{"instId":"BTC-USDT","ordId":"` + strconv.Itoa(i) + `","state":"live"}]}`))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("publish blocked by a hung consumer")
+	}
+	for i := 0; i < 32; i++ {
+		want := strconv.Itoa(i)
+		select {
+		case v := <-got:
+			if v != want {
+				t.Fatalf("risk got %s, want %s", v, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("risk consumer stalled at %s", want)
+		}
+		if v := <-handled; v != want {
+			t.Fatalf("OnOrders got %s, want %s", v, want)
+		}
+	}
+}
+
+func TestConsumeWS_DisconnectPolicyDetachesOnlyThatConsumer(t *testing.T) {
+	w := &WSClient{}
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+
+	started := make(chan struct{}, 1)
+	slow, err := ConsumeWS(w, WSChannelPositions, "slow", func(AccountPosition) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-block
+	}, WithSubscriptionBuffer(1), WithSubscriptionQueueFullPolicy(WSQueueFullDisconnect))
+	if err != nil {
+		t.Fatalf("ConsumeWS() error = %v", err)
+	}
+	got := make(chan string, 16)
+	if _, err := ConsumeWS(w, WSChannelPositions, "fast", func(p AccountPosition) { got <- p.PosId }); err != nil {
+		t.Fatalf("ConsumeWS() error = %v", err)
+	}
+
+	push := func(posId string) {
+		w.onDataMessage([]byte(`{"arg":{"channel":"positions","instType":"ANY"},"data":[{"instId":"BTC-USDT-SWAP","posId":"` + posId + `"}]}`))
+	}
+	push("1")
+	<-started
+	push("2")
+	push("3") // slow 队列已满：摘除 slow
+
+	select {
+	case <-slow.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("slow consumer not detached")
+	}
+	st := w.ConsumerStats()
+	if len(st) != 1 || st[0].Name != "fast" {
+		t.Fatalf("stats = %#v", st)
+	}
+	for _, want := range []string{"1", "2", "3"} {
+		if v := <-got; v != want {
+			t.Fatalf("got %s, want %s", v, want)
+		}
+	}
+	slow.Close() // 幂等
+}
+
+func TestConsumeWS_InvalidArgs(t *testing.T) {
+	w := &WSClient{}
+	if _, err := ConsumeWS(w, WSChannelTickers, "x", func(MarketTicker) {}); err == nil {
+		t.Fatalf("expected unsupported channel error")
+	}
+	if _, err := ConsumeWS(w, WSChannelOrders, "x", func(WSFill) {}); err == nil {
+		t.Fatalf("expected type mismatch error")
+	}
+	if _, err := ConsumeWS[TradeOrder](w, WSChannelOrders, "x", nil); err == nil {
+		t.Fatalf("expected nil handler error")
+	}
+}
@@ -0,0 +1,160 @@
+package okx
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// WSDispatchClass 表示 typed handler 分发队列的频道类别（WithWSPriorityDispatch）。
+type WSDispatchClass uint8
+
+const (
+	// WSDispatchTrading 为交易事件：orders/fills/orders-algo/algo-advance/grid-*/algo-recurring-buy/copytrading-lead-notification/
+	// rfqs/quotes/struc-block-trades/sprd-orders/sprd-trades，以及业务 op 回包。
+	WSDispatchTrading WSDispatchClass = iota
+	// WSDispatchAccount 为账户事件：account/positions/balance_and_position/liquidation-warning/account-greeks/
+	// deposit-info/withdrawal-info/adl-warning。
+	WSDispatchAccount
+	// WSDispatchMarketData 为行情及其余事件（含 OnWSChannel 自定义频道与 SBE）。
+	WSDispatchMarketData
+
+	wsDispatchClassCount = int(WSDispatchMarketData) + 1
+)
+
+func (c WSDispatchClass) String() string {
+	switch c {
+	case WSDispatchTrading:
+		return "trading"
+	case WSDispatchAccount:
+		return "account"
+	case WSDispatchMarketData:
+		return "market-data"
+	default:
+		return "unknown"
+	}
+}
+
+// WSDispatchQueueConfig 为单个类别队列的配置。
+type WSDispatchQueueConfig struct {
+	// Buffer 为队列长度（<=0 时默认 1024）。
+	Buffer int
+	// Policy 为队列满时的策略（零值为 WSQueueFullBlock）。
+	Policy WSQueueFullPolicy
+}
+
+// WSDispatchQueueStats 为单个类别队列的运行状态。
+type WSDispatchQueueStats struct {
+	Class    WSDispatchClass
+	Policy   WSQueueFullPolicy
+	QueueLen int
+	QueueCap int
+	Dropped  uint64
+}
+
+// WithWSPriorityDispatch 将 typed handler 按频道类别拆分为独立队列（交易 > 账户 > 行情），各自有 buffer、队列满策略与 worker。
+//
+// 未在 queues 中出现的类别使用 buffer=1024 与 WithWSQueueFullPolicy 设置的策略（默认 block）。
+//
+// 注意：
+// - 各类别在独立 worker goroutine 中执行：同一类别内的回调仍按到达顺序串行，不同类别的回调可能并发；
+// - 队列满且策略为 block 时会阻塞 WS read goroutine（进而拖慢所有类别）：行情队列建议用 drop 或 disconnect，交易/账户队列保持 block；
+// - 仅在异步 typed 分发下生效（WithWSTypedHandlerInline 时忽略）。
+func WithWSPriorityDispatch(queues map[WSDispatchClass]WSDispatchQueueConfig) WSOption {
+	return func(c *WSClient) {
+		c.dispatchQueues = make([]*wsDispatchQueue, wsDispatchClassCount)
+		for i := range c.dispatchQueues {
+			class := WSDispatchClass(i)
+			q := &wsDispatchQueue{class: class, buffer: 1024, inheritPolicy: true}
+			if cfg, ok := queues[class]; ok {
+				if cfg.Buffer > 0 {
+					q.buffer = cfg.Buffer
+				}
+				q.policy = cfg.Policy
+				q.inheritPolicy = false
+			}
+			c.dispatchQueues[i] = q
+		}
+	}
+}
+
+type wsDispatchQueue struct {
+	class  WSDispatchClass
+	buffer int
+	policy WSQueueFullPolicy
+	// inheritPolicy 表示未单独配置，Start 时沿用 WithWSQueueFullPolicy 的策略。
+	inheritPolicy bool
+
+	ch      chan wsTypedTask
+	dropped atomic.Uint64
+	warnAt  atomic.Int64
+}
+
+// wsTypedKindClass 返回 typed 任务所属的分发类别。
+func wsTypedKindClass(kind wsTypedKind) WSDispatchClass {
+	switch kind {
+	case wsTypedKindOrders, wsTypedKindFills, wsTypedKindOrdersAlgo, wsTypedKindAlgoAdvance,
+		wsTypedKindGridOrdersSpot, wsTypedKindGridOrdersContract, wsTypedKindGridPositions, wsTypedKindGridSubOrders,
+		wsTypedKindAlgoRecurringBuy, wsTypedKindCopyTradingLeadNotification,
+		wsTypedKindRFQs, wsTypedKindQuotes, wsTypedKindStrucBlockTrades,
+		wsTypedKindSprdOrders, wsTypedKindSprdTrades, wsTypedKindOpReply:
+		return WSDispatchTrading
+	case wsTypedKindAccount, wsTypedKindPositions, wsTypedKindBalanceAndPosition, wsTypedKindLiquidationWarning,
+		wsTypedKindAccountGreeks, wsTypedKindDepositInfo, wsTypedKindWithdrawalInfo, wsTypedKindADLWarning:
+		return WSDispatchAccount
+	default:
+		return WSDispatchMarketData
+	}
+}
+
+// startDispatchQueues 创建各类别队列并启动 worker（Start 时调用）。
+func (w *WSClient) startDispatchQueues(ctx context.Context) {
+	for _, q := range w.dispatchQueues {
+		if q.ch != nil {
+			continue
+		}
+		if q.inheritPolicy {
+			q.policy = w.typedQueueFullPolicy
+		}
+		q.ch = make(chan wsTypedTask, q.buffer)
+		go w.dispatchQueueLoop(ctx, q)
+	}
+}
+
+func (w *WSClient) dispatchQueueLoop(ctx context.Context, q *wsDispatchQueue) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-q.ch:
+			w.handleTyped(task)
+		}
+	}
+}
+
+// dispatchQueueFor 返回任务所属的类别队列（未启用或未 Start 时返回 nil）。
+func (w *WSClient) dispatchQueueFor(kind wsTypedKind) *wsDispatchQueue {
+	if w.dispatchQueues == nil {
+		return nil
+	}
+	q := w.dispatchQueues[wsTypedKindClass(kind)]
+	if q.ch == nil {
+		return nil
+	}
+	return q
+}
+
+func (w *WSClient) dispatchQueueStats() []WSDispatchQueueStats {
+	if w.dispatchQueues == nil {
+		return nil
+	}
+	out := make([]WSDispatchQueueStats, 0, len(w.dispatchQueues))
+	for _, q := range w.dispatchQueues {
+		s := WSDispatchQueueStats{Class: q.class, Policy: q.policy, Dropped: q.dropped.Load()}
+		if q.ch != nil {
+			s.QueueLen = len(q.ch)
+			s.QueueCap = cap(q.ch)
+		}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWSTypedKindClass(t *testing.T) {
+	cases := map[wsTypedKind]WSDispatchClass{
+		wsTypedKindOrders:      WSDispatchTrading,
+		wsTypedKindOrdersAlgo:  WSDispatchTrading,
+		wsTypedKindOpReply:     WSDispatchTrading,
+		wsTypedKindPositions:   WSDispatchAccount,
+		wsTypedKindDepositInfo: WSDispatchAccount,
+		wsTypedKindCandles:     WSDispatchMarketData,
+		wsTypedKindOrderBook:   WSDispatchMarketData,
+		wsTypedKindChannel:     WSDispatchMarketData,
+	}
+	for kind, want := range cases {
+		if got := wsTypedKindClass(kind); got != want {
+			t.Fatalf("%s: class = %s, want %s", kind, got, want)
+		}
+	}
+}
+
+func TestWSClient_PriorityDispatch_TradingNotBehindMarketData(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	errCh := make(chan error, 8)
+	w := NewClient().NewWSBusiness(
+		WithWSQueueFullPolicy(WSQueueFullDisconnect),
+		WithWSPriorityDispatch(map[WSDispatchClass]WSDispatchQueueConfig{
+			WSDispatchMarketData: {Buffer: 2, Policy: WSQueueFullDrop},
+			WSDispatchTrading:    {Buffer: 4},
+		}),
+	)
+	w.ctxDone = ctx.Done()
+	w.errHandler = func(err error) {
+		select {
+		case errCh <- err:
+		default:
+		}
+	}
+
+	release := make(chan struct{})
+	candleStarted := make(chan struct{}, 1)
+	w.OnCandles(func(WSCandle) {
+		select {
+		case candleStarted <- struct{}{}:
+		default:
+		}
+		<-release
+	})
+	algoCh := make(chan string, 1)
+	w.OnOrdersAlgo(func(o TradeAlgoOrder) { algoCh <- o.AlgoId })
+
+	// 未 Start：同步执行。
+	if q := w.dispatchQueueFor(wsTypedKindCandles); q != nil {
+		t.Fatalf("queue before start = %#v", q)
+	}
+	w.startDispatchQueues(ctx)
+
+	candle := wsTypedTask{kind: wsTypedKindCandles, candles: []WSCandle{{}}}
+	w.dispatchTyped(candle)
+	<-candleStarted
+	// 行情 worker 阻塞中：填满行情队列后继续推送，按 drop 策略丢弃而不阻塞。
+	for i := 0; i < 5; i++ {
+		w.dispatchTyped(candle)
+	}
+
+	w.dispatchTyped(wsTypedTask{kind: wsTypedKindOrdersAlgo, ordersAlgo: []TradeAlgoOrder{{AlgoId: "a1"}}})
+	select {
+	case id := <-algoCh:
+		if id != "a1" {
+			t.Fatalf("algoId = %q", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("trading event waited behind market data")
+	}
+
+	var qfe *WSQueueFullError
+	select {
+	case err := <-errCh:
+		if !errors.As(err, &qfe) || qfe.Queue != "typed:market-data" || qfe.Policy != WSQueueFullDrop {
+			t.Fatalf("error = %v", err)
+		}
+	default:
+		t.Fatalf("expected queue full warning")
+	}
+
+	st := w.Stats()
+	if len(st.DispatchQueues) != 3 {
+		t.Fatalf("DispatchQueues = %#v", st.DispatchQueues)
+	}
+	trading, account, market := st.DispatchQueues[WSDispatchTrading], st.DispatchQueues[WSDispatchAccount], st.DispatchQueues[WSDispatchMarketData]
+	if trading.QueueCap != 4 || trading.Policy != WSQueueFullBlock {
+		t.Fatalf("trading = %#v", trading)
+	}
+	// 未单独配置的类别沿用 WithWSQueueFullPolicy。
+	if account.QueueCap != 1024 || account.Policy != WSQueueFullDisconnect {
+		t.Fatalf("account = %#v", account)
+	}
+	if market.QueueLen != 2 || market.QueueCap != 2 || market.Dropped != 3 {
+		t.Fatalf("market = %#v", market)
+	}
+	if st.TypedDropped != 3 || st.TypedQueueCap != 1030 {
+		t.Fatalf("typed totals: dropped=%d cap=%d", st.TypedDropped, st.TypedQueueCap)
+	}
+	close(release)
+}
@@ -0,0 +1,554 @@
+package okx
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"sort"
+	"sync"
+)
+
+// wsOrderBookChecksumDepth 为 OKX checksum 覆盖的每侧档位数。
+const wsOrderBookChecksumDepth = 25
+
+// wsFastOrderBookMaxScale 为定点价格的最大小数位数。
+const wsFastOrderBookMaxScale = 18
+
+// WSFastOrderBook 是面向高频深度频道（如 books-l2-tbt）的本地深度实现，语义与 WSOrderBookStore 一致：
+// 合并 snapshot/update，校验 seqId/prevSeqId 与 checksum，错误类型相同。
+//
+// 与 WSOrderBookStore 的差异：
+// - 价格解析为定点整数（按已见最大小数位缩放），档位以有序数组维护，按整数二分查找；
+// - 增量更新原地插入/删除（容量足够时不分配内存）；
+// - checksum 仅在前 25 档变化时重算，并复用内部缓冲区；
+// - 读取提供 BestBid/BestAsk/Depth（可复用调用方缓冲区），避免每次深拷贝整本深度。
+//
+// 并发：该结构体并发安全；Apply/Reset 与读取方法可并发调用。
+type WSFastOrderBook struct {
+	mu sync.RWMutex
+
+	channel string
+	instId  string
+	sprdId  string
+
+	verifySequence bool
+	verifyChecksum bool
+
+	ready bool
+	scale int
+
+	// bids 按价格降序、asks 按价格升序。
+	bids []wsFastLevel
+	asks []wsFastLevel
+
+	ts       int64
+	seqId    int64
+	checksum int64
+
+	checksumDirty bool
+	crcBuf        []byte
+}
+
+type wsFastLevel struct {
+	px    int64
+	level OrderBookLevel
+}
+
+// NewWSFastOrderBook 创建用于指定频道/产品的高性能本地深度（opts 与 WSOrderBookStore 通用）。
+func NewWSFastOrderBook(channel, instId string, opts ...WSOrderBookStoreOption) *WSFastOrderBook {
+	cfg := WSOrderBookStore{verifySequence: true, verifyChecksum: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &WSFastOrderBook{
+		channel:        channel,
+		instId:         instId,
+		verifySequence: cfg.verifySequence,
+		verifyChecksum: cfg.verifyChecksum,
+	}
+}
+
+// NewWSSprdFastOrderBook 创建用于指定频道/Spread 的高性能本地深度。
+func NewWSSprdFastOrderBook(channel, sprdId string, opts ...WSOrderBookStoreOption) *WSFastOrderBook {
+	b := NewWSFastOrderBook(channel, "", opts...)
+	b.sprdId = sprdId
+	return b
+}
+
+// Ready 表示是否已接收并应用过至少一条 snapshot（或 books5/bbo-tbt 的任意推送）。
+func (b *WSFastOrderBook) Ready() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.ready
+}
+
+// Reset 清空本地状态。
+func (b *WSFastOrderBook) Reset() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.resetLocked()
+}
+
+// SeqId 返回最近一次应用的 seqId。
+func (b *WSFastOrderBook) SeqId() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.seqId
+}
+
+// BestBid 返回最优买价档位；ok=false 表示买盘为空。
+func (b *WSFastOrderBook) BestBid() (level OrderBookLevel, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.bids) == 0 {
+		return OrderBookLevel{}, false
+	}
+	return b.bids[0].level, true
+}
+
+// BestAsk 返回最优卖价档位；ok=false 表示卖盘为空。
+func (b *WSFastOrderBook) BestAsk() (level OrderBookLevel, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.asks) == 0 {
+		return OrderBookLevel{}, false
+	}
+	return b.asks[0].level, true
+}
+
+// Depth 将前 n 档（n<=0 表示全部）买/卖盘追加到 bids/asks 并返回（传入可复用的切片以避免分配）。
+func (b *WSFastOrderBook) Depth(n int, bids, asks []OrderBookLevel) ([]OrderBookLevel, []OrderBookLevel) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.depthLocked(n, bids, asks)
+}
+
+func (b *WSFastOrderBook) depthLocked(n int, bids, asks []OrderBookLevel) ([]OrderBookLevel, []OrderBookLevel) {
+	for i, l := range b.bids {
+		if n > 0 && i >= n {
+			break
+		}
+		bids = append(bids, l.level)
+	}
+	for i, l := range b.asks {
+		if n > 0 && i >= n {
+			break
+		}
+		asks = append(asks, l.level)
+	}
+	return bids, asks
+}
+
+// Snapshot 返回当前深度快照（深拷贝全部档位；高频读取建议使用 BestBid/BestAsk/Depth）。
+func (b *WSFastOrderBook) Snapshot() WSOrderBookSnapshot {
+	if b == nil {
+		return WSOrderBookSnapshot{}
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := WSOrderBookSnapshot{
+		Channel:  b.channel,
+		InstId:   b.instId,
+		SprdId:   b.sprdId,
+		TS:       b.ts,
+		SeqId:    b.seqId,
+		Checksum: b.checksum,
+	}
+	if len(b.bids) > 0 || len(b.asks) > 0 {
+		out.Bids, out.Asks = b.depthLocked(0, make([]OrderBookLevel, 0, len(b.bids)), make([]OrderBookLevel, 0, len(b.asks)))
+	}
+	return out
+}
+
+// ApplyMessage 尝试解析并应用一条 WS 原始消息；ok=false 表示不是当前深度关心的消息。
+func (b *WSFastOrderBook) ApplyMessage(message []byte) (ok bool, err error) {
+	dm, ok, err := WSParseOrderBook(message)
+	if err != nil || !ok {
+		return ok, err
+	}
+	if b == nil {
+		return true, errors.New("okx: nil ws fast order book")
+	}
+
+	b.mu.RLock()
+	channel, instId, sprdId := b.channel, b.instId, b.sprdId
+	b.mu.RUnlock()
+
+	if channel != "" && dm.Arg.Channel != channel {
+		return false, nil
+	}
+	if instId != "" && dm.Arg.InstId != "" && dm.Arg.InstId != instId {
+		return false, nil
+	}
+	if sprdId != "" && dm.Arg.SprdId != "" && dm.Arg.SprdId != sprdId {
+		return false, nil
+	}
+	return true, b.Apply(dm)
+}
+
+// Apply 应用一条已解析的深度推送（通常来自 WSParseOrderBook）。
+func (b *WSFastOrderBook) Apply(dm *WSData[WSOrderBook]) error {
+	if b == nil {
+		return errors.New("okx: nil ws fast order book")
+	}
+	if dm == nil {
+		return errors.New("okx: nil ws order book data")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if dm.Arg.Channel == "" {
+		return errors.New("okx: ws order book missing channel")
+	}
+	if b.channel != "" && dm.Arg.Channel != b.channel {
+		return &WSOrderBookChannelMismatchError{Got: dm.Arg.Channel, Want: b.channel}
+	}
+	if b.channel == "" {
+		b.channel = dm.Arg.Channel
+	}
+	if b.instId != "" && dm.Arg.InstId != "" && dm.Arg.InstId != b.instId {
+		return &WSOrderBookInstIdMismatchError{Channel: dm.Arg.Channel, Got: dm.Arg.InstId, Want: b.instId}
+	}
+	if b.instId == "" {
+		b.instId = dm.Arg.InstId
+	}
+	if b.sprdId != "" && dm.Arg.SprdId != "" && dm.Arg.SprdId != b.sprdId {
+		return &WSOrderBookSprdIdMismatchError{Channel: dm.Arg.Channel, Got: dm.Arg.SprdId, Want: b.sprdId}
+	}
+	if b.sprdId == "" {
+		b.sprdId = dm.Arg.SprdId
+	}
+	if !isOrderBookChannel(dm.Arg.Channel) {
+		return fmt.Errorf("okx: ws order book invalid channel %q", dm.Arg.Channel)
+	}
+	if len(dm.Data) != 1 {
+		if len(dm.Data) == 0 {
+			return errors.New("okx: ws order book empty data")
+		}
+		return fmt.Errorf("okx: ws order book expect 1 data item, got %d", len(dm.Data))
+	}
+
+	action := dm.Action
+	if action == "" {
+		if b.ready {
+			action = "update"
+		} else {
+			action = "snapshot"
+		}
+	}
+	upd := &dm.Data[0]
+
+	if isOrderBookFullRefreshChannel(dm.Arg.Channel) || action == "snapshot" {
+		if err := b.applySnapshot(upd); err != nil {
+			b.resetLocked()
+			return err
+		}
+		b.ready = true
+		return nil
+	}
+	if action != "update" {
+		return fmt.Errorf("okx: ws order book unknown action %q", action)
+	}
+	if !b.ready {
+		return &WSOrderBookNotReadyError{Channel: dm.Arg.Channel, InstId: b.instId, SprdId: b.sprdId}
+	}
+	if err := b.applyUpdate(dm.Arg.Channel, upd); err != nil {
+		b.resetLocked()
+		return err
+	}
+	return nil
+}
+
+func (b *WSFastOrderBook) resetLocked() {
+	b.ready = false
+	b.bids = b.bids[:0]
+	b.asks = b.asks[:0]
+	b.ts = 0
+	b.seqId = 0
+	b.checksum = 0
+	b.checksumDirty = false
+}
+
+func (b *WSFastOrderBook) applySnapshot(upd *WSOrderBook) error {
+	if err := b.ensureScale(upd.Bids, upd.Asks); err != nil {
+		return err
+	}
+
+	b.bids = b.bids[:0]
+	b.asks = b.asks[:0]
+	for _, l := range upd.Bids {
+		px, ok := parseFixedDecimal(l.Px, b.scale)
+		if !ok {
+			return fmt.Errorf("okx: ws fast order book invalid price %q", l.Px)
+		}
+		b.bids = append(b.bids, wsFastLevel{px: px, level: l})
+	}
+	for _, l := range upd.Asks {
+		px, ok := parseFixedDecimal(l.Px, b.scale)
+		if !ok {
+			return fmt.Errorf("okx: ws fast order book invalid price %q", l.Px)
+		}
+		b.asks = append(b.asks, wsFastLevel{px: px, level: l})
+	}
+	sort.Slice(b.bids, func(i, j int) bool { return b.bids[i].px > b.bids[j].px })
+	sort.Slice(b.asks, func(i, j int) bool { return b.asks[i].px < b.asks[j].px })
+
+	b.ts = upd.TS
+	b.seqId = upd.SeqId
+	b.checksumDirty = true
+	return b.verifyChecksumLocked(b.channel, upd)
+}
+
+func (b *WSFastOrderBook) applyUpdate(channel string, upd *WSOrderBook) error {
+	if b.verifySequence && isOrderBookSequencedChannel(channel) {
+		// seq 相关字段缺失时，均为默认 0；此时跳过校验以避免误报。
+		if !(b.seqId == 0 && upd.PrevSeqId == 0 && upd.SeqId == 0) && upd.PrevSeqId != b.seqId {
+			return &WSOrderBookSequenceError{
+				Channel:           channel,
+				InstId:            b.instId,
+				SprdId:            b.sprdId,
+				ExpectedPrevSeqId: b.seqId,
+				GotPrevSeqId:      upd.PrevSeqId,
+				SeqId:             upd.SeqId,
+			}
+		}
+	}
+	if err := b.ensureScale(upd.Bids, upd.Asks); err != nil {
+		return err
+	}
+
+	var (
+		dirty bool
+		err   error
+	)
+	b.bids, dirty, err = applyFastOrderBookDelta(b.bids, upd.Bids, b.scale, true)
+	b.checksumDirty = b.checksumDirty || dirty
+	if err != nil {
+		return err
+	}
+	b.asks, dirty, err = applyFastOrderBookDelta(b.asks, upd.Asks, b.scale, false)
+	b.checksumDirty = b.checksumDirty || dirty
+	if err != nil {
+		return err
+	}
+
+	b.ts = upd.TS
+	b.seqId = upd.SeqId
+	return b.verifyChecksumLocked(channel, upd)
+}
+
+// ensureScale 确保定点缩放位数覆盖 levels 中所有价格的小数位；必要时按原始字符串重建已有档位的定点价格。
+func (b *WSFastOrderBook) ensureScale(bids, asks []OrderBookLevel) error {
+	need := b.scale
+	for _, l := range bids {
+		need = max(need, decimalPlaces(l.Px))
+	}
+	for _, l := range asks {
+		need = max(need, decimalPlaces(l.Px))
+	}
+	if need == b.scale {
+		return nil
+	}
+	if need > wsFastOrderBookMaxScale {
+		return fmt.Errorf("okx: ws fast order book price scale %d exceeds %d", need, wsFastOrderBookMaxScale)
+	}
+
+	for _, side := range [][]wsFastLevel{b.bids, b.asks} {
+		for i := range side {
+			px, ok := parseFixedDecimal(side[i].level.Px, need)
+			if !ok {
+				return fmt.Errorf("okx: ws fast order book invalid price %q", side[i].level.Px)
+			}
+			side[i].px = px
+		}
+	}
+	b.scale = need
+	return nil
+}
+
+func (b *WSFastOrderBook) verifyChecksumLocked(channel string, upd *WSOrderBook) error {
+	if !b.verifyChecksum {
+		b.checksum = upd.Checksum
+		return nil
+	}
+	if b.checksumDirty {
+		b.checksum = b.computeChecksumLocked()
+		b.checksumDirty = false
+	}
+	if b.checksum != upd.Checksum {
+		return &WSOrderBookChecksumError{
+			Channel:   channel,
+			InstId:    b.instId,
+			SprdId:    b.sprdId,
+			Expected:  b.checksum,
+			Got:       upd.Checksum,
+			SeqId:     upd.SeqId,
+			ChecksumS: string(b.checksumBytesLocked()),
+		}
+	}
+	return nil
+}
+
+func (b *WSFastOrderBook) computeChecksumLocked() int64 {
+	return int64(int32(crc32.ChecksumIEEE(b.checksumBytesLocked())))
+}
+
+// checksumBytesLocked 按 OKX 规则（前 25 档 bid:ask 交替）构造 checksum 字符串，复用内部缓冲区。
+func (b *WSFastOrderBook) checksumBytesLocked() []byte {
+	nb := min(wsOrderBookChecksumDepth, len(b.bids))
+	na := min(wsOrderBookChecksumDepth, len(b.asks))
+	buf := b.crcBuf[:0]
+	for i := 0; i < nb || i < na; i++ {
+		if i < nb {
+			if len(buf) > 0 {
+				buf = append(buf, ':')
+			}
+			buf = append(buf, b.bids[i].level.Px...)
+			buf = append(buf, ':')
+			buf = append(buf, b.bids[i].level.Sz...)
+		}
+		if i < na {
+			if len(buf) > 0 {
+				buf = append(buf, ':')
+			}
+			buf = append(buf, b.asks[i].level.Px...)
+			buf = append(buf, ':')
+			buf = append(buf, b.asks[i].level.Sz...)
+		}
+	}
+	b.crcBuf = buf
+	return buf
+}
+
+// applyFastOrderBookDelta 原地合并增量档位；dirty=true 表示 checksum 覆盖的前 25 档发生变化。
+func applyFastOrderBookDelta(levels []wsFastLevel, updates []OrderBookLevel, scale int, bids bool) ([]wsFastLevel, bool, error) {
+	dirty := false
+	for _, u := range updates {
+		px, ok := parseFixedDecimal(u.Px, scale)
+		if !ok {
+			return levels, dirty, fmt.Errorf("okx: ws fast order book invalid price %q", u.Px)
+		}
+		idx := searchFastOrderBookIndex(levels, px, bids)
+		found := idx < len(levels) && levels[idx].px == px
+		if idx < wsOrderBookChecksumDepth && (found || !isZeroDecimal(u.Sz)) {
+			dirty = true
+		}
+		switch {
+		case found && isZeroDecimal(u.Sz):
+			copy(levels[idx:], levels[idx+1:])
+			levels = levels[:len(levels)-1]
+		case found:
+			levels[idx].level = u
+		case isZeroDecimal(u.Sz):
+			// 删除不存在的价位：忽略。
+		default:
+			levels = append(levels, wsFastLevel{})
+			copy(levels[idx+1:], levels[idx:])
+			levels[idx] = wsFastLevel{px: px, level: u}
+		}
+	}
+	return levels, dirty, nil
+}
+
+func searchFastOrderBookIndex(levels []wsFastLevel, px int64, bids bool) int {
+	lo, hi := 0, len(levels)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		var before bool
+		if bids {
+			before = levels[mid].px > px
+		} else {
+			before = levels[mid].px < px
+		}
+		if before {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+func isZeroDecimal(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '0' && s[i] != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// decimalPlaces 返回去除末尾 0 后的小数位数。
+func decimalPlaces(s string) int {
+	dot := -1
+	for i := 0; i < len(s); i++ {
+		if s[i] == '.' {
+			dot = i
+			break
+		}
+	}
+	if dot < 0 {
+		return 0
+	}
+	end := len(s)
+	for end > dot+1 && s[end-1] == '0' {
+		end--
+	}
+	return end - dot - 1
+}
+
+// parseFixedDecimal 将非负十进制字符串解析为按 10^scale 缩放的整数；小数位超过 scale、格式错误或溢出时 ok=false。
+func parseFixedDecimal(s string, scale int) (int64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	var v int64
+	frac := -1
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '.' {
+			if frac >= 0 {
+				return 0, false
+			}
+			frac = 0
+			continue
+		}
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		if frac >= 0 {
+			if frac == scale {
+				if c != '0' {
+					return 0, false
+				}
+				continue
+			}
+			frac++
+		}
+		if v > (math.MaxInt64-int64(c-'0'))/10 {
+			return 0, false
+		}
+		v = v*10 + int64(c-'0')
+	}
+	if frac < 0 {
+		frac = 0
+	}
+	for ; frac < scale; frac++ {
+		if v > math.MaxInt64/10 {
+			return 0, false
+		}
+		v *= 10
+	}
+	return v, true
+}
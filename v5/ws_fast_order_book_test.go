@@ -0,0 +1,239 @@
+package okx
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestParseFixedDecimal(t *testing.T) {
+	cases := []struct {
+		s     string
+		scale int
+		want  int64
+		ok    bool
+	}{
+		{"100", 2, 10000, true},
+		{"100.5", 2, 10050, true},
+		{"100.50", 1, 1005, true},
+		{"0.0001", 4, 1, true},
+		{"100.55", 1, 0, false},
+		{"1.2.3", 2, 0, false},
+		{"abc", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := parseFixedDecimal(tc.s, tc.scale)
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("parseFixedDecimal(%q, %d) = %d,%v want %d,%v", tc.s, tc.scale, got, ok, tc.want, tc.ok)
+		}
+	}
+	if decimalPlaces("100.5000") != 1 || decimalPlaces("100") != 0 {
+		t.Fatalf("decimalPlaces mismatch")
+	}
+}
+
+// wsOrderBookTestStream 生成 books-l2-tbt 推送序列（snapshot + updates），checksum 由 WSOrderBookStore 计算。
+func wsOrderBookTestStream(levels, updates int, seed int64) []*WSData[WSOrderBook] {
+	r := rand.New(rand.NewSource(seed))
+	px := func(ticks int) string {
+		return strconv.FormatFloat(float64(ticks)/10, 'f', -1, 64)
+	}
+	sz := func() string {
+		return strconv.Itoa(1 + r.Intn(50))
+	}
+
+	arg := WSArg{Channel: WSChannelBooksL2Tbt, InstId: "BTC-USDT"}
+	snap := WSOrderBook{SeqId: 1}
+	for i := 0; i < levels; i++ {
+		snap.Bids = append(snap.Bids, OrderBookLevel{Px: px(1000000 - 1 - i), Sz: sz()})
+		snap.Asks = append(snap.Asks, OrderBookLevel{Px: px(1000000 + i), Sz: sz()})
+	}
+
+	ref := NewWSOrderBookStore(WSChannelBooksL2Tbt, "BTC-USDT", WithWSOrderBookVerifyChecksum(false))
+	out := []*WSData[WSOrderBook]{{Arg: arg, Action: "snapshot", Data: []WSOrderBook{snap}}}
+	_ = ref.Apply(out[0])
+	withChecksum := func(dm *WSData[WSOrderBook]) {
+		s := ref.Snapshot()
+		dm.Data[0].Checksum = wsOrderBookChecksum(s.Bids, s.Asks)
+	}
+	withChecksum(out[0])
+
+	seq := int64(1)
+	for i := 0; i < updates; i++ {
+		upd := WSOrderBook{PrevSeqId: seq, SeqId: seq + 1}
+		seq++
+		for k := 0; k < 1+r.Intn(4); k++ {
+			off := r.Intn(levels + 20)
+			l := OrderBookLevel{Sz: sz()}
+			if r.Intn(4) == 0 {
+				l.Sz = "0"
+			}
+			if r.Intn(2) == 0 {
+				l.Px = px(1000000 - 1 - off)
+				upd.Bids = append(upd.Bids, l)
+			} else {
+				l.Px = px(1000000 + off)
+				upd.Asks = append(upd.Asks, l)
+			}
+		}
+		dm := &WSData[WSOrderBook]{Arg: arg, Action: "update", Data: []WSOrderBook{upd}}
+		_ = ref.Apply(dm)
+		withChecksum(dm)
+		out = append(out, dm)
+	}
+	return out
+}
+
+func TestWSFastOrderBook_MatchesStore(t *testing.T) {
+	stream := wsOrderBookTestStream(50, 2000, 1)
+	store := NewWSOrderBookStore(WSChannelBooksL2Tbt, "BTC-USDT")
+	fast := NewWSFastOrderBook(WSChannelBooksL2Tbt, "BTC-USDT")
+
+	var bids, asks []OrderBookLevel
+	for i, dm := range stream {
+		if err := store.Apply(dm); err != nil {
+			t.Fatalf("store.Apply(%d) error = %v", i, err)
+		}
+		if err := fast.Apply(dm); err != nil {
+			t.Fatalf("fast.Apply(%d) error = %v", i, err)
+		}
+		want := store.Snapshot()
+		bids, asks = fast.Depth(0, bids[:0], asks[:0])
+		if fmt.Sprint(bids) != fmt.Sprint(want.Bids) || fmt.Sprint(asks) != fmt.Sprint(want.Asks) {
+			t.Fatalf("step %d: book mismatch", i)
+		}
+		got := fast.Snapshot()
+		if got.SeqId != want.SeqId || got.Checksum != want.Checksum {
+			t.Fatalf("step %d: snapshot = %d/%d want %d/%d", i, got.SeqId, got.Checksum, want.SeqId, want.Checksum)
+		}
+	}
+
+	bb, _ := fast.BestBid()
+	ba, _ := fast.BestAsk()
+	top1b, top1a := fast.Depth(1, nil, nil)
+	if len(top1b) != 1 || top1b[0] != bb || len(top1a) != 1 || top1a[0] != ba {
+		t.Fatalf("top of book mismatch")
+	}
+}
+
+func TestWSFastOrderBook_Errors(t *testing.T) {
+	stream := wsOrderBookTestStream(10, 3, 2)
+	fast := NewWSFastOrderBook(WSChannelBooksL2Tbt, "BTC-USDT")
+
+	var notReady *WSOrderBookNotReadyError
+	if err := fast.Apply(stream[1]); !errors.As(err, &notReady) {
+		t.Fatalf("err = %v, want not ready", err)
+	}
+	if err := fast.Apply(stream[0]); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	var seqErr *WSOrderBookSequenceError
+	if err := fast.Apply(stream[2]); !errors.As(err, &seqErr) || fast.Ready() {
+		t.Fatalf("err = %v ready=%v, want sequence error and reset", err, fast.Ready())
+	}
+
+	_ = fast.Apply(stream[0])
+	bad := *stream[1]
+	bad.Data = []WSOrderBook{stream[1].Data[0]}
+	bad.Data[0].Checksum++
+	var csErr *WSOrderBookChecksumError
+	if err := fast.Apply(&bad); !errors.As(err, &csErr) || fast.Ready() {
+		t.Fatalf("err = %v, want checksum error", err)
+	}
+}
+
+func TestWSFastOrderBook_ScaleGrows(t *testing.T) {
+	fast := NewWSFastOrderBook(WSChannelBooks, "X", WithWSOrderBookVerifyChecksum(false))
+	arg := WSArg{Channel: WSChannelBooks, InstId: "X"}
+	_ = fast.Apply(&WSData[WSOrderBook]{Arg: arg, Action: "snapshot", Data: []WSOrderBook{{
+		Bids: []OrderBookLevel{{Px: "10", Sz: "1"}, {Px: "9", Sz: "1"}},
+		Asks: []OrderBookLevel{{Px: "11", Sz: "1"}},
+	}}})
+	if err := fast.Apply(&WSData[WSOrderBook]{Arg: arg, Action: "update", Data: []WSOrderBook{{
+		Bids: []OrderBookLevel{{Px: "9.55", Sz: "2"}},
+	}}}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	bids, _ := fast.Depth(0, nil, nil)
+	if fmt.Sprint(bids) != fmt.Sprint([]OrderBookLevel{{Px: "10", Sz: "1"}, {Px: "9.55", Sz: "2"}, {Px: "9", Sz: "1"}}) {
+		t.Fatalf("bids = %v", bids)
+	}
+}
+
+func TestWSFastOrderBook_ApplyUpdateAllocFree(t *testing.T) {
+	stream := wsOrderBookTestStream(400, 1, 3)
+	fast := NewWSFastOrderBook(WSChannelBooksL2Tbt, "BTC-USDT", WithWSOrderBookVerifySequence(false))
+	if err := fast.Apply(stream[0]); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	top := stream[0].Data[0].Bids[0]
+	bids, asks := fast.Depth(0, nil, nil)
+	upd := &WSData[WSOrderBook]{Arg: stream[0].Arg, Action: "update", Data: []WSOrderBook{{
+		Bids:     []OrderBookLevel{top},
+		Checksum: wsOrderBookChecksum(bids, asks),
+	}}}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := fast.Apply(upd); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("allocs = %v, want 0", allocs)
+	}
+}
+
+func benchmarkOrderBookStream(b *testing.B, apply func(dm *WSData[WSOrderBook]) error, reset func()) {
+	stream := wsOrderBookTestStream(400, 4096, 4)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := i % (len(stream) - 1)
+		if k == 0 {
+			b.StopTimer()
+			reset()
+			if err := apply(stream[0]); err != nil {
+				b.Fatal(err)
+			}
+			b.StartTimer()
+		}
+		if err := apply(stream[k+1]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWSOrderBookStore_ApplyL2Tbt(b *testing.B) {
+	s := NewWSOrderBookStore(WSChannelBooksL2Tbt, "BTC-USDT")
+	benchmarkOrderBookStream(b, s.Apply, s.Reset)
+}
+
+func BenchmarkWSFastOrderBook_ApplyL2Tbt(b *testing.B) {
+	f := NewWSFastOrderBook(WSChannelBooksL2Tbt, "BTC-USDT")
+	benchmarkOrderBookStream(b, f.Apply, f.Reset)
+}
+
+func BenchmarkWSOrderBookStore_Snapshot(b *testing.B) {
+	s := NewWSOrderBookStore(WSChannelBooksL2Tbt, "BTC-USDT")
+	_ = s.Apply(wsOrderBookTestStream(400, 0, 5)[0])
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = s.Snapshot()
+	}
+}
+
+func BenchmarkWSFastOrderBook_Depth5(b *testing.B) {
+	f := NewWSFastOrderBook(WSChannelBooksL2Tbt, "BTC-USDT")
+	_ = f.Apply(wsOrderBookTestStream(400, 0, 5)[0])
+	bids := make([]OrderBookLevel, 0, 5)
+	asks := make([]OrderBookLevel, 0, 5)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bids, asks = f.Depth(5, bids[:0], asks[:0])
+	}
+}
@@ -0,0 +1,479 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultWSGapFillTimeout  = 30 * time.Second
+	defaultWSGapFillMaxPages = 10
+	defaultWSGapFillMaxSeen  = 10000
+	wsGapFillPageLimit       = 100
+)
+
+// wsGapFillInstTypes 为订阅 instType=ANY 时补偿历史订单需要逐一查询的产品类型。
+var wsGapFillInstTypes = []string{"SPOT", "MARGIN", "SWAP", "FUTURES", "OPTION"}
+
+// WSGapFillConfig 配置私有频道断线补偿（见 WithWSGapFill）。
+type WSGapFillConfig struct {
+	// Timeout 为单次补偿的超时（默认 30s）。
+	Timeout time.Duration
+	// MaxPages 为每类 REST 查询的最大翻页数（每页 100 条；默认 10）。
+	MaxPages int
+	// MaxSeen 为去重记录的最大条目数（订单/成交各自计数，超过后淘汰最早记录；默认 10000）。
+	MaxSeen int
+	// OnDone 为每次补偿完成后的回调（可选；在补偿 goroutine 中执行）。
+	OnDone func(r WSGapFillResult)
+}
+
+// WSGapFillResult 为一次断线补偿的结果。
+type WSGapFillResult struct {
+	// OrdersSince / FillsSince 为本次补偿的起点（毫秒；上次见到的 uTime / 成交 ts，未见过时为首次连接时间）。
+	OrdersSince int64
+	FillsSince  int64
+
+	// Orders / Fills 为补发的条数（已剔除断线前已送达的记录）。
+	Orders int
+	Fills  int
+
+	Err error
+}
+
+// WithWSGapFill 开启私有 WS 的断线补偿（仅 private WS；默认关闭）。
+//
+// 每次断线重连并重订阅成功后，SDK 在后台按上次见到的 uTime / 成交时间，
+// 通过 OrdersPendingService、OrdersHistoryService、TradeFillsService 查询断线期间的订单与成交，
+// 并经同一 OnOrders / OnFills 回调补发（TradeOrder.Recovered / WSFill.Recovered 为 true）；
+// 已送达过的记录（订单按 ordId+uTime，成交按 ordId+tradeId）不会重复补发。
+//
+// 说明：
+// - 仅补偿已设置 OnOrders / OnFills 且已订阅 orders / fills 的部分，按订阅的 instType/instFamily/instId 过滤；
+// - positions 频道在重订阅后会推送全量快照，无需补偿；
+// - 每类查询最多 MaxPages 页，断线期间变更量超过该上限时应以 REST 全量对账为准（见 docs/runbook.md 第 6 节）。
+func WithWSGapFill(cfg WSGapFillConfig) WSOption {
+	return func(c *WSClient) {
+		c.gapFill = newWSGapFiller(cfg)
+	}
+}
+
+type wsGapFiller struct {
+	cfg WSGapFillConfig
+
+	running atomic.Bool
+	// restored 表示已从 Checkpoint 恢复位置：首次连接重订阅后即触发补偿。
+	restored atomic.Bool
+	// startedAt 为首次连接成功时间（毫秒，服务器时钟）。
+	startedAt atomic.Int64
+
+	// mu 串行化 orders/fills 回调（inline 模式下实时推送与补发不并发），并保护以下字段。
+	mu         sync.Mutex
+	lastUTime  int64
+	lastFillTS int64
+	orders     map[string]int64 // ordId -> 已送达的最大 uTime
+	orderKeys  []string
+	fills      map[string]struct{} // ordId|tradeId
+	fillKeys   []string
+}
+
+func newWSGapFiller(cfg WSGapFillConfig) *wsGapFiller {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultWSGapFillTimeout
+	}
+	if cfg.MaxPages <= 0 {
+		cfg.MaxPages = defaultWSGapFillMaxPages
+	}
+	if cfg.MaxSeen <= 0 {
+		cfg.MaxSeen = defaultWSGapFillMaxSeen
+	}
+	return &wsGapFiller{
+		cfg:    cfg,
+		orders: make(map[string]int64),
+		fills:  make(map[string]struct{}),
+	}
+}
+
+func wsGapFillFillKey(ordId, tradeId string) string {
+	return ordId + "|" + tradeId
+}
+
+// seenOrderLocked 判断订单的该状态是否已送达（调用方持有 mu）。
+func (g *wsGapFiller) seenOrderLocked(o TradeOrder) bool {
+	u, ok := g.orders[o.OrdId]
+	return ok && u >= o.UTime
+}
+
+func (g *wsGapFiller) markOrderLocked(o TradeOrder) {
+	if o.UTime > g.lastUTime {
+		g.lastUTime = o.UTime
+	}
+	if o.OrdId == "" {
+		return
+	}
+	u, ok := g.orders[o.OrdId]
+	if !ok {
+		g.orderKeys = append(g.orderKeys, o.OrdId)
+		if len(g.orderKeys) > g.cfg.MaxSeen {
+			delete(g.orders, g.orderKeys[0])
+			g.orderKeys = g.orderKeys[1:]
+		}
+	}
+	if !ok || o.UTime > u {
+		g.orders[o.OrdId] = o.UTime
+	}
+}
+
+func (g *wsGapFiller) seenFillLocked(f WSFill) bool {
+	_, ok := g.fills[wsGapFillFillKey(f.OrdId, f.TradeId)]
+	return ok
+}
+
+func (g *wsGapFiller) markFillLocked(f WSFill) {
+	if ts, err := strconv.ParseInt(f.TS, 10, 64); err == nil && ts > g.lastFillTS {
+		g.lastFillTS = ts
+	}
+	if f.TradeId == "" {
+		return
+	}
+	k := wsGapFillFillKey(f.OrdId, f.TradeId)
+	if _, ok := g.fills[k]; ok {
+		return
+	}
+	g.fills[k] = struct{}{}
+	g.fillKeys = append(g.fillKeys, k)
+	if len(g.fillKeys) > g.cfg.MaxSeen {
+		delete(g.fills, g.fillKeys[0])
+		g.fillKeys = g.fillKeys[1:]
+	}
+}
+
+// deliverOrders 逐条调用回调并记录已送达；补发的记录若已送达则跳过。
+func (g *wsGapFiller) deliverOrders(orders []TradeOrder, recovered bool, call func(TradeOrder)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, o := range orders {
+		if recovered {
+			if g.seenOrderLocked(o) {
+				continue
+			}
+			o.Recovered = true
+		}
+		g.markOrderLocked(o)
+		call(o)
+	}
+}
+
+func (g *wsGapFiller) deliverFills(fills []WSFill, recovered bool, call func(WSFill)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, f := range fills {
+		if recovered {
+			if g.seenFillLocked(f) {
+				continue
+			}
+			f.Recovered = true
+		}
+		g.markFillLocked(f)
+		call(f)
+	}
+}
+
+// markGapFillStart 记录首次连接时间（作为未见过任何推送时的补偿起点）。
+func (w *WSClient) markGapFillStart() {
+	if w.gapFill == nil {
+		return
+	}
+	now := time.Now()
+	if w.c != nil {
+		now = now.Add(-w.c.TimeOffset())
+	}
+	w.gapFill.startedAt.CompareAndSwap(0, now.UnixMilli())
+}
+
+// onResubscribed 在断线重连并重订阅成功后触发后台补偿。
+func (w *WSClient) onResubscribed(ctx context.Context) {
+	g := w.gapFill
+	if g == nil || w.c == nil {
+		return
+	}
+	if w.connects.Load() < 2 && !g.restored.CompareAndSwap(true, false) {
+		return
+	}
+	if !g.running.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer g.running.Store(false)
+		res := w.runGapFill(ctx)
+		if res.Err != nil {
+			w.onError(res.Err)
+		}
+		if g.cfg.OnDone != nil {
+			g.cfg.OnDone(res)
+		}
+	}()
+}
+
+func (w *WSClient) runGapFill(ctx context.Context) WSGapFillResult {
+	g := w.gapFill
+	ctx, cancel := context.WithTimeout(ctx, g.cfg.Timeout)
+	defer cancel()
+
+	w.typedMu.RLock()
+	wantOrders := w.ordersHandler != nil || w.hasConsumers(wsTypedKindOrders)
+	wantFills := w.fillsHandler != nil || w.hasConsumers(wsTypedKindFills)
+	w.typedMu.RUnlock()
+
+	var orderArgs, fillArgs []WSArg
+	for _, a := range w.snapshotDesired() {
+		switch a.Channel {
+		case WSChannelOrders:
+			orderArgs = append(orderArgs, a)
+		case WSChannelFills:
+			fillArgs = append(fillArgs, a)
+		}
+	}
+
+	start := g.startedAt.Load()
+	g.mu.Lock()
+	res := WSGapFillResult{OrdersSince: g.lastUTime, FillsSince: g.lastFillTS}
+	g.mu.Unlock()
+	if res.OrdersSince == 0 {
+		res.OrdersSince = start
+	}
+	if res.FillsSince == 0 {
+		res.FillsSince = start
+	}
+
+	var errs []error
+	if wantOrders && len(orderArgs) > 0 {
+		orders, err := w.gapFillOrders(ctx, orderArgs, res.OrdersSince)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		res.Orders = w.emitRecoveredOrders(orders)
+	}
+	if wantFills && len(fillArgs) > 0 {
+		fills, err := w.gapFillFills(ctx, fillArgs, res.FillsSince)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		res.Fills = w.emitRecoveredFills(fills)
+	}
+	if err := errors.Join(errs...); err != nil {
+		res.Err = &WSGapFillError{Err: err}
+	}
+	return res
+}
+
+// WSGapFillError 表示断线补偿的 REST 查询失败（已查到的部分仍会补发）。
+type WSGapFillError struct {
+	Err error
+}
+
+func (e *WSGapFillError) Error() string {
+	if e == nil || e.Err == nil {
+		return "okx: ws gap fill failed"
+	}
+	return "okx: ws gap fill failed: " + e.Err.Error()
+}
+
+func (e *WSGapFillError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Err
+}
+
+func (w *WSClient) emitRecoveredOrders(orders []TradeOrder) int {
+	g := w.gapFill
+	g.mu.Lock()
+	out := orders[:0]
+	for _, o := range orders {
+		if !g.seenOrderLocked(o) {
+			out = append(out, o)
+		}
+	}
+	g.mu.Unlock()
+	if len(out) == 0 {
+		return 0
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].UTime < out[j].UTime })
+	w.dispatchTyped(wsTypedTask{kind: wsTypedKindOrders, orders: out, recovered: true})
+	return len(out)
+}
+
+func (w *WSClient) emitRecoveredFills(fills []WSFill) int {
+	g := w.gapFill
+	g.mu.Lock()
+	out := fills[:0]
+	for _, f := range fills {
+		if !g.seenFillLocked(f) {
+			out = append(out, f)
+		}
+	}
+	g.mu.Unlock()
+	if len(out) == 0 {
+		return 0
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		ti, _ := strconv.ParseInt(out[i].TS, 10, 64)
+		tj, _ := strconv.ParseInt(out[j].TS, 10, 64)
+		return ti < tj
+	})
+	w.dispatchTyped(wsTypedTask{kind: wsTypedKindFills, fills: out, recovered: true})
+	return len(out)
+}
+
+// wsGapFillArgMatches 判断私有频道订阅参数是否覆盖该产品。
+func wsGapFillArgMatches(a WSArg, instType, instId string) bool {
+	if a.InstType != "" && a.InstType != "ANY" && a.InstType != instType {
+		return false
+	}
+	if a.InstFamily != "" && !strings.HasPrefix(instId, a.InstFamily+"-") {
+		return false
+	}
+	return a.InstId == "" || a.InstId == instId
+}
+
+func wsGapFillArgsMatch(args []WSArg, instType, instId string) bool {
+	for _, a := range args {
+		if wsGapFillArgMatches(a, instType, instId) {
+			return true
+		}
+	}
+	return false
+}
+
+// gapFillOrders 查询 uTime >= since 的未成交订单与历史订单。
+func (w *WSClient) gapFillOrders(ctx context.Context, args []WSArg, since int64) ([]TradeOrder, error) {
+	maxPages := w.gapFill.cfg.MaxPages
+	byOrdId := make(map[string]TradeOrder)
+	collect := func(page []TradeOrder) {
+		for _, o := range page {
+			if o.UTime < since || !wsGapFillArgsMatch(args, o.InstType, o.InstId) {
+				continue
+			}
+			if prev, ok := byOrdId[o.OrdId]; !ok || o.UTime > prev.UTime {
+				byOrdId[o.OrdId] = o
+			}
+		}
+	}
+
+	after := ""
+	for i := 0; i < maxPages; i++ {
+		svc := w.c.NewOrdersPendingService().Limit(wsGapFillPageLimit)
+		if after != "" {
+			svc.After(after)
+		}
+		page, err := svc.Do(ctx)
+		if err != nil {
+			return wsGapFillOrderList(byOrdId), err
+		}
+		collect(page)
+		if len(page) < wsGapFillPageLimit {
+			break
+		}
+		after = page[len(page)-1].OrdId
+	}
+
+	for _, instType := range wsGapFillHistoryInstTypes(args) {
+		after = ""
+		for i := 0; i < maxPages; i++ {
+			svc := w.c.NewOrdersHistoryService().InstType(instType).Limit(wsGapFillPageLimit)
+			if after != "" {
+				svc.After(after)
+			}
+			page, err := svc.Do(ctx)
+			if err != nil {
+				return wsGapFillOrderList(byOrdId), err
+			}
+			collect(page)
+			if len(page) < wsGapFillPageLimit || wsGapFillAllBefore(page, since) {
+				break
+			}
+			after = page[len(page)-1].OrdId
+		}
+	}
+	return wsGapFillOrderList(byOrdId), nil
+}
+
+// wsGapFillAllBefore 判断整页订单的 uTime 是否都早于 since（此时不再向更早的页翻页）。
+func wsGapFillAllBefore(page []TradeOrder, since int64) bool {
+	for _, o := range page {
+		if o.UTime >= since {
+			return false
+		}
+	}
+	return true
+}
+
+func wsGapFillOrderList(m map[string]TradeOrder) []TradeOrder {
+	out := make([]TradeOrder, 0, len(m))
+	for _, o := range m {
+		out = append(out, o)
+	}
+	return out
+}
+
+// wsGapFillHistoryInstTypes 返回订阅覆盖的产品类型（OrdersHistoryService 要求 instType 必填）。
+func wsGapFillHistoryInstTypes(args []WSArg) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, a := range args {
+		types := []string{a.InstType}
+		if a.InstType == "" || a.InstType == "ANY" {
+			types = wsGapFillInstTypes
+		}
+		for _, t := range types {
+			if !seen[t] {
+				seen[t] = true
+				out = append(out, t)
+			}
+		}
+	}
+	return out
+}
+
+// gapFillFills 查询 ts >= since 的成交明细。
+func (w *WSClient) gapFillFills(ctx context.Context, args []WSArg, since int64) ([]WSFill, error) {
+	var out []WSFill
+	after := ""
+	for i := 0; i < w.gapFill.cfg.MaxPages; i++ {
+		svc := w.c.NewTradeFillsService().Begin(strconv.FormatInt(since-1, 10)).Limit(wsGapFillPageLimit)
+		if after != "" {
+			svc.After(after)
+		}
+		page, err := svc.Do(ctx)
+		if err != nil {
+			return out, err
+		}
+		for _, f := range page {
+			if f.TS < since || !wsGapFillArgsMatch(args, f.InstType, f.InstId) {
+				continue
+			}
+			out = append(out, WSFill{
+				InstId:   f.InstId,
+				FillSz:   f.FillSz,
+				FillPx:   f.FillPx,
+				Side:     f.Side,
+				TS:       strconv.FormatInt(f.TS, 10),
+				OrdId:    f.OrdId,
+				ClOrdId:  f.ClOrdId,
+				TradeId:  f.TradeId,
+				ExecType: f.ExecType,
+			})
+		}
+		if len(page) < wsGapFillPageLimit {
+			break
+		}
+		after = page[len(page)-1].BillId
+	}
+	return out, nil
+}
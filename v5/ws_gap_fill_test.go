@@ -0,0 +1,219 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWSClient_GapFill_RecoversMissedOrdersAndFills(t *testing.T) {
+	const t1 = int64(1700000000000)
+
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	var conns atomic.Int32
+	var historyTypes sync.Map
+	var fillsBegin atomic.Value
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v5/trade/orders-pending":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"code":"0","msg":"","data":[
+				{"instType":"SPOT","instId":"BTC-USDT","ordId":"A","state":"live","uTime":"%d","cTime":"%d"},
+				{"instType":"SPOT","instId":"BTC-USDT","ordId":"B","state":"partially_filled","uTime":"%d","cTime":"%d"},
+				{"instType":"SWAP","instId":"BTC-USDT-SWAP","ordId":"X","state":"live","uTime":"%d","cTime":"%d"}]}`,
+				t1, t1, t1+20, t1+5, t1+30, t1+30)
+			return
+		case "/api/v5/trade/orders-history":
+			historyTypes.Store(r.URL.Query().Get("instType"), true)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"code":"0","msg":"","data":[
+				{"instType":"SPOT","instId":"BTC-USDT","ordId":"C","state":"canceled","uTime":"%d","cTime":"%d"},
+				{"instType":"SPOT","instId":"BTC-USDT","ordId":"OLD","state":"filled","uTime":"%d","cTime":"%d"}]}`,
+				t1+10, t1-100, t1-50, t1-100)
+			return
+		case "/api/v5/trade/fills":
+			fillsBegin.Store(r.URL.Query().Get("begin"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"code":"0","msg":"","data":[
+				{"instType":"SPOT","instId":"BTC-USDT","ordId":"B","tradeId":"t2","billId":"b2","fillPx":"100","fillSz":"1","side":"buy","ts":"%d","fillTime":"%d"},
+				{"instType":"SPOT","instId":"BTC-USDT","ordId":"A","tradeId":"t1","billId":"b1","fillPx":"100","fillSz":"1","side":"buy","ts":"%d","fillTime":"%d"}]}`,
+				t1+20, t1+20, t1, t1)
+			return
+		}
+
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+		n := conns.Add(1)
+
+		if _, _, err := c.ReadMessage(); err != nil {
+			return
+		}
+		_ = c.WriteMessage(websocket.TextMessage, []byte(`{"event":"login","code":"0","msg":"","connId":"x"}`))
+
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req wsOpRequest
+			if err := json.Unmarshal(msg, &req); err != nil || req.Op != "subscribe" {
+				continue
+			}
+			for _, a := range req.Args {
+				b, _ := json.Marshal(WSEvent{ID: req.ID, Event: req.Op, Arg: &a})
+				_ = c.WriteMessage(websocket.TextMessage, b)
+			}
+			if n == 1 {
+				_ = c.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"arg":{"channel":"orders","instType":"SPOT"},"data":[{"instType":"SPOT","instId":"BTC-USDT","ordId":"A","state":"live","uTime":"%d","cTime":"%d"}]}`, t1, t1)))
+				_ = c.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"arg":{"channel":"fills"},"data":[{"instId":"BTC-USDT","ordId":"A","tradeId":"t1","fillPx":"100","fillSz":"1","side":"buy","ts":"%d"}]}`, t1)))
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	var mu sync.Mutex
+	var orders []TradeOrder
+	var fills []WSFill
+	doneCh := make(chan WSGapFillResult, 1)
+
+	client := NewClient(
+		WithBaseURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+		WithCredentials(Credentials{APIKey: "k", SecretKey: "s", Passphrase: "p"}),
+	)
+	ws := client.NewWSPrivate(
+		WithWSURL("ws"+srv.URL[len("http"):]),
+		WithWSGapFill(WSGapFillConfig{OnDone: func(r WSGapFillResult) { doneCh <- r }}),
+		WithWSOrdersHandler(func(o TradeOrder) {
+			mu.Lock()
+			defer mu.Unlock()
+			orders = append(orders, o)
+		}),
+		WithWSFillsHandler(func(f WSFill) {
+			mu.Lock()
+			defer mu.Unlock()
+			fills = append(fills, f)
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ws.Start(ctx, nil, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(ws.Close)
+	if err := ws.SubscribeAndWait(ctx, WSArg{Channel: WSChannelOrders, InstType: "SPOT"}, WSArg{Channel: WSChannelFills}); err != nil {
+		t.Fatalf("SubscribeAndWait() error = %v", err)
+	}
+	waitFor(t, "live pushes", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(orders) == 1 && len(fills) == 1
+	})
+
+	// 断线：重连并重订阅后触发补偿。
+	ws.closeConn()
+
+	var res WSGapFillResult
+	select {
+	case res = <-doneCh:
+	case <-time.After(3 * time.Second):
+		t.Fatalf("timeout waiting gap fill")
+	}
+	if res.Err != nil || res.OrdersSince != t1 || res.FillsSince != t1 || res.Orders != 2 || res.Fills != 1 {
+		t.Fatalf("result = %#v", res)
+	}
+	if begin, _ := fillsBegin.Load().(string); begin != fmt.Sprint(t1-1) {
+		t.Fatalf("fills begin = %q", begin)
+	}
+	if _, ok := historyTypes.Load("SPOT"); !ok {
+		t.Fatalf("orders-history not queried for SPOT")
+	}
+	if _, ok := historyTypes.Load("SWAP"); ok {
+		t.Fatalf("orders-history queried for unsubscribed SWAP")
+	}
+
+	waitFor(t, "recovered items", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(orders) == 3 && len(fills) == 2
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	if orders[0].Recovered || fills[0].Recovered {
+		t.Fatalf("live items flagged recovered: %#v %#v", orders[0], fills[0])
+	}
+	// 补发按 uTime 升序：C(t1+10) 先于 B(t1+20)；A 已送达、X 不在订阅范围、OLD 早于起点。
+	if orders[1].OrdId != "C" || !orders[1].Recovered || orders[2].OrdId != "B" || !orders[2].Recovered {
+		t.Fatalf("orders = %#v", orders)
+	}
+	if fills[1].TradeId != "t2" || !fills[1].Recovered || fills[1].TS != fmt.Sprint(t1+20) {
+		t.Fatalf("fills = %#v", fills)
+	}
+}
+
+func TestWSGapFiller_DedupAndEviction(t *testing.T) {
+	g := newWSGapFiller(WSGapFillConfig{MaxSeen: 2})
+	var got []string
+	call := func(o TradeOrder) { got = append(got, fmt.Sprintf("%s@%d/%v", o.OrdId, o.UTime, o.Recovered)) }
+
+	g.deliverOrders([]TradeOrder{{OrdId: "a", UTime: 10}, {OrdId: "b", UTime: 11}}, false, call)
+	g.deliverOrders([]TradeOrder{{OrdId: "a", UTime: 10}, {OrdId: "a", UTime: 12}, {OrdId: "b", UTime: 9}}, true, call)
+	// 实时推送始终送达。
+	g.deliverOrders([]TradeOrder{{OrdId: "b", UTime: 11}}, false, call)
+	// 第三个订单淘汰最早记录 a。
+	g.deliverOrders([]TradeOrder{{OrdId: "c", UTime: 13}}, false, call)
+	g.deliverOrders([]TradeOrder{{OrdId: "a", UTime: 12}}, true, call)
+
+	want := "[a@10/false b@11/false a@12/true b@11/false c@13/false a@12/true]"
+	if fmt.Sprint(got) != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if g.lastUTime != 13 {
+		t.Fatalf("lastUTime = %d", g.lastUTime)
+	}
+
+	var fills []string
+	fcall := func(f WSFill) { fills = append(fills, f.TradeId+fmt.Sprint(f.Recovered)) }
+	g.deliverFills([]WSFill{{OrdId: "a", TradeId: "1", TS: "100"}}, false, fcall)
+	g.deliverFills([]WSFill{{OrdId: "a", TradeId: "1", TS: "100"}, {OrdId: "a", TradeId: "2", TS: "101"}}, true, fcall)
+	if fmt.Sprint(fills) != "[1false 2true]" || g.lastFillTS != 101 {
+		t.Fatalf("fills = %v lastFillTS = %d", fills, g.lastFillTS)
+	}
+}
+
+func TestWSGapFillArgMatches(t *testing.T) {
+	cases := []struct {
+		arg              WSArg
+		instType, instId string
+		want             bool
+	}{
+		{WSArg{InstType: "ANY"}, "SWAP", "BTC-USDT-SWAP", true},
+		{WSArg{InstType: "SPOT"}, "SWAP", "BTC-USDT-SWAP", false},
+		{WSArg{InstType: "SWAP", InstFamily: "BTC-USDT"}, "SWAP", "BTC-USDT-SWAP", true},
+		{WSArg{InstType: "SWAP", InstFamily: "ETH-USDT"}, "SWAP", "BTC-USDT-SWAP", false},
+		{WSArg{InstType: "SPOT", InstId: "BTC-USDT"}, "SPOT", "ETH-USDT", false},
+		{WSArg{}, "OPTION", "BTC-USD-250101-100000-C", true},
+	}
+	for _, tc := range cases {
+		if got := wsGapFillArgMatches(tc.arg, tc.instType, tc.instId); got != tc.want {
+			t.Fatalf("%#v %s %s: got %v", tc.arg, tc.instType, tc.instId, got)
+		}
+	}
+	if got := fmt.Sprint(wsGapFillHistoryInstTypes([]WSArg{{InstType: "SPOT"}, {InstType: "ANY"}})); got != "[SPOT MARGIN SWAP FUTURES OPTION]" {
+		t.Fatalf("history inst types = %s", got)
+	}
+}
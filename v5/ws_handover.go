@@ -0,0 +1,243 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsHandoverMaxPending 为切换等待期间新连接上缓存的数据消息上限（超过则放弃切换，回退为断开重连）。
+const wsHandoverMaxPending = 65536
+
+// WithWSMakeBeforeBreak 启用 64008（服务升级）通知的“先连后断”切换：
+// 收到 notice code=64008 时，旧连接继续推送；后台拨号新连接、登录并重订阅 snapshotDesired() 集合，
+// 待订阅全部确认后再原子切换到新连接并关闭旧连接。重叠期间两条连接上重复的数据推送会被去重。
+//
+// 新连接建立失败时回退为默认行为（断开并重连）。
+func WithWSMakeBeforeBreak() WSOption {
+	return func(w *WSClient) {
+		w.makeBeforeBreak = true
+	}
+}
+
+// wsHandover 为已完成订阅确认、等待 run loop 接管的新连接。
+type wsHandover struct {
+	conn    *websocket.Conn
+	pending [][]byte
+}
+
+// wsOverlap 为切换期间的去重窗口；until 为 0 表示仍在重叠期，否则为去重截止时间（UnixNano）。
+type wsOverlap struct {
+	dedup *wsDedup
+	until atomic.Int64
+}
+
+// dropDuplicate 返回 true 表示该数据消息在 64008 切换重叠期内重复，应丢弃（热备冗余组的去重见 WSRedundantFeed.forward）。
+func (w *WSClient) dropDuplicate(message []byte) bool {
+	if w == nil {
+		return false
+	}
+	ov := w.overlap.Load()
+	if ov == nil {
+		return false
+	}
+	_, key, ok := wsDedupKey(message)
+	if !ok {
+		return false
+	}
+	if until := ov.until.Load(); until != 0 && time.Now().UnixNano() > until {
+		w.overlap.CompareAndSwap(ov, nil)
+		return false
+	}
+	if !ov.dedup.firstSeen(key) {
+		w.overlapDropped.Add(1)
+		return true
+	}
+	return false
+}
+
+func (w *WSClient) startHandover(ctx context.Context, old *websocket.Conn) {
+	if !w.handoverActive.CompareAndSwap(false, true) {
+		return
+	}
+	ov := &wsOverlap{dedup: newWSDedup(defaultWSDedupWindow)}
+	w.overlap.Store(ov)
+
+	go func() {
+		err := w.handover(ctx, old, ov)
+		if err == nil {
+			return
+		}
+		w.overlap.CompareAndSwap(ov, nil)
+		w.handoverActive.Store(false)
+		w.onError(fmt.Errorf("okx: ws make-before-break failed, fallback to reconnect: %w", err))
+
+		w.mu.Lock()
+		cur := w.conn
+		w.mu.Unlock()
+		if cur == old {
+			w.closeConn()
+		}
+	}()
+}
+
+// handover 建立新连接并等待重订阅确认；成功后切换 w.conn 并关闭旧连接，由 run loop 接管新连接。
+func (w *WSClient) handover(ctx context.Context, old *websocket.Conn, ov *wsOverlap) error {
+	w.dialAttempts.Add(1)
+	conn, err := w.dial(ctx)
+	if err != nil {
+		return err
+	}
+	w.prepareConn(conn)
+	fail := func(err error) error {
+		_ = conn.Close()
+		return err
+	}
+
+	if w.needLogin {
+		if err := w.login(ctx, conn); err != nil {
+			return fail(err)
+		}
+	}
+
+	var pending [][]byte
+	if args := w.snapshotDesired(); len(args) > 0 {
+		waiter, err := w.writeArgsOp(ctx, conn, "subscribe", args, true, w.writeJSONConn)
+		if err != nil {
+			return fail(err)
+		}
+		id := waiter.ids[0]
+
+		timeout := w.resubscribeWait
+		if timeout <= 0 {
+			timeout = 5 * time.Second
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(timeout))
+
+	wait:
+		for {
+			select {
+			case err := <-waiter.done:
+				if err != nil {
+					return fail(err)
+				}
+				break wait
+			default:
+			}
+
+			msgType, msg, err := conn.ReadMessage()
+			if err != nil {
+				w.removeWaiter(id)
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					return fail(errors.New("okx: ws make-before-break resubscribe wait timeout"))
+				}
+				return fail(err)
+			}
+
+			w.recordFrame(w.recordConnID()+1, msgType, msg)
+			if isWSPongMessage(msg) {
+				continue
+			}
+			if isWSPingMessage(msg) {
+				if err := w.writeTextConn(conn, "pong"); err != nil {
+					w.removeWaiter(id)
+					return fail(err)
+				}
+				continue
+			}
+			// 数据推送先缓存，切换后按序回放（经重叠期去重），避免与旧连接的推送交错。
+			if _, _, isData := wsDedupKey(msg); isData {
+				if len(pending) >= wsHandoverMaxPending {
+					w.removeWaiter(id)
+					return fail(errors.New("okx: ws make-before-break pending buffer full"))
+				}
+				pending = append(pending, msg)
+				continue
+			}
+			w.deliverFrame(msgType, msg)
+		}
+		_ = conn.SetReadDeadline(time.Time{})
+	}
+
+	w.mu.Lock()
+	if w.conn != old {
+		w.mu.Unlock()
+		return fail(errors.New("okx: ws connection changed during make-before-break"))
+	}
+	w.opWaitMu.Lock()
+	stale := make([]string, 0, len(w.opWaiters))
+	for id := range w.opWaiters {
+		stale = append(stale, id)
+	}
+	w.opWaitMu.Unlock()
+
+	w.handoverNext = &wsHandover{conn: conn, pending: pending}
+	w.conn = conn
+	w.notifyConnChangeLocked()
+	w.mu.Unlock()
+
+	w.lastPing.Store(0)
+	w.touchRecv()
+	w.handovers.Add(1)
+	resubWait := w.resubscribeWait
+	if resubWait <= 0 {
+		resubWait = 5 * time.Second
+	}
+	ov.until.Store(time.Now().Add(resubWait).UnixNano())
+
+	_ = old.Close()
+	w.failOpWaiterIDs(stale, errors.New("okx: ws disconnected"))
+	return nil
+}
+
+// takeHandover 取出待接管的新连接（无则返回 nil）。
+func (w *WSClient) takeHandover() *wsHandover {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	h := w.handoverNext
+	w.handoverNext = nil
+	return h
+}
+
+// replayHandoverPending 回放切换等待期间新连接上缓存的数据推送（已由旧连接送达的会被去重丢弃）。
+func (w *WSClient) replayHandoverPending(pending [][]byte) {
+	for _, msg := range pending {
+		if w.dropDuplicate(msg) {
+			continue
+		}
+		w.deliverFrame(websocket.TextMessage, msg)
+	}
+}
+
+func (w *WSClient) writeTextConn(conn *websocket.Conn, message string) error {
+	timeout := w.writeTimeout
+
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+	if timeout > 0 {
+		_ = conn.SetWriteDeadline(time.Now().Add(timeout))
+	}
+	return conn.WriteMessage(websocket.TextMessage, []byte(message))
+}
+
+func (w *WSClient) failOpWaiterIDs(ids []string, err error) {
+	for _, id := range ids {
+		w.opWaitMu.Lock()
+		waiter := w.opWaiters[id]
+		delete(w.opWaiters, id)
+		w.opWaitMu.Unlock()
+		if waiter == nil {
+			continue
+		}
+		select {
+		case waiter.done <- wsOpRespResult{err: err}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWSClient_MakeBeforeBreak64008(t *testing.T) {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	trade := func(id int) []byte {
+		return []byte(fmt.Sprintf(`{"arg":{"channel":"trades","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","tradeId":"%d","px":"1","sz":"1","side":"buy","ts":"%d"}]}`, id, id))
+	}
+	ack := func(c *websocket.Conn) {
+		_, msg, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req wsOpRequest
+		_ = json.Unmarshal(msg, &req)
+		for _, a := range req.Args {
+			b, _ := json.Marshal(WSEvent{ID: req.ID, Event: "subscribe", Arg: &a})
+			_ = c.WriteMessage(websocket.TextMessage, b)
+		}
+	}
+
+	var connNum atomic.Int32
+	conn2Subscribed := make(chan struct{})
+	oldPushed := make(chan struct{})
+	oldClosed := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+
+		switch connNum.Add(1) {
+		case 1:
+			ack(c)
+			_ = c.WriteMessage(websocket.TextMessage, trade(1))
+			_ = c.WriteMessage(websocket.TextMessage, trade(2))
+			_ = c.WriteMessage(websocket.TextMessage, []byte(`{"event":"notice","code":"64008","msg":"upgrade","connId":"a"}`))
+			<-conn2Subscribed
+			// 旧连接在切换前继续推送。
+			_ = c.WriteMessage(websocket.TextMessage, trade(3))
+			_ = c.WriteMessage(websocket.TextMessage, trade(4))
+			close(oldPushed)
+			for {
+				if _, _, err := c.ReadMessage(); err != nil {
+					close(oldClosed)
+					return
+				}
+			}
+		case 2:
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			close(conn2Subscribed)
+			<-oldPushed
+			var req wsOpRequest
+			_ = json.Unmarshal(msg, &req)
+			// 订阅确认前的数据推送：缓存并在切换后去重回放。
+			_ = c.WriteMessage(websocket.TextMessage, trade(3))
+			for _, a := range req.Args {
+				b, _ := json.Marshal(WSEvent{ID: req.ID, Event: "subscribe", Arg: &a})
+				_ = c.WriteMessage(websocket.TextMessage, b)
+			}
+			for _, id := range []int{4, 5, 6} {
+				_ = c.WriteMessage(websocket.TextMessage, trade(id))
+			}
+			for {
+				if _, _, err := c.ReadMessage(); err != nil {
+					return
+				}
+			}
+		default:
+			t.Errorf("unexpected connection")
+		}
+	}))
+	t.Cleanup(srv.Close)
+	wsURL := "ws" + srv.URL[len("http"):]
+
+	var mu sync.Mutex
+	var got []string
+	c := NewClient()
+	ws := c.NewWSPublic(WithWSURL(wsURL), WithWSMakeBeforeBreak(), WithWSTypedHandlerInline(), WithWSTradesHandler(func(trade MarketTrade) {
+		mu.Lock()
+		got = append(got, trade.TradeId)
+		mu.Unlock()
+	}))
+	if err := ws.Subscribe(WSArg{Channel: WSChannelTrades, InstId: "BTC-USDT"}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ws.Start(ctx, nil, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	waitFor(t, "trades", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) >= 6
+	})
+	select {
+	case <-oldClosed:
+	case <-time.After(3 * time.Second):
+		t.Fatalf("timeout waiting old conn close")
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	want := []string{"1", "2", "3", "4", "5", "6"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("trades = %v, want %v", got, want)
+	}
+	mu.Unlock()
+
+	st := ws.Stats()
+	if st.Handovers != 1 || st.Reconnects != 0 || !st.Connected || connNum.Load() != 2 {
+		t.Fatalf("stats = %#v conns=%d", st, connNum.Load())
+	}
+}
@@ -0,0 +1,314 @@
+package okx
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// WSLatencyKindPush 为推送延迟：数据 ts（无 ts 时取 uTime）-> 本地接收时间（已按 Client.TimeOffset 校准到服务器时钟）。
+	WSLatencyKindPush = "push"
+	// WSLatencyKindOpGateway 为 op 在 OKX 网关内的耗时（outTime - inTime）。
+	WSLatencyKindOpGateway = "op-gateway"
+	// WSLatencyKindOpRoundTrip 为 op 往返耗时（本地发送 -> 收到对应回包）。
+	WSLatencyKindOpRoundTrip = "op-rtt"
+)
+
+// WSLatencySample 表示一次延迟采样。
+type WSLatencySample struct {
+	Kind string
+	// Key 为频道名（push）或 op 名（op-gateway/op-rtt）。
+	Key     string
+	Latency time.Duration
+	// At 为本地采样时间。
+	At time.Time
+}
+
+// WSLatencyStats 为某一维度的延迟分布快照。
+//
+// 分位数按对数分桶近似（相对误差约 ±10%，不超过 Max）；时钟偏差导致的负延迟计入最低桶，Min 保留原值便于发现偏差。
+type WSLatencyStats struct {
+	Count uint64
+	Min   time.Duration
+	Max   time.Duration
+	Mean  time.Duration
+
+	P50  time.Duration
+	P90  time.Duration
+	P99  time.Duration
+	P999 time.Duration
+}
+
+// WithWSLatencyTelemetry 开启延迟统计（按频道的推送延迟、按 op 的网关耗时与往返耗时；结果见 Stats）。
+//
+// 默认关闭：推送延迟需要额外解析一次数据消息的 ts。
+func WithWSLatencyTelemetry() WSOption {
+	return func(c *WSClient) {
+		if c.latency == nil {
+			c.latency = newWSLatencyRecorder()
+		}
+	}
+}
+
+// WithWSLatencyHook 设置每次延迟采样的回调（同时开启延迟统计）。
+//
+// 回调在 WS 接收 goroutine 中同步执行，应尽快返回（如仅做阈值判断后投递告警）。
+func WithWSLatencyHook(hook func(s WSLatencySample)) WSOption {
+	return func(c *WSClient) {
+		if c.latency == nil {
+			c.latency = newWSLatencyRecorder()
+		}
+		c.latency.hook = hook
+	}
+}
+
+type wsLatencyKey struct {
+	kind string
+	key  string
+}
+
+type wsLatencyRecorder struct {
+	hook func(s WSLatencySample)
+
+	mu    sync.RWMutex
+	hists map[wsLatencyKey]*latencyHistogram
+}
+
+func newWSLatencyRecorder() *wsLatencyRecorder {
+	return &wsLatencyRecorder{hists: make(map[wsLatencyKey]*latencyHistogram)}
+}
+
+func (r *wsLatencyRecorder) histogram(kind, key string) *latencyHistogram {
+	k := wsLatencyKey{kind: kind, key: key}
+	r.mu.RLock()
+	h := r.hists[k]
+	r.mu.RUnlock()
+	if h != nil {
+		return h
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if h = r.hists[k]; h == nil {
+		h = newLatencyHistogram()
+		r.hists[k] = h
+	}
+	return h
+}
+
+// snapshot 返回 kind 下各 key 的分布（无样本时返回 nil）。
+func (r *wsLatencyRecorder) snapshot(kind string) map[string]WSLatencyStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out map[string]WSLatencyStats
+	for k, h := range r.hists {
+		if k.kind != kind {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]WSLatencyStats)
+		}
+		out[k.key] = h.stats()
+	}
+	return out
+}
+
+// observeLatency 记录一次采样并调用 hook。
+func (w *WSClient) observeLatency(kind, key string, d time.Duration, at time.Time) {
+	r := w.latency
+	if r == nil || key == "" {
+		return
+	}
+	r.histogram(kind, key).observe(d)
+	if r.hook != nil {
+		w.safeLatencyHookCall(WSLatencySample{Kind: kind, Key: key, Latency: d, At: at})
+	}
+}
+
+func (w *WSClient) safeLatencyHookCall(s WSLatencySample) {
+	defer func() {
+		if r := recover(); r != nil {
+			w.onError(fmt.Errorf("okx: ws latency hook panic: %v", r))
+		}
+	}()
+	w.latency.hook(s)
+}
+
+// observePushLatency 从数据推送首条记录的 ts/uTime（毫秒）计算推送延迟。
+func (w *WSClient) observePushLatency(msg []byte, recvAt time.Time) {
+	if w.latency == nil || len(msg) == 0 || msg[0] != '{' {
+		return
+	}
+	var probe struct {
+		Arg  WSArg             `json:"arg"`
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &probe); err != nil || probe.Arg.Channel == "" || len(probe.Data) == 0 {
+		return
+	}
+	ms, ok := wsPushTimestampMillis(probe.Data[0])
+	if !ok {
+		return
+	}
+	serverRecv := recvAt
+	if w.c != nil {
+		serverRecv = recvAt.Add(-w.c.TimeOffset())
+	}
+	w.observeLatency(WSLatencyKindPush, probe.Arg.Channel, serverRecv.Sub(time.UnixMilli(ms)), recvAt)
+}
+
+// wsPushTimestampMillis 提取单条推送记录的时间戳：对象取 ts（缺省取 uTime）。
+//
+// 数组形式的记录（K 线）首元素为 K 线开始时间而非推送时间，不计入推送延迟。
+func wsPushTimestampMillis(item json.RawMessage) (int64, bool) {
+	if len(item) == 0 || item[0] != '{' {
+		return 0, false
+	}
+	var v struct {
+		TS    json.RawMessage `json:"ts"`
+		UTime json.RawMessage `json:"uTime"`
+	}
+	if err := json.Unmarshal(item, &v); err != nil {
+		return 0, false
+	}
+	raw := v.TS
+	if len(raw) == 0 {
+		raw = v.UTime
+	}
+	ms, err := strconv.ParseInt(unquoteJSONNumber(raw), 10, 64)
+	if err != nil || ms <= 0 {
+		return 0, false
+	}
+	return ms, true
+}
+
+func unquoteJSONNumber(raw json.RawMessage) string {
+	if len(raw) >= 2 && raw[0] == '"' && raw[len(raw)-1] == '"' {
+		return string(raw[1 : len(raw)-1])
+	}
+	return string(raw)
+}
+
+// observeOpGatewayLatency 按 op 回包的 inTime/outTime（微秒）记录网关耗时。
+func (w *WSClient) observeOpGatewayLatency(reply WSOpReply, recvAt time.Time) {
+	if w.latency == nil || reply.InTime == "" || reply.OutTime == "" {
+		return
+	}
+	in, err1 := strconv.ParseInt(reply.InTime, 10, 64)
+	out, err2 := strconv.ParseInt(reply.OutTime, 10, 64)
+	if err1 != nil || err2 != nil || in <= 0 || out < in {
+		return
+	}
+	w.observeLatency(WSLatencyKindOpGateway, reply.Op, time.Duration(out-in)*time.Microsecond, recvAt)
+}
+
+// latencyHistogram 为并发安全的对数分桶直方图（微秒精度；每个 2 的幂区间细分 latencySubBuckets 个桶）。
+type latencyHistogram struct {
+	buckets [latencyBuckets]atomic.Uint64
+	count   atomic.Uint64
+	sum     atomic.Int64
+	min     atomic.Int64
+	max     atomic.Int64
+}
+
+const (
+	latencySubBucketBits = 2
+	latencySubBuckets    = 1 << latencySubBucketBits
+	latencyMaxBits       = 36 // 2^36µs ≈ 19h，更大的值计入末桶
+	latencyBuckets       = (latencyMaxBits - latencySubBucketBits + 1) * latencySubBuckets
+)
+
+func newLatencyHistogram() *latencyHistogram {
+	h := &latencyHistogram{}
+	h.min.Store(math.MaxInt64)
+	h.max.Store(math.MinInt64)
+	return h
+}
+
+// latencyBucket 返回微秒值 us 所在桶下标。
+func latencyBucket(us int64) int {
+	if us < latencySubBuckets {
+		if us < 0 {
+			return 0
+		}
+		return int(us)
+	}
+	n := bits.Len64(uint64(us)) // us ∈ [2^(n-1), 2^n)
+	if n > latencyMaxBits {
+		return latencyBuckets - 1
+	}
+	shift := n - 1 - latencySubBucketBits
+	sub := int(uint64(us)>>uint(shift)) - latencySubBuckets
+	return (n-latencySubBucketBits)*latencySubBuckets + sub
+}
+
+// latencyBucketUpper 返回桶 i 的上界（微秒，含）。
+func latencyBucketUpper(i int) int64 {
+	if i < latencySubBuckets {
+		return int64(i)
+	}
+	n := i/latencySubBuckets + latencySubBucketBits
+	sub := i % latencySubBuckets
+	shift := n - 1 - latencySubBucketBits
+	return int64(latencySubBuckets+sub+1)<<uint(shift) - 1
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	us := d.Microseconds()
+	h.buckets[latencyBucket(us)].Add(1)
+	h.count.Add(1)
+	h.sum.Add(int64(d))
+	for v := h.min.Load(); int64(d) < v && !h.min.CompareAndSwap(v, int64(d)); v = h.min.Load() {
+	}
+	for v := h.max.Load(); int64(d) > v && !h.max.CompareAndSwap(v, int64(d)); v = h.max.Load() {
+	}
+}
+
+func (h *latencyHistogram) stats() WSLatencyStats {
+	var counts [latencyBuckets]uint64
+	var total uint64
+	for i := range h.buckets {
+		counts[i] = h.buckets[i].Load()
+		total += counts[i]
+	}
+	if total == 0 {
+		return WSLatencyStats{}
+	}
+	s := WSLatencyStats{
+		Count: total,
+		Min:   time.Duration(h.min.Load()),
+		Max:   time.Duration(h.max.Load()),
+		Mean:  time.Duration(h.sum.Load() / int64(h.count.Load())),
+	}
+	quantile := func(q float64) time.Duration {
+		rank := uint64(q*float64(total) + 0.5)
+		if rank == 0 {
+			rank = 1
+		}
+		var seen uint64
+		for i, c := range counts {
+			seen += c
+			if seen >= rank {
+				d := time.Duration(latencyBucketUpper(i)) * time.Microsecond
+				if d > s.Max {
+					d = s.Max
+				}
+				if d < 0 {
+					d = 0
+				}
+				return d
+			}
+		}
+		return s.Max
+	}
+	s.P50 = quantile(0.50)
+	s.P90 = quantile(0.90)
+	s.P99 = quantile(0.99)
+	s.P999 = quantile(0.999)
+	return s
+}
@@ -0,0 +1,197 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestLatencyHistogram_Buckets(t *testing.T) {
+	for us := int64(0); us < 1<<20; us += 1 + us/7 {
+		i := latencyBucket(us)
+		if upper := latencyBucketUpper(i); us > upper {
+			t.Fatalf("us=%d bucket=%d upper=%d", us, i, upper)
+		}
+		if i > 0 && us <= latencyBucketUpper(i-1) {
+			t.Fatalf("us=%d bucket=%d prev upper=%d", us, i, latencyBucketUpper(i-1))
+		}
+	}
+	if got := latencyBucket(-5); got != 0 {
+		t.Fatalf("negative bucket = %d", got)
+	}
+	if got := latencyBucket(1 << 50); got != latencyBuckets-1 {
+		t.Fatalf("overflow bucket = %d", got)
+	}
+}
+
+func TestLatencyHistogram_Stats(t *testing.T) {
+	h := newLatencyHistogram()
+	if s := h.stats(); s.Count != 0 || s.P99 != 0 {
+		t.Fatalf("empty stats = %#v", s)
+	}
+	for i := 1; i <= 1000; i++ {
+		h.observe(time.Duration(i) * time.Millisecond)
+	}
+	h.observe(-time.Millisecond)
+
+	s := h.stats()
+	if s.Count != 1001 || s.Min != -time.Millisecond || s.Max != time.Second {
+		t.Fatalf("stats = %#v", s)
+	}
+	check := func(name string, got, want time.Duration) {
+		t.Helper()
+		if got < want*85/100 || got > want*115/100 {
+			t.Fatalf("%s = %v, want ~%v", name, got, want)
+		}
+	}
+	check("P50", s.P50, 500*time.Millisecond)
+	check("P90", s.P90, 900*time.Millisecond)
+	check("P99", s.P99, 990*time.Millisecond)
+	check("Mean", s.Mean, 500*time.Millisecond)
+	if s.P999 > s.Max {
+		t.Fatalf("P999 = %v > Max %v", s.P999, s.Max)
+	}
+}
+
+func TestWSPushTimestampMillis(t *testing.T) {
+	cases := []struct {
+		item string
+		want int64
+		ok   bool
+	}{
+		{`{"instId":"BTC-USDT","ts":"1700000000001"}`, 1700000000001, true},
+		{`{"ordId":"1","uTime":"1700000000002","cTime":"1"}`, 1700000000002, true},
+		{`{"ts":1700000000003}`, 1700000000003, true},
+		// K 线首元素为开始时间而非推送时间：跳过。
+		{`["1700000000004","1","2","3","4","5","6","7","0"]`, 0, false},
+		{`{"instId":"BTC-USDT"}`, 0, false},
+		{`"x"`, 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := wsPushTimestampMillis(json.RawMessage(tc.item))
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("%s: got %d,%v want %d,%v", tc.item, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestWSClient_LatencyTelemetry(t *testing.T) {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handleTradeAccountRateLimitMock(w, r) {
+			return
+		}
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+
+		if _, _, err := c.ReadMessage(); err != nil {
+			return
+		}
+		_ = c.WriteMessage(websocket.TextMessage, []byte(`{"event":"login","code":"0","msg":"","connId":"x"}`))
+
+		push := fmt.Sprintf(`{"arg":{"channel":"orders","instType":"ANY"},"data":[{"ordId":"1","uTime":"%d"}]}`, time.Now().UnixMilli())
+		_ = c.WriteMessage(websocket.TextMessage, []byte(push))
+
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req wsOpRequest
+			if err := json.Unmarshal(msg, &req); err != nil {
+				continue
+			}
+			time.Sleep(20 * time.Millisecond)
+			resp := `{"id":"` + req.ID + `","op":"` + req.Op + `","code":"0","msg":"","data":[{"result":true}],"inTime":"1700000000000000","outTime":"1700000000001500"}`
+			_ = c.WriteMessage(websocket.TextMessage, []byte(resp))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	var mu sync.Mutex
+	var samples []WSLatencySample
+	client := NewClient(
+		WithBaseURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+		WithCredentials(Credentials{APIKey: "k", SecretKey: "s", Passphrase: "p"}),
+		// 本地时钟比服务器慢 2s：校准后推送延迟约为 2s。
+		WithTimeOffset(-2*time.Second),
+	)
+	ws := client.NewWSPrivate(
+		WithWSURL("ws"+srv.URL[len("http"):]),
+		WithWSLatencyHook(func(s WSLatencySample) {
+			mu.Lock()
+			defer mu.Unlock()
+			samples = append(samples, s)
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ws.Start(ctx, nil, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(ws.Close)
+
+	opCtx, opCancel := context.WithTimeout(context.Background(), 2*time.Second)
+	t.Cleanup(opCancel)
+	if _, err := ws.MassCancel(opCtx, WSMassCancelArg{InstType: "OPTION", InstFamily: "BTC-USD"}); err != nil {
+		t.Fatalf("MassCancel() error = %v", err)
+	}
+
+	waitFor(t, "latency samples", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(samples) == 3
+	})
+
+	st := ws.Stats()
+	push, ok := st.PushLatency["orders"]
+	if !ok || push.Count != 1 || push.Max < 2*time.Second || push.Max > 3*time.Second {
+		t.Fatalf("PushLatency = %#v", st.PushLatency)
+	}
+	gw, ok := st.OpGatewayLatency["mass-cancel"]
+	if !ok || gw.Count != 1 || gw.Max != 1500*time.Microsecond {
+		t.Fatalf("OpGatewayLatency = %#v", st.OpGatewayLatency)
+	}
+	rtt, ok := st.OpRoundTrip["mass-cancel"]
+	if !ok || rtt.Count != 1 || rtt.Max < 20*time.Millisecond || rtt.P50 != rtt.Max {
+		t.Fatalf("OpRoundTrip = %#v", st.OpRoundTrip)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	kinds := map[string]string{}
+	for _, s := range samples {
+		if s.At.IsZero() {
+			t.Fatalf("sample = %#v", s)
+		}
+		kinds[s.Kind] = s.Key
+	}
+	if kinds[WSLatencyKindPush] != "orders" || kinds[WSLatencyKindOpGateway] != "mass-cancel" || kinds[WSLatencyKindOpRoundTrip] != "mass-cancel" {
+		t.Fatalf("samples = %#v", samples)
+	}
+}
+
+func TestWSClient_LatencyTelemetry_DisabledByDefault(t *testing.T) {
+	ws := NewClient().NewWSPublic()
+	ws.observePushLatency([]byte(`{"arg":{"channel":"tickers"},"data":[{"ts":"`+strconv.FormatInt(time.Now().UnixMilli(), 10)+`"}]}`), time.Now())
+	if st := ws.Stats(); st.PushLatency != nil || st.OpRoundTrip != nil {
+		t.Fatalf("stats = %#v", st)
+	}
+}
@@ -0,0 +1,418 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultManagedOrderBookResyncTimeout = 10 * time.Second
+
+// managedOrderBookMaxBackoffShift 为连续超时重同步的最大退避倍数（resyncTimeout << 5）。
+const managedOrderBookMaxBackoffShift = 5
+
+// ManagedOrderBookState 为 ManagedOrderBook 的同步状态。
+type ManagedOrderBookState string
+
+const (
+	// ManagedOrderBookSyncing 表示正在（重新）获取 snapshot；此时 Snapshot 仅返回 REST 种子（若启用）。
+	ManagedOrderBookSyncing ManagedOrderBookState = "syncing"
+	// ManagedOrderBookReady 表示本地深度已与推送同步（seq/checksum 校验通过）。
+	ManagedOrderBookReady ManagedOrderBookState = "ready"
+	// ManagedOrderBookStale 表示超过 StaleAfter 未收到推送；深度保留但可能已过期，同时会触发重订阅。
+	ManagedOrderBookStale ManagedOrderBookState = "stale"
+)
+
+// ManagedOrderBookEvent 为状态变化事件。
+type ManagedOrderBookEvent struct {
+	Channel string
+	InstId  string
+	State   ManagedOrderBookState
+
+	// Err 为触发重同步的原因（如 WSOrderBookSequenceError/WSOrderBookChecksumError/超时）；进入 ready 时为 nil。
+	Err error
+	// Resyncs 为累计重同步次数。
+	Resyncs uint64
+}
+
+// ManagedOrderBookOption 用于配置 ManagedOrderBook。
+type ManagedOrderBookOption func(*ManagedOrderBook)
+
+// WithManagedOrderBookStateHandler 设置状态变化回调（在 WS read goroutine 或内部 goroutine 中执行，应保持轻量）。
+func WithManagedOrderBookStateHandler(handler func(event ManagedOrderBookEvent)) ManagedOrderBookOption {
+	return func(m *ManagedOrderBook) {
+		m.stateHandler = handler
+	}
+}
+
+// WithManagedOrderBookRESTSeed 在同步期间通过 REST 获取深度作为临时快照（sz<=400 使用 MarketBooksService，否则使用 MarketBooksFullService）。
+//
+// 说明：REST 种子与 WS 推送无 seqId 关联，仅作为同步完成前的只读参考（Snapshot 返回的状态仍为 syncing）。
+func WithManagedOrderBookRESTSeed(c *Client, sz int) ManagedOrderBookOption {
+	return func(m *ManagedOrderBook) {
+		m.seedClient = c
+		m.seedSz = sz
+	}
+}
+
+// WithManagedOrderBookStaleAfter 设置无推送多久视为 stale 并触发重订阅（默认 0：不检测；深度频道在盘口无变化时可能长时间无推送）。
+func WithManagedOrderBookStaleAfter(d time.Duration) ManagedOrderBookOption {
+	return func(m *ManagedOrderBook) {
+		m.staleAfter = d
+	}
+}
+
+// WithManagedOrderBookResyncTimeout 设置重订阅后等待 snapshot 的超时（默认 10s；超时后再次重订阅，连续超时时等待时间按 2 倍退避，最多 32 倍）。
+func WithManagedOrderBookResyncTimeout(d time.Duration) ManagedOrderBookOption {
+	return func(m *ManagedOrderBook) {
+		if d > 0 {
+			m.resyncTimeout = d
+		}
+	}
+}
+
+// WithManagedOrderBookStoreOptions 设置内部 WSOrderBookStore 的选项（如关闭 checksum 校验）。
+func WithManagedOrderBookStoreOptions(opts ...WSOrderBookStoreOption) ManagedOrderBookOption {
+	return func(m *ManagedOrderBook) {
+		m.storeOpts = append(m.storeOpts, opts...)
+	}
+}
+
+// ManagedOrderBook 在 WSClient 上托管单个产品的深度订阅，并在完整性错误时自愈：
+// 收到 WSOrderBookSequenceError/WSOrderBookChecksumError/WSOrderBookNotReadyError 等错误时，
+// 清空本地深度、取消订阅并重新订阅以获取新的 snapshot，期间可选用 REST 深度作为种子。
+//
+// 调用方只需通过 Snapshot 读取：ready 时返回已校验的一致深度；syncing 时仅返回 REST 种子（或空）。
+//
+// 注意：重订阅会影响同一 WSClient 上相同 channel+instId 的其他订阅者（短暂中断后重新收到 snapshot）。
+type ManagedOrderBook struct {
+	w   *WSClient
+	arg WSArg
+
+	storeOpts     []WSOrderBookStoreOption
+	stateHandler  func(event ManagedOrderBookEvent)
+	seedClient    *Client
+	seedSz        int
+	staleAfter    time.Duration
+	resyncTimeout time.Duration
+
+	mu          sync.RWMutex
+	store       *WSOrderBookStore
+	state       ManagedOrderBookState
+	stateSince  time.Time
+	lastMessage time.Time
+	seed        *WSOrderBookSnapshot
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	started   atomic.Bool
+	closed    bool // 由 mu 保护；置位后不再发起重订阅
+	closeOnce sync.Once
+	closeErr  error
+
+	resyncing atomic.Bool
+	resyncWG  sync.WaitGroup
+	resyncs   atomic.Uint64
+}
+
+// NewManagedOrderBook 创建托管深度；channel 为深度频道（books/books5/bbo-tbt/books-l2-tbt/books50-l2-tbt/books-elp）。
+func NewManagedOrderBook(w *WSClient, channel, instId string, opts ...ManagedOrderBookOption) *ManagedOrderBook {
+	m := &ManagedOrderBook{
+		w:             w,
+		arg:           WSArg{Channel: channel, InstId: instId},
+		resyncTimeout: defaultManagedOrderBookResyncTimeout,
+		state:         ManagedOrderBookSyncing,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.store = NewWSOrderBookStore(channel, instId, m.storeOpts...)
+	return m
+}
+
+// Start 注册并订阅深度频道（WSClient 已启动时等待订阅确认）；ctx 结束时停止托管（不取消订阅，需调用 Close）。
+func (m *ManagedOrderBook) Start(ctx context.Context) error {
+	if m == nil || m.w == nil {
+		return errors.New("okx: managed order book requires ws client")
+	}
+	if ctx == nil {
+		return errors.New("okx: nil context")
+	}
+	if !isOrderBookChannel(m.arg.Channel) {
+		return fmt.Errorf("okx: managed order book invalid channel %q", m.arg.Channel)
+	}
+	if m.arg.InstId == "" {
+		return errors.New("okx: managed order book requires instId")
+	}
+	if !m.started.CompareAndSwap(false, true) {
+		return errors.New("okx: managed order book already started")
+	}
+
+	m.ctx, m.cancel = context.WithCancel(ctx)
+	now := time.Now()
+	m.mu.Lock()
+	m.stateSince = now
+	m.lastMessage = now
+	m.mu.Unlock()
+
+	if err := m.w.subscribeShared(ctx, m); err != nil {
+		m.cancel()
+		return err
+	}
+	if m.seedClient != nil {
+		go m.seedFromREST()
+	}
+	go m.monitor()
+	return nil
+}
+
+// Close 停止托管（取消并等待进行中的重同步）；若已无其他订阅者使用该 arg，则取消订阅。
+func (m *ManagedOrderBook) Close() error {
+	if m == nil || !m.started.Load() {
+		return nil
+	}
+	m.closeOnce.Do(func() {
+		m.mu.Lock()
+		m.closed = true
+		m.mu.Unlock()
+		m.cancel()
+		m.resyncWG.Wait()
+		if m.w.removeSubscriber(m) {
+			m.closeErr = m.w.Unsubscribe(m.arg)
+		}
+	})
+	return m.closeErr
+}
+
+// State 返回当前同步状态。
+func (m *ManagedOrderBook) State() ManagedOrderBookState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state
+}
+
+// Resyncs 返回累计重同步次数。
+func (m *ManagedOrderBook) Resyncs() uint64 {
+	return m.resyncs.Load()
+}
+
+// Snapshot 返回一致的深度快照与当前状态：
+// - ready/stale：返回已校验的本地深度；
+// - syncing：返回 REST 种子（未启用或尚未获取时为空快照）。
+func (m *ManagedOrderBook) Snapshot() (WSOrderBookSnapshot, ManagedOrderBookState) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.state == ManagedOrderBookSyncing {
+		if m.seed == nil {
+			return WSOrderBookSnapshot{Channel: m.arg.Channel, InstId: m.arg.InstId}, m.state
+		}
+		out := *m.seed
+		out.Asks = append([]OrderBookLevel(nil), m.seed.Asks...)
+		out.Bids = append([]OrderBookLevel(nil), m.seed.Bids...)
+		return out, m.state
+	}
+	return m.store.Snapshot(), m.state
+}
+
+func (m *ManagedOrderBook) subscriptionArg() WSArg {
+	return m.arg
+}
+
+// deliver 在 WS read goroutine 中按序应用深度推送；完整性错误时触发重同步。
+func (m *ManagedOrderBook) deliver(message []byte) {
+	if m.ctx == nil || m.ctx.Err() != nil {
+		return
+	}
+	dm, ok, err := WSParseOrderBook(message)
+	if !m.w.wsParseGuard(m.arg.Channel, ok, err) {
+		return
+	}
+
+	m.mu.Lock()
+	m.lastMessage = time.Now()
+	prev := m.state
+	err = m.store.Apply(dm)
+	if err == nil {
+		becameReady := prev != ManagedOrderBookReady && m.store.Ready()
+		if becameReady {
+			m.setStateLocked(ManagedOrderBookReady)
+			m.seed = nil
+		}
+		m.mu.Unlock()
+		if becameReady {
+			m.emit(ManagedOrderBookReady, nil)
+		}
+		return
+	}
+
+	var notReady *WSOrderBookNotReadyError
+	if prev == ManagedOrderBookSyncing && errors.As(err, &notReady) {
+		// 重订阅期间旧订阅的 update 在新 snapshot 到达前会被丢弃。
+		m.mu.Unlock()
+		return
+	}
+	// 在同一临界区内清空，避免读方看到校验失败的深度。
+	m.store.Reset()
+	m.setStateLocked(ManagedOrderBookSyncing)
+	m.mu.Unlock()
+	m.startResync(err, ManagedOrderBookSyncing)
+}
+
+// resync 切换到 syncing（reset=true 时清空本地深度）或 stale，并重新订阅以获取新的 snapshot。
+func (m *ManagedOrderBook) resync(cause error, reset bool) {
+	state := ManagedOrderBookSyncing
+	if !reset {
+		state = ManagedOrderBookStale
+	}
+
+	m.mu.Lock()
+	if reset {
+		m.store.Reset()
+	}
+	m.setStateLocked(state)
+	m.mu.Unlock()
+	m.startResync(cause, state)
+}
+
+func (m *ManagedOrderBook) startResync(cause error, state ManagedOrderBookState) {
+	n := m.resyncs.Add(1)
+	m.w.onError(fmt.Errorf("okx: managed order book resync channel=%s instId=%s: %w", m.arg.Channel, m.arg.InstId, cause))
+	m.emitEvent(ManagedOrderBookEvent{Channel: m.arg.Channel, InstId: m.arg.InstId, State: state, Err: cause, Resyncs: n})
+
+	if !m.resyncing.CompareAndSwap(false, true) {
+		return
+	}
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		m.resyncing.Store(false)
+		return
+	}
+	m.resyncWG.Add(1)
+	m.mu.Unlock()
+
+	go func() {
+		defer m.resyncWG.Done()
+		defer m.resyncing.Store(false)
+		if state == ManagedOrderBookSyncing && m.seedClient != nil {
+			m.seedFromREST()
+		}
+		if m.ctx.Err() != nil {
+			return
+		}
+		if err := m.w.Unsubscribe(m.arg); err != nil {
+			m.w.onError(err)
+		}
+		// 在 mu 内检查 closed 并重订阅：Close 置位后不会再订阅，避免关闭后泄漏订阅。
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.closed || m.ctx.Err() != nil {
+			return
+		}
+		if err := m.w.Subscribe(m.arg); err != nil {
+			m.w.onError(err)
+		}
+	}()
+}
+
+// monitor 检测 snapshot 等待超时与 stale；连续超时的重同步按 resyncTimeout 的 2 倍退避。
+func (m *ManagedOrderBook) monitor() {
+	interval := m.resyncTimeout / 4
+	if m.staleAfter > 0 && m.staleAfter/4 < interval {
+		interval = m.staleAfter / 4
+	}
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// attempts 为自上次 ready 以来 monitor 触发的重同步次数。
+	attempts := 0
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		m.mu.RLock()
+		state := m.state
+		since := m.stateSince
+		last := m.lastMessage
+		m.mu.RUnlock()
+
+		wait := m.resyncTimeout << min(attempts, managedOrderBookMaxBackoffShift)
+		switch {
+		case state != ManagedOrderBookReady && now.Sub(since) > wait:
+			attempts++
+			m.resync(fmt.Errorf("okx: managed order book %s timeout after %s", state, wait), state == ManagedOrderBookSyncing)
+		case state == ManagedOrderBookReady && m.staleAfter > 0 && now.Sub(last) > m.staleAfter:
+			attempts++
+			m.resync(fmt.Errorf("okx: managed order book no message for %s", m.staleAfter), false)
+		case state == ManagedOrderBookReady:
+			attempts = 0
+		}
+	}
+}
+
+func (m *ManagedOrderBook) seedFromREST() {
+	ctx := m.ctx
+	var (
+		book *OrderBook
+		err  error
+	)
+	if m.seedSz > 400 {
+		book, err = m.seedClient.NewMarketBooksFullService().InstId(m.arg.InstId).Sz(m.seedSz).Do(ctx)
+	} else {
+		svc := m.seedClient.NewMarketBooksService().InstId(m.arg.InstId)
+		if m.seedSz > 0 {
+			svc.Sz(m.seedSz)
+		}
+		book, err = svc.Do(ctx)
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			m.w.onError(fmt.Errorf("okx: managed order book rest seed failed instId=%s: %w", m.arg.InstId, err))
+		}
+		return
+	}
+
+	seed := &WSOrderBookSnapshot{
+		Channel: m.arg.Channel,
+		InstId:  m.arg.InstId,
+		TS:      book.TS,
+		Asks:    append([]OrderBookLevel(nil), book.Asks...),
+		Bids:    append([]OrderBookLevel(nil), book.Bids...),
+	}
+	m.mu.Lock()
+	if m.state == ManagedOrderBookSyncing {
+		m.seed = seed
+	}
+	m.mu.Unlock()
+}
+
+func (m *ManagedOrderBook) setStateLocked(state ManagedOrderBookState) {
+	m.state = state
+	m.stateSince = time.Now()
+}
+
+func (m *ManagedOrderBook) emit(state ManagedOrderBookState, err error) {
+	m.emitEvent(ManagedOrderBookEvent{Channel: m.arg.Channel, InstId: m.arg.InstId, State: state, Err: err, Resyncs: m.resyncs.Load()})
+}
+
+func (m *ManagedOrderBook) emitEvent(event ManagedOrderBookEvent) {
+	if m.stateHandler == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			m.w.onError(fmt.Errorf("okx: managed order book state handler panic: %v", r))
+		}
+	}()
+	m.stateHandler(event)
+}
@@ -0,0 +1,219 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestManagedOrderBook_ResyncOnSequenceGap(t *testing.T) {
+	bookMsg := func(action string, prevSeq, seq int64, bidPx string) []byte {
+		bids := []OrderBookLevel{{Px: bidPx, Sz: "1"}}
+		asks := []OrderBookLevel{{Px: "101", Sz: "2"}}
+		cs := wsOrderBookChecksum(bids, asks)
+		return []byte(fmt.Sprintf(`{"arg":{"channel":"books","instId":"BTC-USDT"},"action":"%s","data":[{"asks":[["101","2","0","1"]],"bids":[["%s","1","0","1"]],"ts":"1","checksum":%d,"prevSeqId":%d,"seqId":%d}]}`,
+			action, bidPx, cs, prevSeq, seq))
+	}
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	var opsMu sync.Mutex
+	var ops []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+		subs := 0
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req wsOpRequest
+			if err := json.Unmarshal(msg, &req); err != nil {
+				continue
+			}
+			opsMu.Lock()
+			ops = append(ops, req.Op)
+			opsMu.Unlock()
+			for _, a := range req.Args {
+				b, _ := json.Marshal(WSEvent{ID: req.ID, Event: req.Op, Arg: &a})
+				_ = c.WriteMessage(websocket.TextMessage, b)
+			}
+			if req.Op != "subscribe" {
+				continue
+			}
+			subs++
+			if subs == 1 {
+				_ = c.WriteMessage(websocket.TextMessage, bookMsg("snapshot", -1, 10, "100"))
+				// prevSeqId 不连续：触发重同步。
+				_ = c.WriteMessage(websocket.TextMessage, bookMsg("update", 999, 11, "100"))
+				// 重订阅前旧订阅的后续 update：应被丢弃。
+				_ = c.WriteMessage(websocket.TextMessage, bookMsg("update", 11, 12, "100"))
+				continue
+			}
+			_ = c.WriteMessage(websocket.TextMessage, bookMsg("snapshot", -1, 20, "99"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	wsURL := "ws" + srv.URL[len("http"):]
+
+	var evMu sync.Mutex
+	var events []ManagedOrderBookEvent
+	c := NewClient()
+	ws := c.NewWSPublic(WithWSURL(wsURL))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ws.Start(ctx, nil, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	m := NewManagedOrderBook(ws, WSChannelBooks, "BTC-USDT", WithManagedOrderBookStateHandler(func(ev ManagedOrderBookEvent) {
+		evMu.Lock()
+		events = append(events, ev)
+		evMu.Unlock()
+	}))
+	if err := m.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	waitFor(t, "resynced book", func() bool {
+		snap, state := m.Snapshot()
+		return state == ManagedOrderBookReady && snap.SeqId == 20
+	})
+	snap, _ := m.Snapshot()
+	if len(snap.Bids) != 1 || snap.Bids[0].Px != "99" {
+		t.Fatalf("snapshot = %#v", snap)
+	}
+	if m.Resyncs() != 1 {
+		t.Fatalf("Resyncs() = %d, want 1", m.Resyncs())
+	}
+
+	evMu.Lock()
+	var states []ManagedOrderBookState
+	for _, ev := range events {
+		states = append(states, ev.State)
+	}
+	var seqErr *WSOrderBookSequenceError
+	if len(events) != 3 || !errors.As(events[1].Err, &seqErr) {
+		t.Fatalf("events = %#v", events)
+	}
+	evMu.Unlock()
+	if fmt.Sprint(states) != "[ready syncing ready]" {
+		t.Fatalf("states = %v", states)
+	}
+
+	opsMu.Lock()
+	if fmt.Sprint(ops) != "[subscribe unsubscribe subscribe]" {
+		t.Fatalf("ops = %v", ops)
+	}
+	opsMu.Unlock()
+
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	waitFor(t, "unsubscribe", func() bool {
+		opsMu.Lock()
+		defer opsMu.Unlock()
+		return len(ops) == 4 && ops[3] == "unsubscribe"
+	})
+}
+
+func TestManagedOrderBook_RESTSeedWhileSyncing(t *testing.T) {
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/market/books" || r.URL.Query().Get("instId") != "BTC-USDT" || r.URL.Query().Get("sz") != "5" {
+			t.Errorf("unexpected request %s", r.URL.String())
+		}
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"asks":[["101","1","0","1"]],"bids":[["100","1","0","1"]],"ts":"7"}]}`))
+	}))
+	t.Cleanup(rest.Close)
+
+	c := NewClient(WithBaseURL(rest.URL), WithRequestGateDisabled())
+	ws := c.NewWSPublic()
+	m := NewManagedOrderBook(ws, WSChannelBooks, "BTC-USDT", WithManagedOrderBookRESTSeed(c, 5))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	// WSClient 未启动：仅记录订阅，snapshot 到达前一直处于 syncing。
+	if err := m.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	waitFor(t, "rest seed", func() bool {
+		snap, _ := m.Snapshot()
+		return snap.TS == 7
+	})
+	snap, state := m.Snapshot()
+	if state != ManagedOrderBookSyncing || len(snap.Asks) != 1 || snap.Bids[0].Px != "100" {
+		t.Fatalf("snapshot = %#v state = %s", snap, state)
+	}
+}
+
+func TestManagedOrderBook_CloseCancelsResync(t *testing.T) {
+	release := make(chan struct{})
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"asks":[],"bids":[],"ts":"7"}]}`))
+	}))
+	t.Cleanup(rest.Close)
+	t.Cleanup(func() { close(release) })
+
+	c := NewClient(WithBaseURL(rest.URL), WithRequestGateDisabled())
+	ws := c.NewWSPublic()
+	m := NewManagedOrderBook(ws, WSChannelBooks, "BTC-USDT", WithManagedOrderBookRESTSeed(c, 5))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := m.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	// 重同步阻塞在 REST 种子上时 Close：Close 取消并等待重同步结束，之后不再重订阅。
+	m.resync(errors.New("test"), true)
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if m.resyncing.Load() {
+		t.Fatalf("resync still running after Close")
+	}
+	m.resync(errors.New("after close"), true)
+	time.Sleep(50 * time.Millisecond)
+
+	ws.mu.Lock()
+	_, subscribed := ws.desired[m.arg.key()]
+	ws.mu.Unlock()
+	if subscribed {
+		t.Fatalf("arg re-subscribed after Close")
+	}
+}
+
+func TestManagedOrderBook_ResyncBackoff(t *testing.T) {
+	c := NewClient()
+	ws := c.NewWSPublic()
+	m := NewManagedOrderBook(ws, WSChannelBooks, "BTC-USDT", WithManagedOrderBookResyncTimeout(20*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	// WSClient 未启动：snapshot 永不到达，monitor 反复超时重同步。
+	if err := m.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	time.Sleep(400 * time.Millisecond)
+	_ = m.Close()
+
+	// 无退避时约 400/20=20 次；退避后为 20+40+80+160ms 内的 4 次左右。
+	if n := m.Resyncs(); n == 0 || n > 5 {
+		t.Fatalf("resyncs = %d, want 1..5", n)
+	}
+}
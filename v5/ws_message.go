@@ -175,12 +175,13 @@ type WSADLWarning struct {
 }
 
 // WSParseChannelData 解析指定 channel 的 data 推送消息。
+// channel 为精确频道名，或以 * 结尾的前缀（如 "candle*" 匹配 candle1m/candle1H 等）。
 func WSParseChannelData[T any](message []byte, channel string) (*WSData[T], bool, error) {
 	dm, ok, err := WSParseData[T](message)
 	if err != nil || !ok {
 		return nil, ok, err
 	}
-	if dm.Arg.Channel != channel {
+	if !wsChannelPatternMatch(channel, dm.Arg.Channel) {
 		return nil, false, nil
 	}
 	return dm, true, nil
@@ -700,6 +701,9 @@ type WSFill struct {
 
 	ExecType string `json:"execType"`
 	Count    string `json:"count"`
+
+	// Recovered 为 true 表示该条由 WS 断线补偿（WithWSGapFill）经 REST 查询补发，而非实时推送。
+	Recovered bool `json:"-"`
 }
 
 // WSDepositInfo 表示充值信息推送（deposit-info）。
@@ -0,0 +1,379 @@
+package okx
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// wsOrderBookAnalyticsScale 为派生价格/数量（mid、microprice、VWAP 等）格式化时的最大小数位数（去除末尾 0）。
+const wsOrderBookAnalyticsScale = 16
+
+// WSOrderBookDepth 为某一方向在指定价格范围内的累计深度。
+type WSOrderBookDepth struct {
+	Side string
+	// Px 为范围边界价格（买盘为下界，卖盘为上界）。
+	Px       string
+	Sz       string
+	Notional string
+	Levels   int
+}
+
+// WSOrderBookExecution 为按当前快照吃单成交指定数量的估算结果（不考虑成交过程中的盘口变化）。
+type WSOrderBookExecution struct {
+	Side string
+	Sz   string
+
+	// FilledSz 为可成交数量；Complete=false 表示当前深度不足以成交全部 Sz。
+	FilledSz string
+	Complete bool
+
+	Notional string
+	VWAP     string
+	WorstPx  string
+	Levels   int
+
+	// SlippageBps 为 VWAP 相对同方向最优价的不利偏离（bps，>=0）；ImpactBps 为 VWAP 相对 mid 的不利偏离（无 mid 时为 0）。
+	SlippageBps float64
+	ImpactBps   float64
+}
+
+// BestBid 返回最优买价档位；买盘为空时 ok=false。
+func (s WSOrderBookSnapshot) BestBid() (OrderBookLevel, bool) {
+	if len(s.Bids) == 0 {
+		return OrderBookLevel{}, false
+	}
+	return s.Bids[0], true
+}
+
+// BestAsk 返回最优卖价档位；卖盘为空时 ok=false。
+func (s WSOrderBookSnapshot) BestAsk() (OrderBookLevel, bool) {
+	if len(s.Asks) == 0 {
+		return OrderBookLevel{}, false
+	}
+	return s.Asks[0], true
+}
+
+// Mid 返回中间价 (bestBid+bestAsk)/2；任一方向为空时 ok=false。
+func (s WSOrderBookSnapshot) Mid() (string, bool) {
+	mid, ok := s.midRat()
+	if !ok {
+		return "", false
+	}
+	return formatDecimal(mid, wsOrderBookAnalyticsScale), true
+}
+
+// Microprice 返回按最优档数量加权的微观价格：(bidPx*askSz + askPx*bidSz)/(bidSz+askSz)。
+func (s WSOrderBookSnapshot) Microprice() (string, bool) {
+	bid, ask, ok := s.bestRats()
+	if !ok {
+		return "", false
+	}
+	bidSz, err1 := parseDecimal(s.Bids[0].Sz)
+	askSz, err2 := parseDecimal(s.Asks[0].Sz)
+	if err1 != nil || err2 != nil {
+		return "", false
+	}
+	total := new(big.Rat).Add(bidSz, askSz)
+	if total.Sign() <= 0 {
+		return "", false
+	}
+	v := new(big.Rat).Mul(bid, askSz)
+	v.Add(v, new(big.Rat).Mul(ask, bidSz))
+	v.Quo(v, total)
+	return formatDecimal(v, wsOrderBookAnalyticsScale), true
+}
+
+// SpreadBps 返回买卖价差相对 mid 的 bps：(bestAsk-bestBid)/mid*10000。
+func (s WSOrderBookSnapshot) SpreadBps() (float64, bool) {
+	bid, ask, ok := s.bestRats()
+	if !ok {
+		return 0, false
+	}
+	mid := new(big.Rat).Add(bid, ask)
+	mid.Quo(mid, big.NewRat(2, 1))
+	if mid.Sign() == 0 {
+		return 0, false
+	}
+	return deviationBps(ask, mid) - deviationBps(bid, mid), true
+}
+
+// DepthWithinBps 返回 side（WSOrderBookSideBid/WSOrderBookSideAsk）在 mid 上下 bps 范围内的累计深度；无 mid 时 ok=false。
+func (s WSOrderBookSnapshot) DepthWithinBps(side string, bps float64) (WSOrderBookDepth, bool) {
+	mid, ok := s.midRat()
+	if !ok || bps < 0 {
+		return WSOrderBookDepth{}, false
+	}
+	ratio := new(big.Rat).SetFloat64(bps / 10000)
+	if ratio == nil {
+		return WSOrderBookDepth{}, false
+	}
+
+	var levels []OrderBookLevel
+	limit := new(big.Rat).Mul(mid, ratio)
+	switch side {
+	case WSOrderBookSideBid:
+		levels = s.Bids
+		limit.Sub(mid, limit)
+	case WSOrderBookSideAsk:
+		levels = s.Asks
+		limit.Add(mid, limit)
+	default:
+		return WSOrderBookDepth{}, false
+	}
+
+	sz, notional := new(big.Rat), new(big.Rat)
+	out := WSOrderBookDepth{Side: side, Px: formatDecimal(limit, wsOrderBookAnalyticsScale)}
+	for _, l := range levels {
+		px, err := parseDecimal(l.Px)
+		if err != nil {
+			return WSOrderBookDepth{}, false
+		}
+		if side == WSOrderBookSideBid && px.Cmp(limit) < 0 || side == WSOrderBookSideAsk && px.Cmp(limit) > 0 {
+			break
+		}
+		lsz, err := parseDecimal(l.Sz)
+		if err != nil {
+			return WSOrderBookDepth{}, false
+		}
+		sz.Add(sz, lsz)
+		notional.Add(notional, new(big.Rat).Mul(px, lsz))
+		out.Levels++
+	}
+	out.Sz = formatDecimal(sz, wsOrderBookAnalyticsScale)
+	out.Notional = formatDecimal(notional, wsOrderBookAnalyticsScale)
+	return out, true
+}
+
+// ExecutionCost 估算以市价吃单成交 sz 的成本：side="buy" 吃卖盘，side="sell" 吃买盘。
+//
+// 深度不足时不返回错误，而是 Complete=false 且 FilledSz/Notional/VWAP 为可成交部分的结果。
+func (s WSOrderBookSnapshot) ExecutionCost(side, sz string) (WSOrderBookExecution, error) {
+	var levels []OrderBookLevel
+	switch side {
+	case "buy":
+		levels = s.Asks
+	case "sell":
+		levels = s.Bids
+	default:
+		return WSOrderBookExecution{}, errors.New("okx: invalid order book execution side " + side)
+	}
+	want, err := parseDecimal(sz)
+	if err != nil {
+		return WSOrderBookExecution{}, err
+	}
+	if want.Sign() <= 0 {
+		return WSOrderBookExecution{}, errors.New("okx: order book execution sz must be > 0")
+	}
+	if len(levels) == 0 {
+		return WSOrderBookExecution{}, errors.New("okx: order book " + side + " side has no liquidity")
+	}
+
+	out := WSOrderBookExecution{Side: side, Sz: strings.TrimSpace(sz)}
+	remaining := new(big.Rat).Set(want)
+	filled, notional := new(big.Rat), new(big.Rat)
+	var best *big.Rat
+	for _, l := range levels {
+		if remaining.Sign() <= 0 {
+			break
+		}
+		px, err := parseDecimal(l.Px)
+		if err != nil {
+			return WSOrderBookExecution{}, err
+		}
+		lsz, err := parseDecimal(l.Sz)
+		if err != nil {
+			return WSOrderBookExecution{}, err
+		}
+		if best == nil {
+			best = px
+		}
+		take := lsz
+		if take.Cmp(remaining) > 0 {
+			take = remaining
+		}
+		filled.Add(filled, take)
+		notional.Add(notional, new(big.Rat).Mul(px, take))
+		remaining = new(big.Rat).Sub(remaining, take)
+		out.WorstPx = l.Px
+		out.Levels++
+	}
+
+	out.FilledSz = formatDecimal(filled, wsOrderBookAnalyticsScale)
+	out.Notional = formatDecimal(notional, wsOrderBookAnalyticsScale)
+	out.Complete = remaining.Sign() <= 0
+	if filled.Sign() == 0 {
+		return out, nil
+	}
+	vwap := new(big.Rat).Quo(notional, filled)
+	out.VWAP = formatDecimal(vwap, wsOrderBookAnalyticsScale)
+
+	sign := 1.0
+	if side == "sell" {
+		sign = -1
+	}
+	out.SlippageBps = sign * deviationBps(vwap, best)
+	if mid, ok := s.midRat(); ok {
+		out.ImpactBps = sign * deviationBps(vwap, mid)
+	}
+	return out, nil
+}
+
+// VWAP 返回以市价吃单成交 sz 的成交均价；参数非法或深度不足以成交全部 sz 时 ok=false。
+func (s WSOrderBookSnapshot) VWAP(side, sz string) (string, bool) {
+	exec, err := s.ExecutionCost(side, sz)
+	if err != nil || !exec.Complete {
+		return "", false
+	}
+	return exec.VWAP, true
+}
+
+// Imbalance 返回前 levels 档（levels<=0 表示全部档位）的盘口不平衡度 (bidSz-askSz)/(bidSz+askSz)，取值 [-1,1]。
+func (s WSOrderBookSnapshot) Imbalance(levels int) (float64, bool) {
+	bidSz, ok1 := sumOrderBookSz(topOrderBookLevels(s.Bids, levels))
+	askSz, ok2 := sumOrderBookSz(topOrderBookLevels(s.Asks, levels))
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	total := new(big.Rat).Add(bidSz, askSz)
+	if total.Sign() == 0 {
+		return 0, false
+	}
+	v := new(big.Rat).Sub(bidSz, askSz)
+	v.Quo(v, total)
+	f, _ := v.Float64()
+	return f, true
+}
+
+func (s WSOrderBookSnapshot) bestRats() (bid, ask *big.Rat, ok bool) {
+	if len(s.Bids) == 0 || len(s.Asks) == 0 {
+		return nil, nil, false
+	}
+	bid, err := parseDecimal(s.Bids[0].Px)
+	if err != nil {
+		return nil, nil, false
+	}
+	ask, err = parseDecimal(s.Asks[0].Px)
+	if err != nil {
+		return nil, nil, false
+	}
+	return bid, ask, true
+}
+
+func (s WSOrderBookSnapshot) midRat() (*big.Rat, bool) {
+	bid, ask, ok := s.bestRats()
+	if !ok {
+		return nil, false
+	}
+	mid := new(big.Rat).Add(bid, ask)
+	return mid.Quo(mid, big.NewRat(2, 1)), true
+}
+
+func sumOrderBookSz(levels []OrderBookLevel) (*big.Rat, bool) {
+	sum := new(big.Rat)
+	for _, l := range levels {
+		sz, err := parseDecimal(l.Sz)
+		if err != nil {
+			return nil, false
+		}
+		sum.Add(sum, sz)
+	}
+	return sum, true
+}
+
+// WSOrderFlowImbalance 计算两份连续快照之间前 levels 档（levels<=0 视为 1）的订单流不平衡（OFI，Cont et al. 定义，按档位求和）。
+//
+// 正值表示买方压力（买盘增加/卖盘减少），负值表示卖方压力；单位与 Sz 相同。任一快照缺少对应档位时该档位不计入。
+func WSOrderFlowImbalance(prev, curr *WSOrderBookSnapshot, levels int) (float64, bool) {
+	if prev == nil || curr == nil {
+		return 0, false
+	}
+	if levels <= 0 {
+		levels = 1
+	}
+	ofi := new(big.Rat)
+	counted := false
+	for i := 0; i < levels; i++ {
+		if i < len(prev.Bids) && i < len(curr.Bids) {
+			e, ok := orderFlowLevelContribution(prev.Bids[i], curr.Bids[i], true)
+			if !ok {
+				return 0, false
+			}
+			ofi.Add(ofi, e)
+			counted = true
+		}
+		if i < len(prev.Asks) && i < len(curr.Asks) {
+			e, ok := orderFlowLevelContribution(prev.Asks[i], curr.Asks[i], false)
+			if !ok {
+				return 0, false
+			}
+			ofi.Sub(ofi, e)
+			counted = true
+		}
+	}
+	if !counted {
+		return 0, false
+	}
+	f, _ := ofi.Float64()
+	return f, true
+}
+
+// orderFlowLevelContribution 返回单档的流量贡献：价格改善时计入新数量，价格恶化时扣除旧数量，价格不变时取数量差。
+func orderFlowLevelContribution(prev, curr OrderBookLevel, bids bool) (*big.Rat, bool) {
+	prevSz, err1 := parseDecimal(prev.Sz)
+	currSz, err2 := parseDecimal(curr.Sz)
+	if err1 != nil || err2 != nil {
+		return nil, false
+	}
+	cmp := compareDecimalString(curr.Px, prev.Px)
+	if !bids {
+		cmp = -cmp
+	}
+	switch {
+	case cmp > 0:
+		return currSz, true
+	case cmp < 0:
+		return new(big.Rat).Neg(prevSz), true
+	default:
+		return new(big.Rat).Sub(currSz, prevSz), true
+	}
+}
+
+// WSOrderFlowImbalanceEvent 为 OFI 流中的一条记录（相邻两次快照之间）。
+type WSOrderFlowImbalanceEvent struct {
+	Channel string
+	InstId  string
+	SprdId  string
+	TS      int64
+	SeqId   int64
+	Levels  int
+
+	OFI float64
+}
+
+// SubscribeOrderFlowImbalance 订阅各产品前 levels 档的 OFI 流：前 levels 档变化时按相邻两次快照计算并回调；返回取消函数。
+//
+// 回调执行语义同 Subscribe（在 Apply 的调用 goroutine 中同步执行）。
+func (m *WSOrderBookManager) SubscribeOrderFlowImbalance(levels int, handler func(ev WSOrderFlowImbalanceEvent)) (cancel func()) {
+	if m == nil || handler == nil {
+		return func() {}
+	}
+	if levels <= 0 {
+		levels = 1
+	}
+	return m.Subscribe(levels, func(change WSOrderBookChange) {
+		ofi, ok := WSOrderFlowImbalance(change.Prev, change.Curr, levels)
+		if !ok {
+			return
+		}
+		handler(WSOrderFlowImbalanceEvent{
+			Channel: change.Channel,
+			InstId:  change.InstId,
+			SprdId:  change.SprdId,
+			TS:      change.Curr.TS,
+			SeqId:   change.Curr.SeqId,
+			Levels:  levels,
+			OFI:     ofi,
+		})
+	})
+}
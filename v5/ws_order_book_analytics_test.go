@@ -0,0 +1,201 @@
+package okx
+
+import (
+	"math"
+	"testing"
+)
+
+func analyticsTestSnapshot() WSOrderBookSnapshot {
+	return WSOrderBookSnapshot{
+		Channel: WSChannelBooks,
+		InstId:  "BTC-USDT",
+		Bids: []OrderBookLevel{
+			{Px: "100", Sz: "1"},
+			{Px: "99.9", Sz: "2"},
+			{Px: "99", Sz: "5"},
+		},
+		Asks: []OrderBookLevel{
+			{Px: "100.1", Sz: "3"},
+			{Px: "100.2", Sz: "1"},
+			{Px: "101", Sz: "4"},
+		},
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestWSOrderBookSnapshot_TopOfBook(t *testing.T) {
+	s := analyticsTestSnapshot()
+
+	if bid, ok := s.BestBid(); !ok || bid.Px != "100" {
+		t.Fatalf("BestBid() = %v,%v", bid, ok)
+	}
+	if ask, ok := s.BestAsk(); !ok || ask.Px != "100.1" {
+		t.Fatalf("BestAsk() = %v,%v", ask, ok)
+	}
+	if mid, ok := s.Mid(); !ok || mid != "100.05" {
+		t.Fatalf("Mid() = %q,%v", mid, ok)
+	}
+	// (100*3 + 100.1*1) / 4 = 100.025
+	if mp, ok := s.Microprice(); !ok || mp != "100.025" {
+		t.Fatalf("Microprice() = %q,%v", mp, ok)
+	}
+	if bps, ok := s.SpreadBps(); !ok || !approxEqual(bps, 0.1/100.05*10000) {
+		t.Fatalf("SpreadBps() = %v,%v", bps, ok)
+	}
+
+	empty := WSOrderBookSnapshot{Bids: s.Bids}
+	if _, ok := empty.Mid(); ok {
+		t.Fatalf("Mid() on one-sided book should be !ok")
+	}
+	if _, ok := empty.SpreadBps(); ok {
+		t.Fatalf("SpreadBps() on one-sided book should be !ok")
+	}
+}
+
+func TestWSOrderBookSnapshot_DepthWithinBps(t *testing.T) {
+	s := analyticsTestSnapshot()
+
+	// mid=100.05；20bps 范围为 [99.8499, 100.2501]。
+	bid, ok := s.DepthWithinBps(WSOrderBookSideBid, 20)
+	if !ok || bid.Sz != "3" || bid.Notional != "299.8" || bid.Levels != 2 || bid.Px != "99.8499" {
+		t.Fatalf("bid depth = %#v,%v", bid, ok)
+	}
+	ask, ok := s.DepthWithinBps(WSOrderBookSideAsk, 20)
+	if !ok || ask.Sz != "4" || ask.Notional != "400.5" || ask.Levels != 2 || ask.Px != "100.2501" {
+		t.Fatalf("ask depth = %#v,%v", ask, ok)
+	}
+	if _, ok := s.DepthWithinBps("buy", 20); ok {
+		t.Fatalf("invalid side should be !ok")
+	}
+}
+
+func TestWSOrderBookSnapshot_ExecutionCost(t *testing.T) {
+	s := analyticsTestSnapshot()
+
+	exec, err := s.ExecutionCost("buy", "4")
+	if err != nil {
+		t.Fatalf("ExecutionCost() error = %v", err)
+	}
+	// 3@100.1 + 1@100.2 = 400.5，VWAP=100.125。
+	if !exec.Complete || exec.FilledSz != "4" || exec.Notional != "400.5" || exec.VWAP != "100.125" || exec.WorstPx != "100.2" || exec.Levels != 2 {
+		t.Fatalf("exec = %#v", exec)
+	}
+	if !approxEqual(exec.SlippageBps, 0.025/100.1*10000) || !approxEqual(exec.ImpactBps, 0.075/100.05*10000) {
+		t.Fatalf("bps = %v/%v", exec.SlippageBps, exec.ImpactBps)
+	}
+
+	sell, err := s.ExecutionCost("sell", "2")
+	if err != nil {
+		t.Fatalf("ExecutionCost() error = %v", err)
+	}
+	// 1@100 + 1@99.9 = 199.9，VWAP=99.95；卖出方向的不利偏离为正。
+	if sell.VWAP != "99.95" || sell.SlippageBps <= 0 || !approxEqual(sell.SlippageBps, 0.05/100*10000) {
+		t.Fatalf("sell = %#v", sell)
+	}
+
+	partial, err := s.ExecutionCost("buy", "10")
+	if err != nil {
+		t.Fatalf("ExecutionCost() error = %v", err)
+	}
+	if partial.Complete || partial.FilledSz != "8" || partial.WorstPx != "101" {
+		t.Fatalf("partial = %#v", partial)
+	}
+	if _, ok := s.VWAP("buy", "10"); ok {
+		t.Fatalf("VWAP() with insufficient depth should be !ok")
+	}
+	if v, ok := s.VWAP("sell", "2"); !ok || v != "99.95" {
+		t.Fatalf("VWAP() = %q,%v", v, ok)
+	}
+
+	if _, err := s.ExecutionCost("bid", "1"); err == nil {
+		t.Fatalf("expected invalid side error")
+	}
+	if _, err := s.ExecutionCost("buy", "0"); err == nil {
+		t.Fatalf("expected invalid sz error")
+	}
+	if _, err := (WSOrderBookSnapshot{}).ExecutionCost("buy", "1"); err == nil {
+		t.Fatalf("expected empty book error")
+	}
+}
+
+func TestWSOrderBookSnapshot_Imbalance(t *testing.T) {
+	s := analyticsTestSnapshot()
+
+	if v, ok := s.Imbalance(1); !ok || !approxEqual(v, -0.5) {
+		t.Fatalf("Imbalance(1) = %v,%v", v, ok)
+	}
+	if v, ok := s.Imbalance(0); !ok || !approxEqual(v, 0) {
+		t.Fatalf("Imbalance(0) = %v,%v", v, ok)
+	}
+	if _, ok := (WSOrderBookSnapshot{}).Imbalance(5); ok {
+		t.Fatalf("Imbalance() on empty book should be !ok")
+	}
+}
+
+func TestWSOrderFlowImbalance(t *testing.T) {
+	prev := analyticsTestSnapshot()
+
+	cases := []struct {
+		name       string
+		bids, asks []OrderBookLevel
+		want       float64
+	}{
+		{"bid size up", []OrderBookLevel{{Px: "100", Sz: "3"}}, prev.Asks[:1], 2},
+		{"bid price up", []OrderBookLevel{{Px: "100.05", Sz: "0.5"}}, prev.Asks[:1], 0.5},
+		{"bid price down", []OrderBookLevel{{Px: "99.9", Sz: "2"}}, prev.Asks[:1], -1},
+		{"ask size up", prev.Bids[:1], []OrderBookLevel{{Px: "100.1", Sz: "5"}}, -2},
+		{"ask price up", prev.Bids[:1], []OrderBookLevel{{Px: "100.2", Sz: "1"}}, 3},
+		{"ask price down", prev.Bids[:1], []OrderBookLevel{{Px: "100.05", Sz: "1"}}, -1},
+	}
+	for _, tc := range cases {
+		curr := WSOrderBookSnapshot{Bids: tc.bids, Asks: tc.asks}
+		got, ok := WSOrderFlowImbalance(&prev, &curr, 1)
+		if !ok || !approxEqual(got, tc.want) {
+			t.Fatalf("%s: OFI = %v,%v want %v", tc.name, got, ok, tc.want)
+		}
+	}
+
+	if _, ok := WSOrderFlowImbalance(nil, &prev, 1); ok {
+		t.Fatalf("OFI with nil prev should be !ok")
+	}
+}
+
+func TestWSOrderBookManager_SubscribeOrderFlowImbalance(t *testing.T) {
+	m := NewWSOrderBookManager(WithWSOrderBookManagerStoreOptions(WithWSOrderBookVerifyChecksum(false), WithWSOrderBookVerifySequence(false)))
+	var events []WSOrderFlowImbalanceEvent
+	cancel := m.SubscribeOrderFlowImbalance(1, func(ev WSOrderFlowImbalanceEvent) {
+		events = append(events, ev)
+	})
+	defer cancel()
+
+	arg := WSArg{Channel: WSChannelBooks, InstId: "BTC-USDT"}
+	apply := func(action string, seq int64, data WSOrderBook) {
+		t.Helper()
+		data.SeqId = seq
+		if err := m.Apply(&WSData[WSOrderBook]{Arg: arg, Action: action, Data: []WSOrderBook{data}}); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+	}
+	apply("snapshot", 1, WSOrderBook{
+		Bids: []OrderBookLevel{{Px: "100", Sz: "1"}},
+		Asks: []OrderBookLevel{{Px: "101", Sz: "1"}},
+	})
+	apply("update", 2, WSOrderBook{Bids: []OrderBookLevel{{Px: "100", Sz: "4"}}})
+	// 前 1 档之外的变化不触发。
+	apply("update", 3, WSOrderBook{Asks: []OrderBookLevel{{Px: "102", Sz: "1"}}})
+	apply("update", 4, WSOrderBook{Asks: []OrderBookLevel{{Px: "101", Sz: "0"}}})
+
+	if len(events) != 2 {
+		t.Fatalf("events = %#v", events)
+	}
+	if events[0].SeqId != 2 || events[0].InstId != "BTC-USDT" || !approxEqual(events[0].OFI, 3) {
+		t.Fatalf("events[0] = %#v", events[0])
+	}
+	// 卖一从 101 撤单后变为 102：卖价上移，计入 +1。
+	if events[1].SeqId != 4 || !approxEqual(events[1].OFI, 1) {
+		t.Fatalf("events[1] = %#v", events[1])
+	}
+}
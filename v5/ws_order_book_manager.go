@@ -0,0 +1,346 @@
+package okx
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// WSOrderBookSideBid 表示买盘。
+	WSOrderBookSideBid = "bid"
+	// WSOrderBookSideAsk 表示卖盘。
+	WSOrderBookSideAsk = "ask"
+)
+
+// WSOrderBookLevelChange 为前 N 档中一个价位的变化（Sz="0" 表示该价位已移出前 N 档或被删除）。
+type WSOrderBookLevelChange struct {
+	Side   string
+	Px     string
+	PrevSz string
+	Sz     string
+}
+
+// WSOrderBookChange 为“深度变化”通知：仅当订阅者关心的前 N 档发生变化时触发。
+type WSOrderBookChange struct {
+	Channel string
+	InstId  string
+	SprdId  string
+	TopN    int
+
+	// Prev 为上一版快照（首次为 nil）；Curr 为当前快照。两者均为只读共享数据，请勿修改。
+	Prev *WSOrderBookSnapshot
+	Curr *WSOrderBookSnapshot
+
+	// Changes 为前 N 档内按买/卖、价位的变化（买盘在前）。
+	Changes []WSOrderBookLevelChange
+}
+
+// WSOrderBookManagerOption 用于配置 WSOrderBookManager。
+type WSOrderBookManagerOption func(*WSOrderBookManager)
+
+// WithWSOrderBookManagerStoreOptions 设置按需创建的 WSOrderBookStore 的选项。
+func WithWSOrderBookManagerStoreOptions(opts ...WSOrderBookStoreOption) WSOrderBookManagerOption {
+	return func(m *WSOrderBookManager) {
+		m.storeOpts = append(m.storeOpts, opts...)
+	}
+}
+
+// WithWSOrderBookManagerErrorHandler 设置 Handler() 路由时的错误回调（如 WSOrderBookSequenceError/WSOrderBookChecksumError）。
+func WithWSOrderBookManagerErrorHandler(handler func(err error)) WSOrderBookManagerOption {
+	return func(m *WSOrderBookManager) {
+		m.errHandler = handler
+	}
+}
+
+type wsOrderBookKey struct {
+	channel string
+	instId  string
+	sprdId  string
+}
+
+type wsOrderBookEntry struct {
+	mu    sync.Mutex
+	store *WSOrderBookStore
+	snap  atomic.Pointer[WSOrderBookSnapshot]
+}
+
+type wsOrderBookSubscriber struct {
+	topN    int
+	handler func(change WSOrderBookChange)
+}
+
+// WSOrderBookManager 按 (channel, instId/sprdId) 按需创建 WSOrderBookStore，并将深度推送路由到对应 store。
+//
+// 读取为无锁的 copy-on-write：每次成功应用推送后发布一份新的只读快照，Snapshot 直接返回该快照指针。
+// 可通过 Subscribe 订阅“深度变化”通知（仅当前 N 档变化时回调）。
+//
+// 典型用法：ws.OnOrderBook(mgr.Handler())
+type WSOrderBookManager struct {
+	storeOpts  []WSOrderBookStoreOption
+	errHandler func(err error)
+
+	mu    sync.Mutex
+	books atomic.Pointer[map[wsOrderBookKey]*wsOrderBookEntry]
+
+	subMu sync.Mutex
+	subs  atomic.Pointer[[]*wsOrderBookSubscriber]
+}
+
+// NewWSOrderBookManager 创建多产品深度管理器。
+func NewWSOrderBookManager(opts ...WSOrderBookManagerOption) *WSOrderBookManager {
+	m := &WSOrderBookManager{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	books := make(map[wsOrderBookKey]*wsOrderBookEntry)
+	m.books.Store(&books)
+	return m
+}
+
+// Handler 返回可直接用于 WSClient.OnOrderBook / WithWSOrderBookHandler 的回调；Apply 的错误通过 WithWSOrderBookManagerErrorHandler 上报。
+func (m *WSOrderBookManager) Handler() func(data WSData[WSOrderBook]) {
+	return func(data WSData[WSOrderBook]) {
+		if err := m.Apply(&data); err != nil && m.errHandler != nil {
+			m.errHandler(err)
+		}
+	}
+}
+
+// Apply 将一条深度推送路由到对应 store 并发布新快照；错误时该产品的快照被清空（Snapshot 返回 ok=false），等待下一条 snapshot。
+func (m *WSOrderBookManager) Apply(dm *WSData[WSOrderBook]) error {
+	if m == nil {
+		return errors.New("okx: nil ws order book manager")
+	}
+	if dm == nil {
+		return errors.New("okx: nil ws order book data")
+	}
+	if !isOrderBookChannel(dm.Arg.Channel) {
+		return errors.New("okx: ws order book manager invalid channel " + dm.Arg.Channel)
+	}
+
+	key := wsOrderBookKey{channel: dm.Arg.Channel, instId: dm.Arg.InstId, sprdId: dm.Arg.SprdId}
+	e := m.entry(key)
+
+	e.mu.Lock()
+	if err := e.store.Apply(dm); err != nil {
+		e.store.Reset()
+		e.snap.Store(nil)
+		e.mu.Unlock()
+		return err
+	}
+	prev := e.snap.Load()
+	curr := e.store.Snapshot()
+	e.snap.Store(&curr)
+	e.mu.Unlock()
+
+	m.notify(key, prev, &curr)
+	return nil
+}
+
+// Snapshot 返回指定产品的最新快照（无锁）；instId 与 sprdId 二选一（Spread 频道使用 sprdId）。
+//
+// 返回的快照为只读共享数据，请勿修改；ok=false 表示尚未同步（未收到 snapshot 或刚发生校验错误）。
+func (m *WSOrderBookManager) Snapshot(channel, instIdOrSprdId string) (*WSOrderBookSnapshot, bool) {
+	if m == nil {
+		return nil, false
+	}
+	books := *m.books.Load()
+	e := books[wsOrderBookKey{channel: channel, instId: instIdOrSprdId}]
+	if e == nil {
+		e = books[wsOrderBookKey{channel: channel, sprdId: instIdOrSprdId}]
+	}
+	if e == nil {
+		return nil, false
+	}
+	snap := e.snap.Load()
+	return snap, snap != nil
+}
+
+// Snapshots 返回所有已同步产品的最新快照（无锁；只读共享数据）。
+func (m *WSOrderBookManager) Snapshots() []*WSOrderBookSnapshot {
+	if m == nil {
+		return nil
+	}
+	books := *m.books.Load()
+	out := make([]*WSOrderBookSnapshot, 0, len(books))
+	for _, e := range books {
+		if snap := e.snap.Load(); snap != nil {
+			out = append(out, snap)
+		}
+	}
+	return out
+}
+
+// Len 返回已创建的 store 数量。
+func (m *WSOrderBookManager) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(*m.books.Load())
+}
+
+// Remove 移除指定产品的 store（如取消订阅后）。
+func (m *WSOrderBookManager) Remove(channel, instIdOrSprdId string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	old := *m.books.Load()
+	next := make(map[wsOrderBookKey]*wsOrderBookEntry, len(old))
+	for k, e := range old {
+		if k.channel == channel && (k.instId == instIdOrSprdId || k.sprdId == instIdOrSprdId) {
+			continue
+		}
+		next[k] = e
+	}
+	m.books.Store(&next)
+}
+
+// Subscribe 订阅深度变化通知：仅当某产品前 topN 档（topN<=0 表示全部档位）发生变化时回调；返回取消函数。
+//
+// 回调在 Apply 的调用 goroutine 中同步执行（通常为 typed handler worker），应保持轻量。
+func (m *WSOrderBookManager) Subscribe(topN int, handler func(change WSOrderBookChange)) (cancel func()) {
+	if m == nil || handler == nil {
+		return func() {}
+	}
+	sub := &wsOrderBookSubscriber{topN: topN, handler: handler}
+
+	m.subMu.Lock()
+	var next []*wsOrderBookSubscriber
+	if cur := m.subs.Load(); cur != nil {
+		next = append(next, *cur...)
+	}
+	next = append(next, sub)
+	m.subs.Store(&next)
+	m.subMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.subMu.Lock()
+			defer m.subMu.Unlock()
+			cur := m.subs.Load()
+			if cur == nil {
+				return
+			}
+			next := make([]*wsOrderBookSubscriber, 0, len(*cur))
+			for _, s := range *cur {
+				if s != sub {
+					next = append(next, s)
+				}
+			}
+			m.subs.Store(&next)
+		})
+	}
+}
+
+func (m *WSOrderBookManager) entry(key wsOrderBookKey) *wsOrderBookEntry {
+	if e := (*m.books.Load())[key]; e != nil {
+		return e
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	old := *m.books.Load()
+	if e := old[key]; e != nil {
+		return e
+	}
+	var store *WSOrderBookStore
+	if key.sprdId != "" {
+		store = NewWSSprdOrderBookStore(key.channel, key.sprdId, m.storeOpts...)
+	} else {
+		store = NewWSOrderBookStore(key.channel, key.instId, m.storeOpts...)
+	}
+	e := &wsOrderBookEntry{store: store}
+
+	next := make(map[wsOrderBookKey]*wsOrderBookEntry, len(old)+1)
+	for k, v := range old {
+		next[k] = v
+	}
+	next[key] = e
+	m.books.Store(&next)
+	return e
+}
+
+func (m *WSOrderBookManager) notify(key wsOrderBookKey, prev, curr *WSOrderBookSnapshot) {
+	subs := m.subs.Load()
+	if subs == nil || len(*subs) == 0 {
+		return
+	}
+	for _, s := range *subs {
+		changes := diffOrderBookTopN(prev, curr, s.topN)
+		if len(changes) == 0 {
+			continue
+		}
+		s.handler(WSOrderBookChange{
+			Channel: key.channel,
+			InstId:  key.instId,
+			SprdId:  key.sprdId,
+			TopN:    s.topN,
+			Prev:    prev,
+			Curr:    curr,
+			Changes: changes,
+		})
+	}
+}
+
+// diffOrderBookTopN 比较两份快照前 n 档（n<=0 表示全部）的价位/数量变化。
+func diffOrderBookTopN(prev, curr *WSOrderBookSnapshot, n int) []WSOrderBookLevelChange {
+	var prevBids, prevAsks []OrderBookLevel
+	if prev != nil {
+		prevBids, prevAsks = topOrderBookLevels(prev.Bids, n), topOrderBookLevels(prev.Asks, n)
+	}
+	var currBids, currAsks []OrderBookLevel
+	if curr != nil {
+		currBids, currAsks = topOrderBookLevels(curr.Bids, n), topOrderBookLevels(curr.Asks, n)
+	}
+
+	var out []WSOrderBookLevelChange
+	out = diffOrderBookSide(out, WSOrderBookSideBid, prevBids, currBids, true)
+	out = diffOrderBookSide(out, WSOrderBookSideAsk, prevAsks, currAsks, false)
+	return out
+}
+
+func topOrderBookLevels(levels []OrderBookLevel, n int) []OrderBookLevel {
+	if n > 0 && len(levels) > n {
+		return levels[:n]
+	}
+	return levels
+}
+
+// diffOrderBookSide 以归并方式比较同一方向的两组有序档位。
+func diffOrderBookSide(out []WSOrderBookLevelChange, side string, prev, curr []OrderBookLevel, bids bool) []WSOrderBookLevelChange {
+	i, j := 0, 0
+	for i < len(prev) || j < len(curr) {
+		var cmp int
+		switch {
+		case i >= len(prev):
+			cmp = 1
+		case j >= len(curr):
+			cmp = -1
+		default:
+			cmp = compareDecimalString(prev[i].Px, curr[j].Px)
+			if bids {
+				cmp = -cmp
+			}
+		}
+
+		switch {
+		case cmp < 0:
+			out = append(out, WSOrderBookLevelChange{Side: side, Px: prev[i].Px, PrevSz: prev[i].Sz, Sz: "0"})
+			i++
+		case cmp > 0:
+			out = append(out, WSOrderBookLevelChange{Side: side, Px: curr[j].Px, PrevSz: "0", Sz: curr[j].Sz})
+			j++
+		default:
+			if prev[i].Sz != curr[j].Sz {
+				out = append(out, WSOrderBookLevelChange{Side: side, Px: curr[j].Px, PrevSz: prev[i].Sz, Sz: curr[j].Sz})
+			}
+			i++
+			j++
+		}
+	}
+	return out
+}
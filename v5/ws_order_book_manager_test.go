@@ -0,0 +1,120 @@
+package okx
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func wsTestBook(channel, instId, action string, prevSeq, seq int64, bids, asks []OrderBookLevel) *WSData[WSOrderBook] {
+	return &WSData[WSOrderBook]{
+		Arg:    WSArg{Channel: channel, InstId: instId},
+		Action: action,
+		Data: []WSOrderBook{{
+			Bids:      bids,
+			Asks:      asks,
+			PrevSeqId: prevSeq,
+			SeqId:     seq,
+			Checksum:  wsOrderBookChecksum(bids, asks),
+		}},
+	}
+}
+
+func TestWSOrderBookManager_RoutesAndPublishes(t *testing.T) {
+	m := NewWSOrderBookManager()
+	bids := []OrderBookLevel{{Px: "100", Sz: "1"}, {Px: "99", Sz: "2"}}
+	asks := []OrderBookLevel{{Px: "101", Sz: "1"}, {Px: "102", Sz: "3"}}
+
+	if err := m.Apply(wsTestBook(WSChannelBooks, "BTC-USDT", "snapshot", -1, 1, bids, asks)); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if err := m.Apply(wsTestBook(WSChannelBooks, "ETH-USDT", "snapshot", -1, 5, bids[:1], asks[:1])); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if m.Len() != 2 || len(m.Snapshots()) != 2 {
+		t.Fatalf("Len() = %d", m.Len())
+	}
+
+	btc, ok := m.Snapshot(WSChannelBooks, "BTC-USDT")
+	if !ok || btc.SeqId != 1 || len(btc.Bids) != 2 {
+		t.Fatalf("btc = %#v ok=%v", btc, ok)
+	}
+
+	// copy-on-write：新推送发布新快照，旧快照保持不变。
+	upd := wsTestBook(WSChannelBooks, "BTC-USDT", "update", 1, 2, []OrderBookLevel{{Px: "100", Sz: "5"}}, nil)
+	upd.Data[0].Checksum = wsOrderBookChecksum([]OrderBookLevel{{Px: "100", Sz: "5"}, {Px: "99", Sz: "2"}}, asks)
+	if err := m.Apply(upd); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	btc2, _ := m.Snapshot(WSChannelBooks, "BTC-USDT")
+	if btc.Bids[0].Sz != "1" || btc2.Bids[0].Sz != "5" || btc2.SeqId != 2 {
+		t.Fatalf("btc=%v btc2=%v", btc.Bids, btc2.Bids)
+	}
+
+	// 序列断档：该产品快照被清空，其他产品不受影响。
+	err := m.Apply(wsTestBook(WSChannelBooks, "BTC-USDT", "update", 99, 100, nil, nil))
+	var seqErr *WSOrderBookSequenceError
+	if !errors.As(err, &seqErr) {
+		t.Fatalf("err = %v", err)
+	}
+	if _, ok := m.Snapshot(WSChannelBooks, "BTC-USDT"); ok {
+		t.Fatalf("expected btc not ready after gap")
+	}
+	if _, ok := m.Snapshot(WSChannelBooks, "ETH-USDT"); !ok {
+		t.Fatalf("expected eth still ready")
+	}
+}
+
+func TestWSOrderBookManager_TopNNotifications(t *testing.T) {
+	m := NewWSOrderBookManager(WithWSOrderBookManagerStoreOptions(WithWSOrderBookVerifyChecksum(false)))
+
+	var mu sync.Mutex
+	var top1, all []WSOrderBookChange
+	cancel1 := m.Subscribe(1, func(c WSOrderBookChange) {
+		mu.Lock()
+		top1 = append(top1, c)
+		mu.Unlock()
+	})
+	m.Subscribe(0, func(c WSOrderBookChange) {
+		mu.Lock()
+		all = append(all, c)
+		mu.Unlock()
+	})
+
+	bids := []OrderBookLevel{{Px: "100", Sz: "1"}, {Px: "99", Sz: "2"}}
+	asks := []OrderBookLevel{{Px: "101", Sz: "1"}}
+	_ = m.Apply(wsTestBook(WSChannelBooks, "BTC-USDT", "snapshot", -1, 1, bids, asks))
+	// 仅第二档变化：top1 不通知。
+	_ = m.Apply(wsTestBook(WSChannelBooks, "BTC-USDT", "update", 1, 2, []OrderBookLevel{{Px: "99", Sz: "3"}}, nil))
+	// 新的最优买价：top1 通知（新增 100.5，100 移出前 1 档）。
+	_ = m.Apply(wsTestBook(WSChannelBooks, "BTC-USDT", "update", 2, 3, []OrderBookLevel{{Px: "100.5", Sz: "1"}}, nil))
+
+	mu.Lock()
+	if len(top1) != 2 || len(all) != 3 {
+		t.Fatalf("top1=%d all=%d", len(top1), len(all))
+	}
+	if top1[0].Prev != nil || len(top1[0].Changes) != 2 {
+		t.Fatalf("first top1 = %#v", top1[0])
+	}
+	got := fmt.Sprint(top1[1].Changes)
+	want := fmt.Sprint([]WSOrderBookLevelChange{
+		{Side: WSOrderBookSideBid, Px: "100.5", PrevSz: "0", Sz: "1"},
+		{Side: WSOrderBookSideBid, Px: "100", PrevSz: "1", Sz: "0"},
+	})
+	if got != want {
+		t.Fatalf("changes = %s, want %s", got, want)
+	}
+	if fmt.Sprint(all[1].Changes) != fmt.Sprint([]WSOrderBookLevelChange{{Side: WSOrderBookSideBid, Px: "99", PrevSz: "2", Sz: "3"}}) {
+		t.Fatalf("all[1] = %v", all[1].Changes)
+	}
+	mu.Unlock()
+
+	cancel1()
+	_ = m.Apply(wsTestBook(WSChannelBooks, "BTC-USDT", "update", 3, 4, []OrderBookLevel{{Px: "100.5", Sz: "2"}}, nil))
+	mu.Lock()
+	defer mu.Unlock()
+	if len(top1) != 2 || len(all) != 4 {
+		t.Fatalf("after cancel top1=%d all=%d", len(top1), len(all))
+	}
+}
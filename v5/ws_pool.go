@@ -0,0 +1,622 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultWSPoolMaxWeight = 100
+
+// WSPoolOption 用于配置 WSPool。
+type WSPoolOption func(*WSPool)
+
+// WithWSPoolMaxWeight 设置单连接的订阅权重上限（默认 100）。
+func WithWSPoolMaxWeight(weight int) WSPoolOption {
+	return func(p *WSPool) {
+		if weight <= 0 {
+			weight = defaultWSPoolMaxWeight
+		}
+		p.maxWeight = weight
+	}
+}
+
+// WithWSPoolChannelWeight 设置某个 channel 单个订阅的权重（默认 1；例如深度频道可设为更高权重）。
+func WithWSPoolChannelWeight(channel string, weight int) WSPoolOption {
+	return func(p *WSPool) {
+		if weight <= 0 {
+			weight = 1
+		}
+		p.weights[channel] = weight
+	}
+}
+
+// WithWSPoolMaxConns 设置最大连接数（默认 0 表示不限制）；容量不足时 Subscribe 返回错误。
+func WithWSPoolMaxConns(n int) WSPoolOption {
+	return func(p *WSPool) {
+		if n < 0 {
+			n = 0
+		}
+		p.maxConns = n
+	}
+}
+
+// WithWSPoolDeadAfter 设置连接“死亡”判定：连续断开超过 d 的连接会被关闭，其订阅迁移到其他连接（默认 0 表示禁用）。
+//
+// 说明：连接停止（Done）时总会触发迁移；该选项用于处理长时间重连失败的连接。
+func WithWSPoolDeadAfter(d time.Duration) WSPoolOption {
+	return func(p *WSPool) {
+		if d < 0 {
+			d = 0
+		}
+		p.deadAfter = d
+	}
+}
+
+// WSPool 将订阅按权重分片到多条 WSClient 连接上，并对外提供统一的订阅/handler/统计入口。
+//
+// 约定：
+// - 每条连接由 newConn 创建（通常为 func() *okx.WSClient { return c.NewWSPublic(opts...) }），typed handler/event handler 通过 opts 统一配置；
+// - Start 传入的 raw handler/errHandler 会被所有连接共享（可能被多个 goroutine 并发调用）；
+// - 连接停止或长时间断开（WithWSPoolDeadAfter）时，其订阅会迁移到其他连接（必要时新建连接）；
+// - 容量不足（WithWSPoolMaxConns）时迁移的订阅暂时超限放在权重最低的连接上，有余量（退订、连接失效）时再迁出；
+// - Start 失败时已创建的连接被丢弃，订阅重新分配到新连接，可再次 Start。
+type WSPool struct {
+	newConn   func() *WSClient
+	maxWeight int
+	maxConns  int
+	weights   map[string]int
+	deadAfter time.Duration
+
+	mu         sync.Mutex
+	members    []*wsPoolMember
+	assign     map[string]*wsPoolMember
+	connCounts map[string]int
+
+	started    bool
+	ctx        context.Context
+	cancel     context.CancelFunc
+	done       chan struct{}
+	handler    WSMessageHandler
+	errHandler WSErrorHandler
+
+	rebalances      atomic.Uint64
+	connCountErrors atomic.Uint64
+}
+
+type wsPoolMember struct {
+	ws        *WSClient
+	args      map[string]WSArg
+	weight    int
+	downSince time.Time
+}
+
+// WSPoolMemberStats 是 WSPool 中单条连接的状态快照。
+type WSPoolMemberStats struct {
+	WSStats
+	Weight        int
+	Subscriptions int
+}
+
+// WSPoolStats 是 WSPool 的运行状态快照。
+type WSPoolStats struct {
+	Conns         int
+	Connected     int
+	Subscriptions int
+	// Rebalances 为订阅迁移的次数（连接失效，或连接超出权重上限后迁出）。
+	Rebalances uint64
+	// ChannelConnCount 为 OKX channel-conn-count 事件上报的各频道连接数（key 为 channel）。
+	ChannelConnCount map[string]int
+	// ChannelConnCountErrors 为 channel-conn-count-error 事件次数（超过 OKX 单频道连接数上限）。
+	ChannelConnCountErrors uint64
+	Members                []WSPoolMemberStats
+}
+
+// NewWSPool 创建 WSPool；newConn 用于创建每一条连接（不要在 newConn 中调用 Start）。
+func NewWSPool(newConn func() *WSClient, opts ...WSPoolOption) *WSPool {
+	p := &WSPool{
+		newConn:    newConn,
+		maxWeight:  defaultWSPoolMaxWeight,
+		weights:    map[string]int{},
+		assign:     map[string]*wsPoolMember{},
+		connCounts: map[string]int{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Start 启动所有连接（后台 goroutine）；之后新增的连接会自动启动。
+func (p *WSPool) Start(ctx context.Context, handler WSMessageHandler, errHandler WSErrorHandler) error {
+	if p == nil || p.newConn == nil {
+		return errors.New("okx: ws pool requires newConn")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	p.mu.Lock()
+	if p.started {
+		p.mu.Unlock()
+		return errors.New("okx: ws pool already started")
+	}
+	p.started = true
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	p.done = make(chan struct{})
+	p.handler = handler
+	p.errHandler = errHandler
+	members := append([]*wsPoolMember(nil), p.members...)
+	runCtx, done := p.ctx, p.done
+	p.mu.Unlock()
+
+	// 先启动退出 goroutine：任一连接启动失败时 Close 也能关闭已启动的连接并关闭 done。
+	go func() {
+		<-runCtx.Done()
+		p.mu.Lock()
+		members := append([]*wsPoolMember(nil), p.members...)
+		p.mu.Unlock()
+		for _, m := range members {
+			m.ws.Close()
+			<-m.ws.Done()
+		}
+		close(done)
+	}()
+
+	for _, m := range members {
+		if err := p.startMember(m); err != nil {
+			p.Close()
+			<-done
+			p.mu.Lock()
+			p.started = false
+			groups := p.resetMembersLocked()
+			p.mu.Unlock()
+			// 新连接尚未启动：仅记录期望订阅。
+			_ = p.applyGroups(groups, nil, false)
+			return err
+		}
+	}
+
+	if p.deadAfter > 0 {
+		go p.healthLoop(p.ctx)
+	}
+	return nil
+}
+
+// resetMembersLocked 丢弃已关闭的连接，并将其订阅重新分配到新建（未启动）的连接上，使 WSPool 可再次 Start。
+func (p *WSPool) resetMembersLocked() map[*wsPoolMember][]WSArg {
+	var args []WSArg
+	for _, m := range p.members {
+		for _, a := range m.args {
+			args = append(args, a)
+		}
+	}
+	p.members = nil
+	p.assign = map[string]*wsPoolMember{}
+	groups := map[*wsPoolMember][]WSArg{}
+	for _, a := range args {
+		m, _, err := p.placeLocked(a, nil)
+		if err != nil {
+			m = p.overflowLocked(a)
+		}
+		if m != nil {
+			groups[m] = append(groups[m], a)
+		}
+	}
+	return groups
+}
+
+// Close 关闭所有连接。
+func (p *WSPool) Close() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	cancel := p.cancel
+	p.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// Done 返回 WSPool 停止（所有连接均已停止）后的信号通道。
+func (p *WSPool) Done() <-chan struct{} {
+	p.mu.Lock()
+	done := p.done
+	p.mu.Unlock()
+	if done == nil {
+		ch := make(chan struct{})
+		close(ch)
+		return ch
+	}
+	return done
+}
+
+// Subscribe 将订阅分配到权重最低且未超上限的连接（必要时新建连接）；已分配的订阅会被忽略。
+func (p *WSPool) Subscribe(args ...WSArg) error {
+	p.mu.Lock()
+	groups := map[*wsPoolMember][]WSArg{}
+	var created []*wsPoolMember
+	for _, a := range args {
+		if a.Channel == "" {
+			p.mu.Unlock()
+			return errors.New("okx: ws subscribe requires channel")
+		}
+		if _, ok := p.assign[a.key()]; ok {
+			continue
+		}
+		m, isNew, err := p.placeLocked(a, nil)
+		if err != nil {
+			p.mu.Unlock()
+			return err
+		}
+		if isNew {
+			created = append(created, m)
+		}
+		groups[m] = append(groups[m], a)
+	}
+	started := p.started
+	p.mu.Unlock()
+
+	return p.applyGroups(groups, created, started)
+}
+
+// Unsubscribe 取消订阅并从所在连接的期望订阅集合中移除。
+func (p *WSPool) Unsubscribe(args ...WSArg) error {
+	p.mu.Lock()
+	groups := map[*wsPoolMember][]WSArg{}
+	for _, a := range args {
+		if a.Channel == "" {
+			p.mu.Unlock()
+			return errors.New("okx: ws unsubscribe requires channel")
+		}
+		m := p.assign[a.key()]
+		if m == nil {
+			continue
+		}
+		delete(p.assign, a.key())
+		delete(m.args, a.key())
+		m.weight -= p.weightOf(a.Channel)
+		groups[m] = append(groups[m], a)
+	}
+	p.mu.Unlock()
+
+	var firstErr error
+	for m, send := range groups {
+		if err := m.ws.Unsubscribe(send...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	// 释放的权重可用于接收超限连接上的订阅。
+	if err := p.rebalance(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// Clients 返回当前所有连接（快照）。
+func (p *WSPool) Clients() []*WSClient {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]*WSClient, 0, len(p.members))
+	for _, m := range p.members {
+		out = append(out, m.ws)
+	}
+	return out
+}
+
+// Stats 返回 WSPool 的运行状态快照（并发安全）。
+func (p *WSPool) Stats() WSPoolStats {
+	var s WSPoolStats
+	if p == nil {
+		return s
+	}
+
+	p.mu.Lock()
+	type snap struct {
+		ws     *WSClient
+		weight int
+		subs   int
+	}
+	members := make([]snap, 0, len(p.members))
+	for _, m := range p.members {
+		members = append(members, snap{ws: m.ws, weight: m.weight, subs: len(m.args)})
+	}
+	s.Subscriptions = len(p.assign)
+	if len(p.connCounts) > 0 {
+		s.ChannelConnCount = make(map[string]int, len(p.connCounts))
+		for ch, n := range p.connCounts {
+			s.ChannelConnCount[ch] = n
+		}
+	}
+	p.mu.Unlock()
+
+	s.Conns = len(members)
+	s.Rebalances = p.rebalances.Load()
+	s.ChannelConnCountErrors = p.connCountErrors.Load()
+	s.Members = make([]WSPoolMemberStats, 0, len(members))
+	for _, m := range members {
+		st := m.ws.Stats()
+		if st.Connected {
+			s.Connected++
+		}
+		s.Members = append(s.Members, WSPoolMemberStats{WSStats: st, Weight: m.weight, Subscriptions: m.subs})
+	}
+	return s
+}
+
+func (p *WSPool) weightOf(channel string) int {
+	if w, ok := p.weights[channel]; ok {
+		return w
+	}
+	return 1
+}
+
+// placeLocked 为订阅选择连接（不含 exclude）：优先权重最低且放得下的连接；否则新建连接（受 maxConns 约束）。
+func (p *WSPool) placeLocked(a WSArg, exclude *wsPoolMember) (*wsPoolMember, bool, error) {
+	w := p.weightOf(a.Channel)
+
+	var best *wsPoolMember
+	for _, m := range p.members {
+		if m == exclude || (m.weight+w > p.maxWeight && m.weight > 0) {
+			continue
+		}
+		if best == nil || m.weight < best.weight {
+			best = m
+		}
+	}
+
+	isNew := false
+	if best == nil {
+		if p.maxConns > 0 && len(p.members) >= p.maxConns {
+			return nil, false, errors.New("okx: ws pool capacity exceeded")
+		}
+		ws := p.newConn()
+		if ws == nil {
+			return nil, false, errors.New("okx: ws pool newConn returned nil")
+		}
+		best = &wsPoolMember{ws: ws, args: map[string]WSArg{}}
+		p.hookEvents(ws)
+		p.members = append(p.members, best)
+		isNew = true
+	}
+
+	best.args[a.key()] = a
+	best.weight += w
+	p.assign[a.key()] = best
+	return best, isNew, nil
+}
+
+// overflowLocked 在容量不足时将订阅放到权重最低的连接上（可能超出 maxWeight，之后由 rebalance 迁出），避免订阅丢失。
+func (p *WSPool) overflowLocked(a WSArg) *wsPoolMember {
+	var best *wsPoolMember
+	for _, m := range p.members {
+		if best == nil || m.weight < best.weight {
+			best = m
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	best.args[a.key()] = a
+	best.weight += p.weightOf(a.Channel)
+	p.assign[a.key()] = best
+	return best
+}
+
+// rebalance 将超出 maxWeight 的连接上的订阅迁移到有余量的连接（必要时新建连接）：先在新连接上订阅，再从原连接退订。
+// 单个订阅权重超过 maxWeight 时独占一条连接，不再迁移。
+func (p *WSPool) rebalance() error {
+	p.mu.Lock()
+	subs := map[*wsPoolMember][]WSArg{}
+	unsubs := map[*wsPoolMember][]WSArg{}
+	var created []*wsPoolMember
+	for _, m := range append([]*wsPoolMember(nil), p.members...) {
+		for m.weight > p.maxWeight && len(m.args) > 1 {
+			var a WSArg
+			for _, v := range m.args {
+				a = v
+				break
+			}
+			key := a.key()
+			delete(m.args, key)
+			m.weight -= p.weightOf(a.Channel)
+			t, isNew, err := p.placeLocked(a, m)
+			if err != nil || t.weight > p.maxWeight {
+				// 无处可迁：放回原连接。
+				if err == nil {
+					delete(t.args, key)
+					t.weight -= p.weightOf(a.Channel)
+				}
+				m.args[key] = a
+				m.weight += p.weightOf(a.Channel)
+				p.assign[key] = m
+				break
+			}
+			if isNew {
+				created = append(created, t)
+			}
+			subs[t] = append(subs[t], a)
+			unsubs[m] = append(unsubs[m], a)
+		}
+	}
+	started := p.started
+	p.mu.Unlock()
+
+	if len(subs) == 0 {
+		return nil
+	}
+	p.rebalances.Add(1)
+	firstErr := p.applyGroups(subs, created, started)
+	for m, send := range unsubs {
+		if err := m.ws.Unsubscribe(send...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (p *WSPool) applyGroups(groups map[*wsPoolMember][]WSArg, created []*wsPoolMember, started bool) error {
+	var firstErr error
+	for m, send := range groups {
+		if err := m.ws.Subscribe(send...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if started {
+		for _, m := range created {
+			if err := p.startMember(m); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func (p *WSPool) startMember(m *wsPoolMember) error {
+	p.mu.Lock()
+	ctx := p.ctx
+	handler := p.handler
+	errHandler := p.errHandler
+	p.mu.Unlock()
+
+	if err := m.ws.Start(ctx, handler, errHandler); err != nil {
+		return err
+	}
+	go func() {
+		<-m.ws.Done()
+		p.onMemberDone(m)
+	}()
+	return nil
+}
+
+// hookEvents 在用户 event handler 之前记录 channel-conn-count 事件。
+func (p *WSPool) hookEvents(ws *WSClient) {
+	prev := ws.eventHandler
+	ws.eventHandler = func(ev WSEvent) {
+		p.onEvent(ev)
+		if prev != nil {
+			prev(ev)
+		}
+	}
+}
+
+func (p *WSPool) onEvent(ev WSEvent) {
+	switch ev.Event {
+	case "channel-conn-count":
+		n, err := strconv.Atoi(ev.ConnCount)
+		if err != nil || ev.Channel == "" {
+			return
+		}
+		p.mu.Lock()
+		p.connCounts[ev.Channel] = n
+		p.mu.Unlock()
+	case "channel-conn-count-error":
+		p.connCountErrors.Add(1)
+	}
+}
+
+// onMemberDone 在连接停止后将其订阅迁移到其他连接（WSPool 已关闭时不迁移）。
+func (p *WSPool) onMemberDone(dead *wsPoolMember) {
+	p.mu.Lock()
+	if p.ctx == nil || p.ctx.Err() != nil {
+		p.mu.Unlock()
+		return
+	}
+	idx := -1
+	for i, m := range p.members {
+		if m == dead {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		p.mu.Unlock()
+		return
+	}
+	p.members = append(p.members[:idx], p.members[idx+1:]...)
+
+	groups := map[*wsPoolMember][]WSArg{}
+	var created []*wsPoolMember
+	var firstErr error
+	for key, a := range dead.args {
+		delete(p.assign, key)
+		m, isNew, err := p.placeLocked(a, nil)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			// 容量不足：暂时超限放置，避免订阅丢失。
+			if m = p.overflowLocked(a); m == nil {
+				continue
+			}
+		}
+		if isNew {
+			created = append(created, m)
+		}
+		groups[m] = append(groups[m], a)
+	}
+	errHandler := p.errHandler
+	p.mu.Unlock()
+
+	p.rebalances.Add(1)
+	if err := p.applyGroups(groups, created, true); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	if err := p.rebalance(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	if firstErr != nil && errHandler != nil {
+		func() {
+			defer func() {
+				_ = recover()
+			}()
+			errHandler(firstErr)
+		}()
+	}
+}
+
+func (p *WSPool) healthLoop(ctx context.Context) {
+	interval := p.deadAfter / 2
+	if interval < 100*time.Millisecond {
+		interval = 100 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		var dead []*wsPoolMember
+		p.mu.Lock()
+		for _, m := range p.members {
+			m.ws.mu.Lock()
+			connected := m.ws.conn != nil
+			m.ws.mu.Unlock()
+			if connected {
+				m.downSince = time.Time{}
+				continue
+			}
+			if m.downSince.IsZero() {
+				m.downSince = now
+				continue
+			}
+			if now.Sub(m.downSince) >= p.deadAfter {
+				dead = append(dead, m)
+			}
+		}
+		p.mu.Unlock()
+
+		for _, m := range dead {
+			m.ws.Close()
+		}
+	}
+}
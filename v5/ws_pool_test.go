@@ -0,0 +1,248 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+type wsPoolTestServer struct {
+	srv *httptest.Server
+
+	mu   sync.Mutex
+	subs map[string]int // arg key -> subscribe count
+}
+
+func newWSPoolTestServer(t *testing.T) *wsPoolTestServer {
+	t.Helper()
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	ts := &wsPoolTestServer{subs: map[string]int{}}
+	ts.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+
+		_ = c.WriteMessage(websocket.TextMessage, []byte(`{"event":"channel-conn-count","channel":"tickers","connCount":"3","connId":"x"}`))
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req wsOpRequest
+			if err := json.Unmarshal(msg, &req); err != nil || req.Op != "subscribe" {
+				continue
+			}
+			for _, a := range req.Args {
+				ts.mu.Lock()
+				ts.subs[a.key()]++
+				ts.mu.Unlock()
+				b, _ := json.Marshal(WSEvent{ID: req.ID, Event: "subscribe", Arg: &a, ConnID: "x"})
+				_ = c.WriteMessage(websocket.TextMessage, b)
+			}
+		}
+	}))
+	t.Cleanup(ts.srv.Close)
+	return ts
+}
+
+func (ts *wsPoolTestServer) subCount(a WSArg) int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.subs[a.key()]
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWSPool_ShardAndRebalance(t *testing.T) {
+	ts := newWSPoolTestServer(t)
+	wsURL := "ws" + ts.srv.URL[len("http"):]
+	c := NewClient()
+
+	p := NewWSPool(func() *WSClient { return c.NewWSPublic(WithWSURL(wsURL)) },
+		WithWSPoolMaxWeight(2),
+		WithWSPoolChannelWeight(WSChannelBooks, 2),
+	)
+
+	t1 := WSArg{Channel: WSChannelTickers, InstId: "BTC-USDT"}
+	t2 := WSArg{Channel: WSChannelTickers, InstId: "ETH-USDT"}
+	t3 := WSArg{Channel: WSChannelTickers, InstId: "SOL-USDT"}
+	b1 := WSArg{Channel: WSChannelBooks, InstId: "BTC-USDT"}
+	if err := p.Subscribe(t1, t2, t3, b1, t1); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	st := p.Stats()
+	if st.Conns != 3 || st.Subscriptions != 4 {
+		t.Fatalf("stats = %#v", st)
+	}
+	for _, m := range st.Members {
+		if m.Weight > 2 {
+			t.Fatalf("member weight = %d, want <= 2", m.Weight)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := p.Start(ctx, nil, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	waitFor(t, "initial subscribe", func() bool {
+		return ts.subCount(t1) == 1 && ts.subCount(t2) == 1 && ts.subCount(t3) == 1 && ts.subCount(b1) == 1
+	})
+	waitFor(t, "channel-conn-count", func() bool { return p.Stats().ChannelConnCount[WSChannelTickers] == 3 })
+
+	// 关闭第一条连接（t1/t2）：订阅迁移到其他连接。
+	p.Clients()[0].Close()
+	waitFor(t, "rebalance", func() bool { return ts.subCount(t1) == 2 && ts.subCount(t2) == 2 })
+
+	st = p.Stats()
+	if st.Rebalances != 1 || st.Subscriptions != 4 || st.Conns != 3 {
+		t.Fatalf("stats after rebalance = %#v", st)
+	}
+
+	if err := p.Unsubscribe(b1); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if st := p.Stats(); st.Subscriptions != 3 {
+		t.Fatalf("Subscriptions = %d, want 3", st.Subscriptions)
+	}
+
+	p.Close()
+	select {
+	case <-p.Done():
+	case <-time.After(3 * time.Second):
+		t.Fatalf("timeout waiting Done")
+	}
+}
+
+func TestWSPool_MaxConns(t *testing.T) {
+	c := NewClient()
+	p := NewWSPool(func() *WSClient { return c.NewWSPublic() }, WithWSPoolMaxWeight(1), WithWSPoolMaxConns(1))
+	if err := p.Subscribe(WSArg{Channel: WSChannelTickers, InstId: "BTC-USDT"}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if err := p.Subscribe(WSArg{Channel: WSChannelTickers, InstId: "ETH-USDT"}); err == nil {
+		t.Fatalf("expected capacity error")
+	}
+}
+
+func TestWSPool_StartMemberError(t *testing.T) {
+	ts := newWSPoolTestServer(t)
+	wsURL := "ws" + ts.srv.URL[len("http"):]
+	c := NewClient()
+
+	// 第二条连接为缺少凭证的私有连接：Start 失败。
+	var n int
+	p := NewWSPool(func() *WSClient {
+		n++
+		if n == 2 {
+			return c.NewWSPrivate(WithWSURL(wsURL))
+		}
+		return c.NewWSPublic(WithWSURL(wsURL))
+	}, WithWSPoolMaxWeight(1))
+
+	t1 := WSArg{Channel: WSChannelTickers, InstId: "BTC-USDT"}
+	t2 := WSArg{Channel: WSChannelTickers, InstId: "ETH-USDT"}
+	if err := p.Subscribe(t1, t2); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if err := p.Start(context.Background(), nil, nil); err == nil {
+		t.Fatalf("Start() error = nil, want credentials error")
+	}
+
+	select {
+	case <-p.Done():
+	case <-time.After(3 * time.Second):
+		t.Fatalf("timeout waiting pool Done after Start error")
+	}
+	p.mu.Lock()
+	started := p.started
+	p.mu.Unlock()
+	if started {
+		t.Fatalf("started = true after Start error")
+	}
+	for _, m := range p.Stats().Members {
+		if m.Connected {
+			t.Fatalf("member still connected: %#v", m)
+		}
+	}
+
+	// 已关闭的连接被丢弃，订阅重新分配到新连接；再次 Start 成功。
+	if st := p.Stats(); st.Conns != 2 || st.Subscriptions != 2 {
+		t.Fatalf("stats after Start error = %#v", st)
+	}
+	if err := p.Start(context.Background(), nil, nil); err != nil {
+		t.Fatalf("second Start() error = %v", err)
+	}
+	t.Cleanup(p.Close)
+	waitFor(t, "resubscribe on fresh conns", func() bool { return ts.subCount(t1) >= 1 && ts.subCount(t2) >= 1 })
+	if n != 4 {
+		t.Fatalf("newConn calls = %d, want 4", n)
+	}
+}
+
+func TestWSPool_RebalanceOverweight(t *testing.T) {
+	c := NewClient()
+	p := NewWSPool(func() *WSClient { return c.NewWSPublic() }, WithWSPoolMaxWeight(2), WithWSPoolMaxConns(2))
+	args := []WSArg{
+		{Channel: WSChannelTickers, InstId: "A"},
+		{Channel: WSChannelTickers, InstId: "B"},
+		{Channel: WSChannelTickers, InstId: "C"},
+		{Channel: WSChannelTickers, InstId: "D"},
+	}
+	if err := p.Subscribe(args...); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	// 模拟容量不足时迁移：第 5 个订阅超限放在权重最低的连接上。
+	extra := WSArg{Channel: WSChannelTickers, InstId: "E"}
+	p.mu.Lock()
+	over := p.overflowLocked(extra)
+	other := p.members[0]
+	if other == over {
+		other = p.members[1]
+	}
+	p.mu.Unlock()
+	if over.weight != 3 {
+		t.Fatalf("overflow weight = %d, want 3", over.weight)
+	}
+
+	// 另一条连接释放余量后，超限连接上的订阅被迁出。
+	var release WSArg
+	for _, a := range other.args {
+		release = a
+		break
+	}
+	if err := p.Unsubscribe(release); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	st := p.Stats()
+	if st.Rebalances != 1 || st.Subscriptions != 4 {
+		t.Fatalf("stats = %#v", st)
+	}
+	for _, m := range st.Members {
+		if m.Weight > 2 {
+			t.Fatalf("member over max weight: %#v", m)
+		}
+	}
+}
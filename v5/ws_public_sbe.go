@@ -0,0 +1,141 @@
+package okx
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkssssss/go-okx/v5/internal/sign"
+)
+
+// NewWSPublicSBE 创建 public-sbe WS 客户端（/ws/v5/public-sbe）。
+//
+// 约定：
+// - 握手时通过 header 登录（OK-ACCESS-TIMESTAMP 为 Unix 秒，签名串同 WS login），需要 API Key 凭证；
+// - 订阅/回包仍为 JSON；二进制 SBE 推送解码后转换为与 JSON 频道一致的 typed 数据（books-l2-tbt/books50-l2-tbt/bbo-tbt -> OnOrderBook，WSOrderBookStore 兼容；trades -> OnTrades）；
+// - 解码后的原始消息可通过 WithWSSBEHandler 获取；instIdCode -> instId 映射见 WithWSSBEInstIdResolver。
+func (c *Client) NewWSPublicSBE(opts ...WSOption) *WSClient {
+	endpoint := wsPublicSBEURL
+	if c.demo {
+		endpoint = wsPublicSBEDemoURL
+	}
+	w := &WSClient{
+		c:                    c,
+		endpoint:             endpoint,
+		kind:                 wsKindPublicSBE,
+		headerLogin:          true,
+		connCh:               make(chan struct{}),
+		desired:              map[string]WSArg{},
+		backoff:              250 * time.Millisecond,
+		heartbeat:            25 * time.Second,
+		resubscribeWait:      5 * time.Second,
+		writeTimeout:         defaultWSWriteTimeout,
+		typedAsync:           true,
+		typedBuffer:          1024,
+		typedQueueFullPolicy: WSQueueFullBlock,
+		rawAsync:             true,
+		rawBuffer:            1024,
+		rawQueueFullPolicy:   WSQueueFullBlock,
+		waiters:              map[string]*wsOpWaiter{},
+		opWaiters:            map[string]*wsOpRespWaiter{},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// WithWSSBEHandler 设置 SBE 解码消息回调（public-sbe；按 typed handler 的同步/异步配置执行）。
+func WithWSSBEHandler(handler func(msg SBEMessage)) WSOption {
+	return func(c *WSClient) {
+		c.OnSBE(handler)
+	}
+}
+
+// OnSBE 设置 SBE 解码消息回调（public-sbe）；nil 表示清除。
+func (w *WSClient) OnSBE(handler func(msg SBEMessage)) {
+	if w == nil {
+		return
+	}
+	w.typedMu.Lock()
+	w.sbeHandler = handler
+	w.typedMu.Unlock()
+}
+
+// WithWSSBEInstIdResolver 设置 instIdCode -> instId 的映射（SBE 推送仅携带 instIdCode）。
+//
+// 未设置或无法解析时，转换后的 typed 数据中 InstId 为 instIdCode 的十进制字符串。
+func WithWSSBEInstIdResolver(resolver func(instIdCode int64) (instId string, ok bool)) WSOption {
+	return func(c *WSClient) {
+		c.typedMu.Lock()
+		c.sbeInstIdResolver = resolver
+		c.typedMu.Unlock()
+	}
+}
+
+// SBEInstIdResolver 基于产品列表（PublicInstrumentsService 返回的 instIdCode）构造 instIdCode -> instId 映射。
+func SBEInstIdResolver(instruments []Instrument) func(instIdCode int64) (string, bool) {
+	m := make(map[int64]string, len(instruments))
+	for _, inst := range instruments {
+		if inst.InstIdCode != nil {
+			m[*inst.InstIdCode] = inst.InstId
+		}
+	}
+	return func(instIdCode int64) (string, bool) {
+		instId, ok := m[instIdCode]
+		return instId, ok
+	}
+}
+
+// setLoginHeader 写入 public-sbe 握手登录 header（每次拨号重新签名）。
+func (w *WSClient) setLoginHeader(header http.Header) {
+	if w.c == nil || w.c.creds == nil {
+		return
+	}
+	tm := w.c.now().Add(-w.c.TimeOffset())
+	timestamp := sign.TimestampUnixSeconds(tm)
+	header.Set("OK-ACCESS-KEY", w.c.creds.APIKey)
+	header.Set("OK-ACCESS-SIGN", sign.SignHMACSHA256Base64(w.c.creds.SecretKey, sign.PrehashWSLogin(timestamp)))
+	header.Set("OK-ACCESS-TIMESTAMP", timestamp)
+	header.Set("OK-ACCESS-PASSPHRASE", w.c.creds.Passphrase)
+}
+
+func (w *WSClient) handleSBEMessage(msg []byte) {
+	m, err := DecodeSBE(msg)
+	if err != nil {
+		w.onError(err)
+		return
+	}
+
+	w.typedMu.RLock()
+	sbeHandler := w.sbeHandler
+	orderBookHandler := w.orderBookHandler
+	tradesHandler := w.tradesHandler
+	w.typedMu.RUnlock()
+
+	if sbeHandler != nil {
+		mv := *m
+		w.dispatchTyped(wsTypedTask{kind: wsTypedKindSBE, call: func() { sbeHandler(mv) }})
+	}
+
+	switch {
+	case m.Depth != nil && orderBookHandler != nil:
+		w.dispatchTyped(wsTypedTask{kind: wsTypedKindOrderBook, orderBooks: []WSData[WSOrderBook]{m.Depth.OrderBookData(w.sbeInstId(m.Depth.InstIdCode))}})
+	case m.Bbo != nil && orderBookHandler != nil:
+		w.dispatchTyped(wsTypedTask{kind: wsTypedKindOrderBook, orderBooks: []WSData[WSOrderBook]{m.Bbo.OrderBookData(w.sbeInstId(m.Bbo.InstIdCode))}})
+	case m.Trades != nil && tradesHandler != nil:
+		w.dispatchTyped(wsTypedTask{kind: wsTypedKindTrades, trades: m.Trades.MarketTrades(w.sbeInstId(m.Trades.InstIdCode))})
+	}
+}
+
+func (w *WSClient) sbeInstId(instIdCode int64) string {
+	w.typedMu.RLock()
+	resolve := w.sbeInstIdResolver
+	w.typedMu.RUnlock()
+	if resolve != nil {
+		if instId, ok := resolve(instIdCode); ok {
+			return instId
+		}
+	}
+	return strconv.FormatInt(instIdCode, 10)
+}
@@ -0,0 +1,161 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkssssss/go-okx/v5/internal/sign"
+)
+
+func TestWSPublicSBE_HeaderLoginAndDecode(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	wantSign := sign.SignHMACSHA256Base64("s", sign.PrehashWSLogin("1700000000"))
+
+	snapshot := sbeFixture(t, sbeFixtureDepthSnapshot...)
+	update := sbeFixture(t, sbeFixtureDepthUpdate...)
+	trades := sbeFixture(t,
+		"0a00 eb03 0100 0100",
+		"0a00000000000000",
+		"fe fd",
+		"2100 01000000",
+		"2b02000000000000 40222018240a0600 823d400000000000 dc05000000000000 00",
+	)
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := r.Header
+		if h.Get("OK-ACCESS-KEY") != "k" || h.Get("OK-ACCESS-PASSPHRASE") != "p" ||
+			h.Get("OK-ACCESS-TIMESTAMP") != "1700000000" || h.Get("OK-ACCESS-SIGN") != wantSign {
+			t.Errorf("unexpected login headers: %v", h)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req wsOpRequest
+			if err := json.Unmarshal(msg, &req); err != nil || req.Op != "subscribe" {
+				continue
+			}
+			for _, a := range req.Args {
+				b, _ := json.Marshal(WSEvent{ID: req.ID, Event: req.Op, Arg: &a})
+				_ = c.WriteMessage(websocket.TextMessage, b)
+			}
+			for _, b := range [][]byte{snapshot, update, trades} {
+				_ = c.WriteMessage(websocket.BinaryMessage, b)
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	wsURL := "ws" + srv.URL[len("http"):]
+
+	code := int64(10)
+	mgr := NewWSOrderBookManager(WithWSOrderBookManagerStoreOptions(WithWSOrderBookVerifyChecksum(false)))
+	var mu sync.Mutex
+	var sbeMsgs []SBEMessage
+	var gotTrades []MarketTrade
+
+	c := NewClient(
+		WithCredentials(Credentials{APIKey: "k", SecretKey: "s", Passphrase: "p"}),
+		WithNowFunc(func() time.Time { return now }),
+	)
+	ws := c.NewWSPublicSBE(
+		WithWSURL(wsURL),
+		WithWSOrderBookHandler(mgr.Handler()),
+		WithWSTradesHandler(func(tr MarketTrade) {
+			mu.Lock()
+			gotTrades = append(gotTrades, tr)
+			mu.Unlock()
+		}),
+		WithWSSBEHandler(func(msg SBEMessage) {
+			mu.Lock()
+			sbeMsgs = append(sbeMsgs, msg)
+			mu.Unlock()
+		}),
+		WithWSSBEInstIdResolver(SBEInstIdResolver([]Instrument{{InstId: "BTC-USDT", InstIdCode: &code}})),
+	)
+	if st := ws.Stats(); st.Kind != "public-sbe" || !st.NeedLogin {
+		t.Fatalf("stats = %#v", st)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ws.Start(ctx, nil, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(ws.Close)
+	if err := ws.SubscribeAndWait(ctx, WSArg{Channel: WSChannelBooksL2Tbt, InstId: "BTC-USDT"}); err != nil {
+		t.Fatalf("SubscribeAndWait() error = %v", err)
+	}
+
+	waitFor(t, "sbe messages", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(sbeMsgs) == 3 && len(gotTrades) == 1
+	})
+	snap, ok := mgr.Snapshot(WSChannelBooksL2Tbt, "BTC-USDT")
+	if !ok || snap.SeqId != 101 || len(snap.Asks) != 1 || snap.Asks[0].Px != "42101.00" {
+		t.Fatalf("snapshot = %#v ok=%v", snap, ok)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if gotTrades[0].InstId != "BTC-USDT" || gotTrades[0].Px != "42100.50" || sbeMsgs[2].Trades == nil {
+		t.Fatalf("trades = %#v", gotTrades)
+	}
+}
+
+func TestWSPublicSBE_RequiresCredentials(t *testing.T) {
+	ws := NewClient().NewWSPublicSBE()
+	if err := ws.Start(context.Background(), nil, nil); err != errMissingCredentials {
+		t.Fatalf("Start() error = %v, want %v", err, errMissingCredentials)
+	}
+}
+
+func TestWSPublicSBE_DispatchByFrameType(t *testing.T) {
+	trades := sbeFixture(t,
+		"0a00 eb03 0100 0100",
+		"0a00000000000000",
+		"fe fd",
+		"2100 01000000",
+		"2b02000000000000 40222018240a0600 823d400000000000 dc05000000000000 00",
+	)
+
+	var sbeMsgs int
+	var errs []error
+	ws := NewClient().NewWSPublicSBE(WithWSSBEHandler(func(msg SBEMessage) { sbeMsgs++ }))
+	ws.errHandler = func(err error) { errs = append(errs, err) }
+
+	// 文本帧（即使不以 '{' 开头）按 JSON 处理，不会误判为 SBE。
+	if ev := ws.handleFrame(websocket.TextMessage, []byte("\n{\"event\":\"subscribe\",\"arg\":{\"channel\":\"bbo-tbt\"}}")); ev == nil || ev.Event != "subscribe" {
+		t.Fatalf("event = %#v", ev)
+	}
+	if ev := ws.handleFrame(websocket.BinaryMessage, trades); ev != nil {
+		t.Fatalf("binary frame returned event %#v", ev)
+	}
+	if sbeMsgs != 1 || len(errs) != 0 {
+		t.Fatalf("sbe messages = %d errs = %v", sbeMsgs, errs)
+	}
+
+	// 非 SBE 连接收到二进制帧时报告错误。
+	pub := NewClient().NewWSPublic()
+	pub.errHandler = func(err error) { errs = append(errs, err) }
+	pub.handleFrame(websocket.BinaryMessage, trades)
+	if len(errs) != 1 {
+		t.Fatalf("errs = %v, want 1", errs)
+	}
+}
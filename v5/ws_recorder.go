@@ -0,0 +1,317 @@
+package okx
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// WSRecordKindData 为数据推送（含 SBE 二进制帧）。
+	WSRecordKindData = "data"
+	// WSRecordKindControl 为控制消息（event/op 回包，如 subscribe/login/notice/error）。
+	WSRecordKindControl = "control"
+	// WSRecordKindHeartbeat 为文本 ping/pong。
+	WSRecordKindHeartbeat = "heartbeat"
+)
+
+const (
+	defaultWSRecorderMaxBytes = 256 << 20 // 单文件未压缩字节上限
+	defaultWSRecorderBuffer   = 4096
+)
+
+// WSRecord 为录制文件（gzip 压缩的 JSONL）中的一行。
+type WSRecord struct {
+	// TS 为接收时间（Unix 纳秒）。
+	TS int64 `json:"ts"`
+	// Conn 为连接序号（同一 WSClient 内每建立一条连接递增，含 make-before-break 切换）。
+	Conn uint64 `json:"conn"`
+	Kind string `json:"kind"`
+
+	// Msg 为 JSON 帧原文；非 JSON 文本（如 ping/pong）记录在 Text；二进制帧（SBE）记录在 Bin（base64）。
+	Msg  json.RawMessage `json:"msg,omitempty"`
+	Text string          `json:"text,omitempty"`
+	Bin  []byte          `json:"bin,omitempty"`
+}
+
+// Payload 返回该记录的原始帧内容。
+func (r WSRecord) Payload() []byte {
+	switch {
+	case len(r.Msg) > 0:
+		return r.Msg
+	case len(r.Bin) > 0:
+		return r.Bin
+	default:
+		return []byte(r.Text)
+	}
+}
+
+// WSRecorderOption 用于配置 WSRecorder。
+type WSRecorderOption func(*WSRecorder)
+
+// WithWSRecorderMaxBytes 设置单个文件的未压缩字节上限（超过后滚动到新文件；默认 256MiB）。
+func WithWSRecorderMaxBytes(n int64) WSRecorderOption {
+	return func(r *WSRecorder) {
+		if n > 0 {
+			r.maxBytes = n
+		}
+	}
+}
+
+// WithWSRecorderRotateInterval 设置按时间滚动的间隔（<=0 表示仅按大小滚动）。
+func WithWSRecorderRotateInterval(d time.Duration) WSRecorderOption {
+	return func(r *WSRecorder) {
+		r.rotateEvery = d
+	}
+}
+
+// WithWSRecorderControl 设置是否录制控制消息（event/op 回包；默认录制）。
+func WithWSRecorderControl(enabled bool) WSRecorderOption {
+	return func(r *WSRecorder) {
+		r.control = enabled
+	}
+}
+
+// WithWSRecorderHeartbeat 设置是否录制文本 ping/pong（默认不录制）。
+func WithWSRecorderHeartbeat(enabled bool) WSRecorderOption {
+	return func(r *WSRecorder) {
+		r.heartbeat = enabled
+	}
+}
+
+// WithWSRecorderBuffer 设置写入队列长度（默认 4096；队列满时阻塞接收 goroutine，保证不丢帧）。
+func WithWSRecorderBuffer(n int) WSRecorderOption {
+	return func(r *WSRecorder) {
+		if n > 0 {
+			r.buffer = n
+		}
+	}
+}
+
+// WithWSRecorderErrorHandler 设置写文件错误回调（默认忽略；出错后该帧丢弃，后续帧继续尝试写入）。
+func WithWSRecorderErrorHandler(handler func(err error)) WSRecorderOption {
+	return func(r *WSRecorder) {
+		r.errHandler = handler
+	}
+}
+
+// WSRecorder 将 WS 接收到的原始帧写入滚动的 gzip JSONL 文件（后台 goroutine 写盘）。
+//
+// 文件名：<pathPrefix>-<UTC 时间 20060102T150405.000000000Z>.jsonl.gz；可被多个 WSClient 共享（Conn 仅在单个 WSClient 内唯一）。
+// 用法：ws := c.NewWSPublic(okx.WithWSRecorder(rec))；退出前调用 rec.Close() 刷盘。
+type WSRecorder struct {
+	prefix      string
+	maxBytes    int64
+	rotateEvery time.Duration
+	control     bool
+	heartbeat   bool
+	buffer      int
+	errHandler  func(err error)
+
+	ch      chan WSRecord
+	done    chan struct{}
+	closeMu sync.RWMutex
+	closed  bool
+
+	// 以下字段仅由写盘 goroutine 访问。
+	file     *os.File
+	gz       *gzip.Writer
+	bw       *bufio.Writer
+	written  int64
+	openedAt time.Time
+	files    []string
+	filesMu  sync.Mutex
+}
+
+// NewWSRecorder 创建录制器并打开第一个文件。
+func NewWSRecorder(pathPrefix string, opts ...WSRecorderOption) (*WSRecorder, error) {
+	if pathPrefix == "" {
+		return nil, errors.New("okx: ws recorder requires path prefix")
+	}
+	r := &WSRecorder{
+		prefix:   pathPrefix,
+		maxBytes: defaultWSRecorderMaxBytes,
+		control:  true,
+		buffer:   defaultWSRecorderBuffer,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if err := r.rotate(time.Now()); err != nil {
+		return nil, err
+	}
+	r.ch = make(chan WSRecord, r.buffer)
+	r.done = make(chan struct{})
+	go r.loop()
+	return r, nil
+}
+
+// WithWSRecorder 将该 WSClient 接收到的每一帧写入 rec（按 rec 的配置过滤控制消息/心跳）。
+func WithWSRecorder(rec *WSRecorder) WSOption {
+	return func(c *WSClient) {
+		c.recorder = rec
+	}
+}
+
+// Files 返回已创建的文件路径（按创建顺序）。
+func (r *WSRecorder) Files() []string {
+	if r == nil {
+		return nil
+	}
+	r.filesMu.Lock()
+	defer r.filesMu.Unlock()
+	return append([]string(nil), r.files...)
+}
+
+// Close 停止录制并刷盘关闭当前文件；之后的帧被忽略。
+func (r *WSRecorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.closeMu.Lock()
+	if r.closed {
+		r.closeMu.Unlock()
+		return nil
+	}
+	r.closed = true
+	close(r.ch)
+	r.closeMu.Unlock()
+
+	<-r.done
+	return r.closeFile()
+}
+
+// record 按配置过滤并入队一帧（在 WS 接收 goroutine 中调用）；msgType 为 WebSocket 帧类型。
+func (r *WSRecorder) record(conn uint64, recvAt time.Time, msgType int, message []byte) {
+	rec := WSRecord{TS: recvAt.UnixNano(), Conn: conn}
+	switch {
+	case msgType == websocket.BinaryMessage:
+		rec.Kind = WSRecordKindData
+		rec.Bin = append([]byte(nil), message...)
+	case isWSPingMessage(message) || isWSPongMessage(message):
+		if !r.heartbeat {
+			return
+		}
+		rec.Kind = WSRecordKindHeartbeat
+		rec.Text = string(message)
+	default:
+		rec.Kind = WSRecordKindData
+		if isWSControlMessage(message) {
+			if !r.control {
+				return
+			}
+			rec.Kind = WSRecordKindControl
+		}
+		if json.Valid(message) {
+			rec.Msg = append(json.RawMessage(nil), message...)
+		} else {
+			rec.Text = string(message)
+		}
+	}
+
+	r.closeMu.RLock()
+	defer r.closeMu.RUnlock()
+	if r.closed {
+		return
+	}
+	r.ch <- rec
+}
+
+// isWSControlMessage 判断是否为 event 或 op 回包（二者均不含 arg+data 的数据推送结构）。
+func isWSControlMessage(message []byte) bool {
+	var probe struct {
+		Event string          `json:"event"`
+		Op    string          `json:"op"`
+		Data  json.RawMessage `json:"data"`
+		Arg   json.RawMessage `json:"arg"`
+	}
+	if err := json.Unmarshal(message, &probe); err != nil {
+		return false
+	}
+	if probe.Event != "" || probe.Op != "" {
+		return true
+	}
+	return len(probe.Arg) == 0 || len(probe.Data) == 0
+}
+
+func (r *WSRecorder) loop() {
+	defer close(r.done)
+	for rec := range r.ch {
+		if err := r.write(rec); err != nil && r.errHandler != nil {
+			r.errHandler(err)
+		}
+	}
+}
+
+func (r *WSRecorder) write(rec WSRecord) error {
+	now := time.Unix(0, rec.TS)
+	if r.bw == nil || r.written >= r.maxBytes || (r.rotateEvery > 0 && now.Sub(r.openedAt) >= r.rotateEvery) {
+		if err := r.rotate(now); err != nil {
+			return err
+		}
+	}
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	n, err := r.bw.Write(b)
+	r.written += int64(n)
+	return err
+}
+
+func (r *WSRecorder) rotate(now time.Time) error {
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.jsonl.gz", r.prefix, now.UTC().Format("20060102T150405.000000000Z"))
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("okx: ws recorder open file: %w", err)
+	}
+	r.file = f
+	r.gz = gzip.NewWriter(f)
+	r.bw = bufio.NewWriterSize(r.gz, 64<<10)
+	r.written = 0
+	r.openedAt = now
+
+	r.filesMu.Lock()
+	r.files = append(r.files, name)
+	r.filesMu.Unlock()
+	return nil
+}
+
+func (r *WSRecorder) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.bw.Flush()
+	if cerr := r.gz.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	r.file, r.gz, r.bw = nil, nil, nil
+	return err
+}
+
+// recordConnID 返回当前连接序号（每次成功建连或 make-before-break 切换递增）。
+func (w *WSClient) recordConnID() uint64 {
+	return w.connects.Load() + w.handovers.Load()
+}
+
+// recordFrame 将接收到的一帧写入录制器（未配置时为空操作）。
+func (w *WSClient) recordFrame(conn uint64, msgType int, message []byte) {
+	if w.recorder == nil {
+		return
+	}
+	w.recorder.record(conn, time.Now(), msgType, message)
+}
@@ -0,0 +1,193 @@
+package okx
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func wsRecorderTestBookMsg(action string, prevSeq, seq int64, bidSz string) []byte {
+	bids := []OrderBookLevel{{Px: "100", Sz: bidSz, LiqOrd: "0", NumOrders: "1"}}
+	asks := []OrderBookLevel{{Px: "101", Sz: "2", LiqOrd: "0", NumOrders: "1"}}
+	return []byte(fmt.Sprintf(`{"arg":{"channel":"books","instId":"BTC-USDT"},"action":"%s","data":[{"asks":[["101","2","0","1"]],"bids":[["100","%s","0","1"]],"ts":"1","checksum":%d,"prevSeqId":%d,"seqId":%d}]}`,
+		action, bidSz, wsOrderBookChecksum(bids, asks), prevSeq, seq))
+}
+
+func readWSRecordFiles(t *testing.T, paths []string) []WSRecord {
+	t.Helper()
+	var out []WSRecord
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			t.Fatalf("open %s: %v", p, err)
+		}
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatalf("gzip %s: %v", p, err)
+		}
+		sc := bufio.NewScanner(gz)
+		for sc.Scan() {
+			var rec WSRecord
+			if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+				t.Fatalf("unmarshal %q: %v", sc.Text(), err)
+			}
+			out = append(out, rec)
+		}
+		_ = gz.Close()
+		_ = f.Close()
+	}
+	return out
+}
+
+func TestWSRecorder_RecordAndReplay(t *testing.T) {
+	frames := [][]byte{
+		[]byte("ping"),
+		wsRecorderTestBookMsg("snapshot", -1, 10, "1"),
+		wsRecorderTestBookMsg("update", 10, 11, "3"),
+		wsRecorderTestBookMsg("update", 11, 12, "5"),
+	}
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req wsOpRequest
+			if err := json.Unmarshal(msg, &req); err != nil || req.Op != "subscribe" {
+				continue
+			}
+			for _, a := range req.Args {
+				b, _ := json.Marshal(WSEvent{ID: req.ID, Event: req.Op, Arg: &a})
+				_ = c.WriteMessage(websocket.TextMessage, b)
+			}
+			for _, f := range frames {
+				_ = c.WriteMessage(websocket.TextMessage, f)
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	wsURL := "ws" + srv.URL[len("http"):]
+
+	prefix := filepath.Join(t.TempDir(), "okx-ws")
+	rec, err := NewWSRecorder(prefix, WithWSRecorderHeartbeat(true), WithWSRecorderMaxBytes(300))
+	if err != nil {
+		t.Fatalf("NewWSRecorder() error = %v", err)
+	}
+
+	live := NewWSOrderBookStore(WSChannelBooks, "BTC-USDT")
+	var mu sync.Mutex
+	c := NewClient()
+	ws := c.NewWSPublic(WithWSURL(wsURL), WithWSRecorder(rec), WithWSOrderBookHandler(func(dm WSData[WSOrderBook]) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := live.Apply(&dm); err != nil {
+			t.Errorf("live Apply() error = %v", err)
+		}
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ws.Start(ctx, nil, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := ws.SubscribeAndWait(ctx, WSArg{Channel: WSChannelBooks, InstId: "BTC-USDT"}); err != nil {
+		t.Fatalf("SubscribeAndWait() error = %v", err)
+	}
+	waitFor(t, "live book", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return live.Snapshot().SeqId == 12
+	})
+	ws.Close()
+	<-ws.Done()
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	files := rec.Files()
+	if len(files) < 2 {
+		t.Fatalf("files = %v, want rotation", files)
+	}
+	records := readWSRecordFiles(t, files)
+	var kinds []string
+	for _, r := range records {
+		if r.Conn != 1 || r.TS == 0 {
+			t.Fatalf("record = %#v", r)
+		}
+		kinds = append(kinds, r.Kind)
+	}
+	if fmt.Sprint(kinds) != "[control heartbeat data data data]" {
+		t.Fatalf("kinds = %v", kinds)
+	}
+	if records[1].Text != "ping" || string(records[2].Msg) != string(frames[1]) {
+		t.Fatalf("records = %#v", records[:3])
+	}
+
+	// 回放到独立的 WSClient：经相同的 OnOrderBook -> WSOrderBookStore 路径得到一致的深度。
+	replayed := NewWSOrderBookStore(WSChannelBooks, "BTC-USDT")
+	var events []string
+	target := c.NewWSPublic(
+		WithWSOrderBookHandler(func(dm WSData[WSOrderBook]) {
+			if err := replayed.Apply(&dm); err != nil {
+				t.Errorf("replay Apply() error = %v", err)
+			}
+		}),
+		WithWSEventHandler(func(ev WSEvent) { events = append(events, ev.Event) }),
+	)
+	rp := NewWSReplayer(target)
+	if err := rp.ReplayFiles(context.Background(), files...); err != nil {
+		t.Fatalf("ReplayFiles() error = %v", err)
+	}
+	if rp.Frames() != 4 || fmt.Sprint(events) != "[subscribe]" {
+		t.Fatalf("frames = %d events = %v", rp.Frames(), events)
+	}
+	mu.Lock()
+	want := live.Snapshot()
+	mu.Unlock()
+	got := replayed.Snapshot()
+	if got.SeqId != want.SeqId || got.Checksum != want.Checksum || fmt.Sprint(got.Bids) != fmt.Sprint(want.Bids) {
+		t.Fatalf("replayed = %#v, want %#v", got, want)
+	}
+}
+
+func TestWSRecorder_FiltersControl(t *testing.T) {
+	rec, err := NewWSRecorder(filepath.Join(t.TempDir(), "rec"), WithWSRecorderControl(false))
+	if err != nil {
+		t.Fatalf("NewWSRecorder() error = %v", err)
+	}
+	ws := NewClient().NewWSPublic(WithWSRecorder(rec))
+	ws.recordFrame(1, websocket.TextMessage, []byte(`{"event":"subscribe","arg":{"channel":"books"}}`))
+	ws.recordFrame(1, websocket.TextMessage, []byte(`{"id":"1","op":"order","code":"0","data":[]}`))
+	ws.recordFrame(1, websocket.TextMessage, []byte("pong"))
+	ws.recordFrame(2, websocket.TextMessage, []byte(`{"arg":{"channel":"tickers","instId":"BTC-USDT"},"data":[{"last":"1"}]}`))
+	ws.recordFrame(2, websocket.BinaryMessage, []byte{0x27, 0x00, 0xe9, 0x03, 0x01, 0x00, 0x01, 0x00})
+	// 按帧类型而非首字节区分二进制帧。
+	ws.recordFrame(2, websocket.BinaryMessage, []byte{'{', 0x00, 0x01})
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	ws.recordFrame(3, websocket.TextMessage, []byte("ignored after close"))
+
+	records := readWSRecordFiles(t, rec.Files())
+	if len(records) != 3 || records[0].Conn != 2 || records[0].Kind != WSRecordKindData || len(records[1].Bin) != 8 || len(records[2].Bin) != 3 {
+		t.Fatalf("records = %#v", records)
+	}
+}
@@ -0,0 +1,328 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultWSDedupWindow = 8192
+	// defaultWSRedundantDedupWindow 为冗余组按 channel+instId 维护的每个去重窗口容量。
+	defaultWSRedundantDedupWindow = 1024
+	// defaultWSRedundantQueue 为冗余组分发队列容量（满时阻塞各连接的读循环）。
+	defaultWSRedundantQueue = 4096
+)
+
+const (
+	// WSRedundantRoleActive 表示冗余组中当前的主连接（按创建顺序第一条在线的连接）。
+	WSRedundantRoleActive = "active"
+	// WSRedundantRoleStandby 表示冗余组中的热备连接。
+	WSRedundantRoleStandby = "standby"
+)
+
+// WSRedundantFeed 以多条订阅完全相同的 WSClient 连接（热备）接收同一份数据，并在分发前去重，
+// 使 raw/typed handler 对每条深度更新、成交、行情只回调一次。
+//
+// 去重键为 channel+arg 加上：
+// - 深度频道：seqId
+// - trades/trades-all：tradeId
+// - tickers：ts
+// - 其他频道：data 内容摘要
+//
+// 说明：
+// - 去重窗口按 channel+instId（WSArg）分别维护，互不挤占
+// - 各连接去重后的消息进入同一个有序队列，由组内唯一的分发 goroutine 回调 handler：handler 不会并发执行，深度更新按首次到达顺序送达
+// - event/op 回包不去重（每条连接各自处理订阅确认等，并同样经分发队列回调 handler）
+type WSRedundantFeed struct {
+	members []*WSClient
+	// owner 持有 handler（由 newConn 额外创建、不建立连接），仅在分发 goroutine 中回调。
+	owner *WSClient
+
+	mu      sync.Mutex
+	windows map[string]*wsDedup
+	queue   chan wsRedundantFrame
+	cancel  context.CancelFunc
+	ctxDone <-chan struct{}
+	done    chan struct{}
+}
+
+type wsRedundantFrame struct {
+	msgType int
+	msg     []byte
+}
+
+// NewWSRedundantFeed 创建 replicas 条（至少 2 条）热备连接；newConn 用于创建每一条连接（typed handler 通过其 opts 配置）。
+//
+// newConn 会被额外调用一次以创建持有 handler 的分发端（不建立连接）。
+func NewWSRedundantFeed(newConn func() *WSClient, replicas int) *WSRedundantFeed {
+	if replicas < 2 {
+		replicas = 2
+	}
+	f := &WSRedundantFeed{windows: map[string]*wsDedup{}}
+	if newConn == nil {
+		return f
+	}
+	owner := newConn()
+	if owner == nil {
+		return f
+	}
+	// 分发 goroutine 内联回调 raw/typed handler，保证组内 handler 串行执行。
+	owner.typedAsync = false
+	owner.rawAsync = false
+	f.owner = owner
+	for i := 0; i < replicas; i++ {
+		w := newConn()
+		if w == nil {
+			continue
+		}
+		w.redundant = f
+		f.members = append(f.members, w)
+	}
+	return f
+}
+
+// Start 启动所有连接与分发 goroutine；raw handler 仅由分发 goroutine 回调，errHandler 由所有连接共享。
+func (f *WSRedundantFeed) Start(ctx context.Context, handler WSMessageHandler, errHandler WSErrorHandler) error {
+	if f == nil || f.owner == nil || len(f.members) == 0 {
+		return errors.New("okx: ws redundant feed requires newConn")
+	}
+	if f.queue != nil {
+		return errors.New("okx: ws redundant feed already started")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	f.cancel = cancel
+	f.ctxDone = runCtx.Done()
+	f.done = make(chan struct{})
+	f.queue = make(chan wsRedundantFrame, defaultWSRedundantQueue)
+	f.owner.handler = handler
+	f.owner.errHandler = errHandler
+	f.owner.ctxDone = runCtx.Done()
+	go f.dispatchLoop(runCtx)
+
+	for i, w := range f.members {
+		if err := w.Start(runCtx, nil, errHandler); err != nil {
+			for _, started := range f.members[:i] {
+				started.Close()
+			}
+			cancel()
+			return err
+		}
+	}
+	return nil
+}
+
+// Close 关闭所有连接并停止分发。
+func (f *WSRedundantFeed) Close() {
+	if f == nil {
+		return
+	}
+	for _, w := range f.members {
+		w.Close()
+	}
+	if f.cancel != nil {
+		f.cancel()
+	}
+}
+
+// Done 返回所有连接与分发 goroutine 均已停止后的信号通道。
+func (f *WSRedundantFeed) Done() <-chan struct{} {
+	ch := make(chan struct{})
+	go func() {
+		if f != nil {
+			for _, w := range f.members {
+				<-w.Done()
+			}
+			if f.done != nil {
+				<-f.done
+			}
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// Subscribe 在所有连接上订阅相同的频道。
+func (f *WSRedundantFeed) Subscribe(args ...WSArg) error {
+	var firstErr error
+	for _, w := range f.members {
+		if err := w.Subscribe(args...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Unsubscribe 在所有连接上取消订阅。
+func (f *WSRedundantFeed) Unsubscribe(args ...WSArg) error {
+	var firstErr error
+	for _, w := range f.members {
+		if err := w.Unsubscribe(args...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Clients 返回冗余组中的所有连接。
+func (f *WSRedundantFeed) Clients() []*WSClient {
+	return append([]*WSClient(nil), f.members...)
+}
+
+// Stats 返回每条连接的 WSStats（含 RedundantRole/DedupDelivered/DedupDropped）。
+func (f *WSRedundantFeed) Stats() []WSStats {
+	out := make([]WSStats, 0, len(f.members))
+	for _, w := range f.members {
+		out = append(out, w.Stats())
+	}
+	return out
+}
+
+func (f *WSRedundantFeed) roleOf(w *WSClient) string {
+	for _, m := range f.members {
+		m.mu.Lock()
+		connected := m.conn != nil
+		m.mu.Unlock()
+		if connected {
+			if m == w {
+				return WSRedundantRoleActive
+			}
+			return WSRedundantRoleStandby
+		}
+	}
+	return WSRedundantRoleStandby
+}
+
+// forward 对成员连接 w 收到的一帧去重，并按到达顺序放入组分发队列。
+//
+// 去重与入队在同一把锁内完成：先通过去重的消息一定先入队，避免多条连接交错导致乱序。
+func (f *WSRedundantFeed) forward(w *WSClient, msgType int, msg []byte) {
+	scope, key, isData := wsDedupKey(msg)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if isData {
+		d := f.windows[scope]
+		if d == nil {
+			d = newWSDedup(defaultWSRedundantDedupWindow)
+			f.windows[scope] = d
+		}
+		if !d.firstSeen(key) {
+			w.dedupDropped.Add(1)
+			return
+		}
+		w.dedupDelivered.Add(1)
+	}
+	select {
+	case f.queue <- wsRedundantFrame{msgType: msgType, msg: msg}:
+	case <-f.ctxDone:
+	}
+}
+
+func (f *WSRedundantFeed) dispatchLoop(ctx context.Context) {
+	defer close(f.done)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case fr := <-f.queue:
+			f.owner.dispatchRaw(fr.msg)
+			f.owner.handleFrame(fr.msgType, fr.msg)
+		}
+	}
+}
+
+// deliverFrame 分发一帧数据/回包：冗余组成员仅在本连接处理订阅确认等等待者，handler 回调交由组分发 goroutine。
+func (w *WSClient) deliverFrame(msgType int, msg []byte) *WSEvent {
+	if f := w.redundant; f != nil {
+		f.forward(w, msgType, msg)
+		return w.handleFrame(msgType, msg)
+	}
+	w.dispatchRaw(msg)
+	return w.handleFrame(msgType, msg)
+}
+
+// wsDedup 是固定容量的去重窗口（FIFO 淘汰）。
+type wsDedup struct {
+	mu   sync.Mutex
+	ring []string
+	pos  int
+	seen map[string]struct{}
+}
+
+func newWSDedup(window int) *wsDedup {
+	if window <= 0 {
+		window = defaultWSDedupWindow
+	}
+	return &wsDedup{ring: make([]string, window), seen: make(map[string]struct{}, window)}
+}
+
+func (d *wsDedup) firstSeen(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.seen[key]; ok {
+		return false
+	}
+	if old := d.ring[d.pos]; old != "" {
+		delete(d.seen, old)
+	}
+	d.ring[d.pos] = key
+	d.pos = (d.pos + 1) % len(d.ring)
+	d.seen[key] = struct{}{}
+	return true
+}
+
+// wsDedupKey 返回数据推送的去重范围（channel+arg）与去重键；ok=false 表示非数据推送（event/op 回包等）。
+func wsDedupKey(message []byte) (scope string, key string, ok bool) {
+	var probe struct {
+		Arg  *WSArg          `json:"arg"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(message, &probe); err != nil || probe.Arg == nil || probe.Arg.Channel == "" || len(probe.Data) == 0 {
+		return "", "", false
+	}
+
+	var items []struct {
+		SeqId   json.RawMessage `json:"seqId"`
+		TradeId string          `json:"tradeId"`
+		Ts      string          `json:"ts"`
+	}
+	var ids []string
+	channel := probe.Arg.Channel
+	if isOrderBookChannel(channel) || channel == WSChannelTrades || channel == WSChannelTradesAll || channel == WSChannelTickers {
+		if err := json.Unmarshal(probe.Data, &items); err == nil && len(items) > 0 {
+			for _, it := range items {
+				var id string
+				switch {
+				case isOrderBookChannel(channel):
+					id = string(it.SeqId)
+				case channel == WSChannelTickers:
+					id = it.Ts
+				default:
+					id = it.TradeId
+				}
+				if id == "" {
+					ids = nil
+					break
+				}
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	scope = probe.Arg.key()
+	if len(ids) == 0 {
+		h := fnv.New64a()
+		_, _ = h.Write(probe.Data)
+		return scope, scope + "|h:" + strconv.FormatUint(h.Sum64(), 16), true
+	}
+	return scope, scope + "|" + strings.Join(ids, ","), true
+}
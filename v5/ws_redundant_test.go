@@ -0,0 +1,275 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWSDedupKey(t *testing.T) {
+	cases := []struct {
+		name string
+		msg  string
+		want string
+		ok   bool
+	}{
+		{"event", `{"event":"subscribe","arg":{"channel":"trades","instId":"BTC-USDT"}}`, "", false},
+		{"trades", `{"arg":{"channel":"trades","instId":"BTC-USDT"},"data":[{"tradeId":"1"},{"tradeId":"2"}]}`, WSArg{Channel: "trades", InstId: "BTC-USDT"}.key() + "|1,2", true},
+		{"books", `{"arg":{"channel":"books","instId":"BTC-USDT"},"action":"update","data":[{"seqId":123,"ts":"1"}]}`, WSArg{Channel: "books", InstId: "BTC-USDT"}.key() + "|123", true},
+		{"tickers", `{"arg":{"channel":"tickers","instId":"BTC-USDT"},"data":[{"last":"1","ts":"99"}]}`, WSArg{Channel: "tickers", InstId: "BTC-USDT"}.key() + "|99", true},
+	}
+	for _, tc := range cases {
+		_, got, ok := wsDedupKey([]byte(tc.msg))
+		if ok != tc.ok || got != tc.want {
+			t.Fatalf("%s: wsDedupKey() = %q, %v; want %q, %v", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+
+	// 无标识字段的频道按 data 内容去重。
+	_, a, _ := wsDedupKey([]byte(`{"arg":{"channel":"orders","instType":"ANY"},"data":[{"ordId":"1","state":"live"}]}`))
+	_, b, _ := wsDedupKey([]byte(`{"arg":{"channel":"orders","instType":"ANY"},"data":[{"ordId":"1","state":"filled"}]}`))
+	if a == b {
+		t.Fatalf("orders keys should differ: %q", a)
+	}
+
+	// 去重窗口按 channel+instId 分别维护。
+	s1, _, _ := wsDedupKey([]byte(`{"arg":{"channel":"trades","instId":"BTC-USDT"},"data":[{"tradeId":"1"}]}`))
+	s2, _, _ := wsDedupKey([]byte(`{"arg":{"channel":"trades","instId":"ETH-USDT"},"data":[{"tradeId":"1"}]}`))
+	if s1 == s2 || s1 != (WSArg{Channel: "trades", InstId: "BTC-USDT"}).key() {
+		t.Fatalf("scopes = %q/%q", s1, s2)
+	}
+}
+
+func TestWSRedundantFeed_Dedup(t *testing.T) {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	pushes := []string{
+		`{"arg":{"channel":"trades","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","tradeId":"1","px":"1","sz":"1","side":"buy","ts":"1"}]}`,
+		`{"arg":{"channel":"trades","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","tradeId":"2","px":"1","sz":"1","side":"buy","ts":"2"}]}`,
+		`{"arg":{"channel":"trades","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","tradeId":"3","px":"1","sz":"1","side":"sell","ts":"3"}]}`,
+	}
+
+	var connNum atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+		n := connNum.Add(1)
+
+		_, msg, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req wsOpRequest
+		_ = json.Unmarshal(msg, &req)
+		for _, a := range req.Args {
+			b, _ := json.Marshal(WSEvent{ID: req.ID, Event: "subscribe", Arg: &a})
+			_ = c.WriteMessage(websocket.TextMessage, b)
+		}
+		// 第二条连接略晚推送，模拟热备延迟。
+		if n == 2 {
+			time.Sleep(20 * time.Millisecond)
+		}
+		for _, p := range pushes {
+			_ = c.WriteMessage(websocket.TextMessage, []byte(p))
+		}
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	wsURL := "ws" + srv.URL[len("http"):]
+
+	var mu sync.Mutex
+	var got []string
+	c := NewClient()
+	feed := NewWSRedundantFeed(func() *WSClient {
+		return c.NewWSPublic(WithWSURL(wsURL), WithWSTypedHandlerInline(), WithWSTradesHandler(func(trade MarketTrade) {
+			mu.Lock()
+			got = append(got, trade.TradeId)
+			mu.Unlock()
+		}))
+	}, 2)
+	if err := feed.Subscribe(WSArg{Channel: WSChannelTrades, InstId: "BTC-USDT"}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	var raw atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := feed.Start(ctx, func([]byte) { raw.Add(1) }, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	waitFor(t, "dedup", func() bool {
+		var dropped uint64
+		for _, st := range feed.Stats() {
+			dropped += st.DedupDropped
+		}
+		return dropped == 3
+	})
+
+	mu.Lock()
+	if len(got) != 3 || got[0] != "1" || got[1] != "2" || got[2] != "3" {
+		t.Fatalf("trades = %v, want [1 2 3]", got)
+	}
+	mu.Unlock()
+
+	stats := feed.Stats()
+	if stats[0].RedundantRole != WSRedundantRoleActive || stats[1].RedundantRole != WSRedundantRoleStandby {
+		t.Fatalf("roles = %q/%q", stats[0].RedundantRole, stats[1].RedundantRole)
+	}
+	if stats[0].DedupDelivered+stats[1].DedupDelivered != 3 {
+		t.Fatalf("delivered = %d+%d, want 3", stats[0].DedupDelivered, stats[1].DedupDelivered)
+	}
+
+	// 主连接断开后，热备连接成为 active。
+	feed.Clients()[0].Close()
+	<-feed.Clients()[0].Done()
+	if role := feed.Clients()[1].Stats().RedundantRole; role != WSRedundantRoleActive {
+		t.Fatalf("role = %q, want active", role)
+	}
+
+	feed.Close()
+	select {
+	case <-feed.Done():
+	case <-time.After(3 * time.Second):
+		t.Fatalf("timeout waiting Done")
+	}
+}
+
+func TestWSRedundantFeed_OrderedSerialDispatch(t *testing.T) {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	book := func(seq int) []byte {
+		b, _ := json.Marshal(map[string]any{
+			"arg":    WSArg{Channel: WSChannelBooks, InstId: "BTC-USDT"},
+			"action": "update",
+			"data":   []map[string]any{{"asks": [][]string{}, "bids": [][]string{}, "ts": "1", "seqId": seq, "prevSeqId": seq - 1}},
+		})
+		return b
+	}
+
+	var connNum atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+		n := connNum.Add(1)
+
+		_, msg, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req wsOpRequest
+		_ = json.Unmarshal(msg, &req)
+		for _, a := range req.Args {
+			b, _ := json.Marshal(WSEvent{ID: req.ID, Event: "subscribe", Arg: &a})
+			_ = c.WriteMessage(websocket.TextMessage, b)
+		}
+		// 第一条连接先推 1-3 后停顿，第二条连接随后推送 1-6：4-6 由第二条连接先送达。
+		if n == 1 {
+			for seq := 1; seq <= 3; seq++ {
+				_ = c.WriteMessage(websocket.TextMessage, book(seq))
+			}
+			time.Sleep(80 * time.Millisecond)
+			for seq := 4; seq <= 6; seq++ {
+				_ = c.WriteMessage(websocket.TextMessage, book(seq))
+			}
+		} else {
+			time.Sleep(15 * time.Millisecond)
+			for seq := 1; seq <= 6; seq++ {
+				_ = c.WriteMessage(websocket.TextMessage, book(seq))
+			}
+		}
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	wsURL := "ws" + srv.URL[len("http"):]
+
+	var inflight, maxInflight atomic.Int32
+	enter := func() {
+		n := inflight.Add(1)
+		for {
+			m := maxInflight.Load()
+			if n <= m || maxInflight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inflight.Add(-1)
+	}
+
+	var mu sync.Mutex
+	var seqs []int64
+	c := NewClient()
+	feed := NewWSRedundantFeed(func() *WSClient {
+		return c.NewWSPublic(WithWSURL(wsURL), WithWSOrderBookHandler(func(data WSData[WSOrderBook]) {
+			enter()
+			mu.Lock()
+			for _, b := range data.Data {
+				seqs = append(seqs, b.SeqId)
+			}
+			mu.Unlock()
+		}))
+	}, 2)
+	if err := feed.Subscribe(WSArg{Channel: WSChannelBooks, InstId: "BTC-USDT"}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := feed.Start(ctx, func([]byte) { enter() }, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	waitFor(t, "books", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(seqs) >= 6
+	})
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	got := append([]int64(nil), seqs...)
+	mu.Unlock()
+	if len(got) != 6 {
+		t.Fatalf("seqs = %v, want 6 updates", got)
+	}
+	for i, seq := range got {
+		if seq != int64(i+1) {
+			t.Fatalf("seqs = %v, want [1 2 3 4 5 6]", got)
+		}
+	}
+	if m := maxInflight.Load(); m != 1 {
+		t.Fatalf("max concurrent handlers = %d, want 1", m)
+	}
+
+	feed.Close()
+	select {
+	case <-feed.Done():
+	case <-time.After(3 * time.Second):
+		t.Fatalf("timeout waiting Done")
+	}
+}
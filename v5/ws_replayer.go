@@ -0,0 +1,175 @@
+package okx
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WSReplayerOption 用于配置 WSReplayer。
+type WSReplayerOption func(*WSReplayer)
+
+// WithWSReplaySpeed 设置回放速度：1 为按录制节奏，2 为两倍速，<=0 为不等待（尽可能快；默认）。
+func WithWSReplaySpeed(speed float64) WSReplayerOption {
+	return func(r *WSReplayer) {
+		r.speed = speed
+	}
+}
+
+// WithWSReplayControl 设置是否回放控制消息（event/op 回包；默认回放，会触发 event handler / op reply handler）。
+func WithWSReplayControl(enabled bool) WSReplayerOption {
+	return func(r *WSReplayer) {
+		r.control = enabled
+	}
+}
+
+// WSReplayer 将 WSRecorder 的录制文件按序回放到 WSClient 的分发链路（onDataMessage / typed handler / 频道注册表 / SubscribeTyped）。
+//
+// 约定：
+// - 目标 WSClient 应为未 Start 的独立实例：此时 typed handler 在回放 goroutine 中同步执行，回放结果确定；
+// - 心跳帧不回放；深度推送经 OnOrderBook 进入 WSOrderBookStore 时与实盘路径完全一致；
+// - 同一 WSReplayer 多次调用 Replay/ReplayFiles 共享时间轴（按首帧对齐）。
+type WSReplayer struct {
+	w       *WSClient
+	speed   float64
+	control bool
+
+	baseTS   int64
+	baseWall time.Time
+	frames   atomic.Uint64
+}
+
+// NewWSReplayer 创建回放器。
+func NewWSReplayer(w *WSClient, opts ...WSReplayerOption) *WSReplayer {
+	r := &WSReplayer{w: w, control: true}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Frames 返回已回放的帧数。
+func (r *WSReplayer) Frames() uint64 {
+	if r == nil {
+		return 0
+	}
+	return r.frames.Load()
+}
+
+// ReplayFiles 依次回放录制文件（.gz 后缀按 gzip 解压）。
+func (r *WSReplayer) ReplayFiles(ctx context.Context, paths ...string) error {
+	for _, path := range paths {
+		if err := r.replayFile(ctx, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *WSReplayer) replayFile(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var rd io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("okx: ws replay %s: %w", path, err)
+		}
+		defer gz.Close()
+		rd = gz
+	}
+	if err := r.Replay(ctx, rd); err != nil {
+		return fmt.Errorf("okx: ws replay %s: %w", path, err)
+	}
+	return nil
+}
+
+// Replay 回放未压缩的 JSONL 录制流（每行一个 WSRecord）。
+func (r *WSReplayer) Replay(ctx context.Context, rd io.Reader) error {
+	if r == nil || r.w == nil {
+		return errors.New("okx: ws replayer requires ws client")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sc := bufio.NewScanner(rd)
+	sc.Buffer(make([]byte, 0, 64<<10), int(defaultWSReadLimitBytes)*2)
+	line := 0
+	for sc.Scan() {
+		line++
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var rec WSRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		switch rec.Kind {
+		case WSRecordKindHeartbeat:
+			continue
+		case WSRecordKindControl:
+			if !r.control {
+				continue
+			}
+		}
+		if err := r.wait(ctx, rec.TS); err != nil {
+			return err
+		}
+		msgType := websocket.TextMessage
+		if len(rec.Bin) > 0 {
+			msgType = websocket.BinaryMessage
+		}
+		r.w.replayFrame(msgType, rec.Payload())
+		r.frames.Add(1)
+	}
+	return sc.Err()
+}
+
+// wait 按录制时间轴与回放速度等待到该帧的回放时刻。
+func (r *WSReplayer) wait(ctx context.Context, ts int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r.speed <= 0 {
+		return nil
+	}
+	if r.baseWall.IsZero() {
+		r.baseTS = ts
+		r.baseWall = time.Now()
+		return nil
+	}
+	offset := time.Duration(float64(ts-r.baseTS) / r.speed)
+	d := time.Until(r.baseWall.Add(offset))
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// replayFrame 将一帧送入与 readLoop 相同的分发链路（不含心跳与连接管理）。
+func (w *WSClient) replayFrame(msgType int, message []byte) {
+	w.dispatchRaw(message)
+	w.handleFrame(msgType, message)
+}
@@ -0,0 +1,96 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func wsReplayTestStream(t *testing.T, gap time.Duration, frames ...[]byte) string {
+	t.Helper()
+	var sb strings.Builder
+	ts := time.Unix(1700000000, 0).UnixNano()
+	for i, f := range frames {
+		b, err := json.Marshal(WSRecord{TS: ts + int64(i)*int64(gap), Conn: 1, Kind: WSRecordKindData, Msg: f})
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		sb.Write(b)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func TestWSReplayer_Speed(t *testing.T) {
+	stream := wsReplayTestStream(t, 100*time.Millisecond,
+		wsRecorderTestBookMsg("snapshot", -1, 10, "1"),
+		wsRecorderTestBookMsg("update", 10, 11, "2"),
+		wsRecorderTestBookMsg("update", 11, 12, "3"),
+	)
+
+	var seqs []int64
+	w := NewClient().NewWSPublic(WithWSOrderBookHandler(func(dm WSData[WSOrderBook]) {
+		seqs = append(seqs, dm.Data[0].SeqId)
+	}))
+
+	start := time.Now()
+	if err := NewWSReplayer(w).Replay(context.Background(), strings.NewReader(stream)); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if d := time.Since(start); d > 100*time.Millisecond {
+		t.Fatalf("fast replay took %v", d)
+	}
+	if len(seqs) != 3 || seqs[2] != 12 {
+		t.Fatalf("seqs = %v", seqs)
+	}
+
+	// 10 倍速：录制跨度 200ms -> 约 20ms。
+	start = time.Now()
+	if err := NewWSReplayer(w, WithWSReplaySpeed(10)).Replay(context.Background(), strings.NewReader(stream)); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if d := time.Since(start); d < 18*time.Millisecond || d > 150*time.Millisecond {
+		t.Fatalf("10x replay took %v", d)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err := NewWSReplayer(w, WithWSReplaySpeed(1)).Replay(ctx, strings.NewReader(stream))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Replay() error = %v, want deadline exceeded", err)
+	}
+}
+
+func TestWSReplayer_SkipsHeartbeatAndControl(t *testing.T) {
+	lines := []WSRecord{
+		{TS: 1, Kind: WSRecordKindControl, Msg: json.RawMessage(`{"event":"subscribe","arg":{"channel":"books","instId":"BTC-USDT"}}`)},
+		{TS: 2, Kind: WSRecordKindHeartbeat, Text: "pong"},
+		{TS: 3, Kind: WSRecordKindData, Msg: wsRecorderTestBookMsg("snapshot", -1, 10, "1")},
+	}
+	var sb strings.Builder
+	for _, l := range lines {
+		b, _ := json.Marshal(l)
+		sb.Write(b)
+		sb.WriteByte('\n')
+	}
+
+	var events, books int
+	w := NewClient().NewWSPublic(
+		WithWSEventHandler(func(WSEvent) { events++ }),
+		WithWSOrderBookHandler(func(WSData[WSOrderBook]) { books++ }),
+	)
+	rp := NewWSReplayer(w, WithWSReplayControl(false))
+	if err := rp.Replay(context.Background(), strings.NewReader(sb.String())); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if events != 0 || books != 1 || rp.Frames() != 1 {
+		t.Fatalf("events=%d books=%d frames=%d", events, books, rp.Frames())
+	}
+
+	if err := rp.Replay(context.Background(), strings.NewReader("{bad json\n")); err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
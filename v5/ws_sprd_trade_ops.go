@@ -109,7 +109,7 @@ func (w *WSClient) SprdPlaceOrder(ctx context.Context, arg WSSprdPlaceOrderArg)
 		return nil, err
 	}
 
-	reply, raw, err := w.doOpAndWaitRaw(ctx, wsOpSprdOrder, []WSSprdPlaceOrderArg{arg})
+	reply, raw, err := w.doOpAndWaitRaw(ctx, CapabilityTrade, wsOpSprdOrder, []WSSprdPlaceOrderArg{arg})
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +145,7 @@ func (w *WSClient) SprdCancelOrder(ctx context.Context, arg WSSprdCancelOrderArg
 		return nil, err
 	}
 
-	reply, raw, err := w.doOpAndWaitRaw(ctx, wsOpSprdCancelOrder, []WSSprdCancelOrderArg{arg})
+	reply, raw, err := w.doOpAndWaitRaw(ctx, CapabilityTrade, wsOpSprdCancelOrder, []WSSprdCancelOrderArg{arg})
 	if err != nil {
 		return nil, err
 	}
@@ -181,7 +181,7 @@ func (w *WSClient) SprdAmendOrder(ctx context.Context, arg WSSprdAmendOrderArg)
 		return nil, err
 	}
 
-	reply, raw, err := w.doOpAndWaitRaw(ctx, wsOpSprdAmendOrder, []WSSprdAmendOrderArg{arg})
+	reply, raw, err := w.doOpAndWaitRaw(ctx, CapabilityTrade, wsOpSprdAmendOrder, []WSSprdAmendOrderArg{arg})
 	if err != nil {
 		return nil, err
 	}
@@ -241,7 +241,7 @@ func (w *WSClient) SprdMassCancel(ctx context.Context, arg WSSprdMassCancelArg)
 		return nil, err
 	}
 
-	reply, raw, err := w.doOpAndWaitRaw(ctx, wsOpSprdMassCancel, []WSSprdMassCancelArg{arg})
+	reply, raw, err := w.doOpAndWaitRaw(ctx, CapabilityTrade, wsOpSprdMassCancel, []WSSprdMassCancelArg{arg})
 	if err != nil {
 		return nil, err
 	}
@@ -31,6 +31,8 @@ type WSStats struct {
 	SubscribeError   uint64
 	UnsubscribeOK    uint64
 	UnsubscribeError uint64
+	// OpThrottled 为 subscribe/unsubscribe/login 请求因连接级限速（WithWSOpRateLimit）而等待的次数。
+	OpThrottled uint64
 
 	DesiredSubscriptions int
 	Waiters              int
@@ -44,7 +46,33 @@ type WSStats struct {
 	TypedDropped uint64
 	RawDropped   uint64
 
+	// DispatchQueues 为按类别拆分的 typed 队列状态（WithWSPriorityDispatch；TypedQueueLen/Cap/TypedDropped 为各队列之和）。
+	DispatchQueues []WSDispatchQueueStats
+
+	// Conflated 为合并分发（WithWSConflation）中被更新值覆盖、未单独送达的推送数；ConflationPending 为待送达的 key 数。
+	Conflated         uint64
+	ConflationPending int
+
 	Backoff time.Duration
+
+	// Handovers 为 64008 先连后断（WithWSMakeBeforeBreak）完成切换的次数。
+	Handovers uint64
+	// HandoverDropped 为切换重叠期内被去重丢弃的数据消息数。
+	HandoverDropped uint64
+
+	// RedundantRole 为热备冗余组（WSRedundantFeed）中的角色（active/standby；非冗余连接为空）。
+	RedundantRole string
+	// DedupDelivered 为冗余组中由该连接首先送达并分发的数据消息数。
+	DedupDelivered uint64
+	// DedupDropped 为冗余组中因已由其他连接送达而被丢弃的数据消息数。
+	DedupDropped uint64
+
+	// PushLatency 为按频道的推送延迟分布（数据 ts -> 本地接收；需 WithWSLatencyTelemetry/WithWSLatencyHook）。
+	PushLatency map[string]WSLatencyStats
+	// OpGatewayLatency 为按 op 的网关耗时分布（outTime - inTime）。
+	OpGatewayLatency map[string]WSLatencyStats
+	// OpRoundTrip 为按 op 的往返耗时分布（发送 -> 收到回包）。
+	OpRoundTrip map[string]WSLatencyStats
 }
 
 // Stats 返回 WSClient 的运行状态快照（并发安全）。
@@ -55,7 +83,7 @@ func (w *WSClient) Stats() WSStats {
 	}
 
 	s.Endpoint = w.endpoint
-	s.NeedLogin = w.needLogin
+	s.NeedLogin = w.needLogin || w.headerLogin
 	s.Started = w.started.Load()
 
 	switch w.kind {
@@ -65,6 +93,8 @@ func (w *WSClient) Stats() WSStats {
 		s.Kind = "private"
 	case wsKindBusiness:
 		s.Kind = "business"
+	case wsKindPublicSBE:
+		s.Kind = "public-sbe"
 	default:
 		s.Kind = "unknown"
 	}
@@ -95,6 +125,7 @@ func (w *WSClient) Stats() WSStats {
 	s.SubscribeError = w.subscribeErr.Load()
 	s.UnsubscribeOK = w.unsubscribeOK.Load()
 	s.UnsubscribeError = w.unsubscribeErr.Load()
+	s.OpThrottled = w.opThrottled.Load()
 
 	if q := w.typedQueue; q != nil {
 		s.TypedQueueLen = len(q)
@@ -105,10 +136,41 @@ func (w *WSClient) Stats() WSStats {
 		s.RawQueueCap = cap(q)
 	}
 	s.TypedDropped = w.typedDropped.Load()
+	if c := w.conflator; c != nil {
+		s.Conflated = c.conflated.Load()
+		s.ConflationPending = c.pendingLen()
+	}
+	if qs := w.dispatchQueueStats(); qs != nil {
+		s.DispatchQueues = qs
+		for _, q := range qs {
+			s.TypedQueueLen += q.QueueLen
+			s.TypedQueueCap += q.QueueCap
+			s.TypedDropped += q.Dropped
+		}
+	}
 	s.RawDropped = w.rawDropped.Load()
+	s.Handovers = w.handovers.Load()
+	s.HandoverDropped = w.overlapDropped.Load()
+
+	if w.redundant != nil {
+		s.RedundantRole = w.redundant.roleOf(w)
+		s.DedupDelivered = w.dedupDelivered.Load()
+		s.DedupDropped = w.dedupDropped.Load()
+	}
+
+	if w.latency != nil {
+		s.PushLatency = w.latency.snapshot(WSLatencyKindPush)
+		s.OpGatewayLatency = w.latency.snapshot(WSLatencyKindOpGateway)
+		s.OpRoundTrip = w.latency.snapshot(WSLatencyKindOpRoundTrip)
+	}
 
 	w.waitMu.Lock()
-	s.Waiters = len(w.waiters)
+	for id, waiter := range w.waiters {
+		// 拆批发送的 waiter 对应多个 id，仅按首个 id 计数一次。
+		if len(waiter.ids) == 0 || waiter.ids[0] == id {
+			s.Waiters++
+		}
+	}
 	w.waitMu.Unlock()
 
 	w.opWaitMu.Lock()
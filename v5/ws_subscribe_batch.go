@@ -0,0 +1,131 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// defaultWSSubscribeFrameBytes 为单个 subscribe/unsubscribe 帧的默认上限（OKX：单次请求频道参数合计不超过 64KB）。
+	defaultWSSubscribeFrameBytes = 64 * 1024
+	// wsOpFrameOverhead 为帧外层 {"id":"...","op":"unsubscribe","args":[]} 的预留字节数。
+	wsOpFrameOverhead = 64
+
+	// OKX：每条连接 subscribe/unsubscribe/login 合计 480 次/小时。
+	// 默认 burst=240、速率 240 次/小时，保证任意 1 小时窗口内不超过 480 次。
+	defaultWSOpRateBurst   = 240
+	defaultWSOpRatePerHour = 240
+)
+
+// WithWSSubscribeFrameBytes 设置单个 subscribe/unsubscribe 帧的字节上限；超出时自动拆分为多帧发送。
+//
+// 默认 64KB。若传入 n<=0，将回退到默认值。
+func WithWSSubscribeFrameBytes(n int) WSOption {
+	return func(c *WSClient) {
+		if n <= 0 {
+			n = defaultWSSubscribeFrameBytes
+		}
+		c.subscribeFrameBytes = n
+	}
+}
+
+// WithWSOpRateLimit 设置连接级 subscribe/unsubscribe/login 请求限速（token bucket，每条连接独立计数）。
+//
+// 默认 burst=240、rps=240/3600（任意 1 小时不超过 OKX 的 480 次/连接）；rps<=0 或 burst<=0 时禁用。
+// 限速生效时 Subscribe/Unsubscribe 会阻塞等待 token；*AndWait 版本受 ctx 控制。
+func WithWSOpRateLimit(rps float64, burst int) WSOption {
+	return func(c *WSClient) {
+		if rps <= 0 || burst <= 0 {
+			rps, burst = -1, 0
+		}
+		c.opRateRPS = rps
+		c.opRateBurst = burst
+	}
+}
+
+// batchWSArgs 按序将 args 拆分为序列化后不超过 maxBytes 的批次（单个超限的 arg 独占一批）。
+func batchWSArgs(args []WSArg, maxBytes int) [][]WSArg {
+	if maxBytes <= 0 {
+		maxBytes = defaultWSSubscribeFrameBytes
+	}
+	var out [][]WSArg
+	var cur []WSArg
+	size := wsOpFrameOverhead
+	for _, a := range args {
+		n := 1 // 分隔逗号
+		if b, err := json.Marshal(a); err == nil {
+			n += len(b)
+		}
+		if len(cur) > 0 && size+n > maxBytes {
+			out = append(out, cur)
+			cur = nil
+			size = wsOpFrameOverhead
+		}
+		cur = append(cur, a)
+		size += n
+	}
+	if len(cur) > 0 {
+		out = append(out, cur)
+	}
+	return out
+}
+
+// opLimiterFor 返回连接 conn 的 op 限速器（连接变化时重建，OKX 按连接计数）。
+func (w *WSClient) opLimiterFor(conn *websocket.Conn) *tokenBucketLimiter {
+	w.opLimitMu.Lock()
+	defer w.opLimitMu.Unlock()
+	if w.opLimitConn != conn {
+		rps, burst := w.opRateRPS, w.opRateBurst
+		if rps == 0 {
+			rps, burst = float64(defaultWSOpRatePerHour)/time.Hour.Seconds(), defaultWSOpRateBurst
+		}
+		w.opLimitConn = conn
+		w.opLimiter = newTokenBucketLimiter(rps, burst)
+	}
+	return w.opLimiter
+}
+
+// waitOpLimit 等待连接级 op 限速 token，并统计被限速的次数。
+func (w *WSClient) waitOpLimit(ctx context.Context, conn *websocket.Conn) error {
+	l := w.opLimiterFor(conn)
+	if l == nil {
+		return nil
+	}
+	if l.takeOrComputeWait() <= 0 {
+		return nil
+	}
+	w.opThrottled.Add(1)
+	return l.Wait(ctx)
+}
+
+// writeArgsOp 将 subscribe/unsubscribe 的 args 按帧大小拆批，逐帧经连接级限速后通过 write 写出。
+// wait=true 时返回覆盖全部批次的 waiter（全部 arg 确认后完成，任一批次 error 即失败）；写出失败时自动移除 waiter。
+func (w *WSClient) writeArgsOp(ctx context.Context, conn *websocket.Conn, op string, args []WSArg, wait bool, write func(*websocket.Conn, any) error) (*wsOpWaiter, error) {
+	batches := batchWSArgs(args, w.subscribeFrameBytes)
+	ids := make([]string, len(batches))
+	for i := range ids {
+		ids[i] = w.nextOpID()
+	}
+	var waiter *wsOpWaiter
+	if wait && len(ids) > 0 {
+		waiter = w.registerWaiterIDs(ids, op, args)
+	}
+	for i, batch := range batches {
+		if err := w.waitOpLimit(ctx, conn); err != nil {
+			if waiter != nil {
+				w.removeWaiter(ids[0])
+			}
+			return nil, err
+		}
+		if err := write(conn, wsOpRequest{ID: ids[i], Op: op, Args: batch}); err != nil {
+			if waiter != nil {
+				w.removeWaiter(ids[0])
+			}
+			return nil, err
+		}
+	}
+	return waiter, nil
+}
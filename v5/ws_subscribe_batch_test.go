@@ -0,0 +1,154 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestBatchWSArgs(t *testing.T) {
+	args := []WSArg{
+		{Channel: "tickers", InstId: "A"},
+		{Channel: "tickers", InstId: "B"},
+		{Channel: "tickers", InstId: "C"},
+	}
+	one, _ := json.Marshal(args[0])
+	// 每批最多容纳 2 个 arg。
+	max := wsOpFrameOverhead + 2*(len(one)+1)
+	batches := batchWSArgs(args, max)
+	if len(batches) != 2 || len(batches[0]) != 2 || batches[1][0].InstId != "C" {
+		t.Fatalf("batches = %#v", batches)
+	}
+	// 单个 arg 超限时独占一批。
+	if got := batchWSArgs(args, 1); len(got) != 3 {
+		t.Fatalf("oversize batches = %d", len(got))
+	}
+	if got := batchWSArgs(nil, 0); len(got) != 0 {
+		t.Fatalf("empty batches = %#v", got)
+	}
+}
+
+func TestWSClient_SubscribeAndWait_BatchesLargeArgSets(t *testing.T) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	var mu sync.Mutex
+	var frames []int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req wsOpRequest
+			if err := json.Unmarshal(msg, &req); err != nil {
+				continue
+			}
+			mu.Lock()
+			frames = append(frames, len(msg))
+			mu.Unlock()
+			for _, a := range req.Args {
+				b, _ := json.Marshal(WSEvent{ID: req.ID, Event: req.Op, Arg: &a})
+				_ = c.WriteMessage(websocket.TextMessage, b)
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	const frameBytes = 4096
+	ws := NewClient().NewWSPublic(
+		WithWSURL("ws"+srv.URL[len("http"):]),
+		WithWSSubscribeFrameBytes(frameBytes),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ws.Start(ctx, nil, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(ws.Close)
+
+	args := make([]WSArg, 2000)
+	for i := range args {
+		args[i] = WSArg{Channel: WSChannelTickers, InstId: fmt.Sprintf("COIN%04d-USDT", i)}
+	}
+	if err := ws.SubscribeAndWait(ctx, args...); err != nil {
+		t.Fatalf("SubscribeAndWait() error = %v", err)
+	}
+
+	mu.Lock()
+	n := len(frames)
+	for _, size := range frames {
+		if size > frameBytes {
+			mu.Unlock()
+			t.Fatalf("frame size %d > %d", size, frameBytes)
+		}
+	}
+	mu.Unlock()
+	if n < 2 {
+		t.Fatalf("frames = %d, want batched", n)
+	}
+	st := ws.Stats()
+	if st.DesiredSubscriptions != len(args) || st.SubscribeOK != 1 || st.Waiters != 0 {
+		t.Fatalf("stats: desired=%d ok=%d waiters=%d", st.DesiredSubscriptions, st.SubscribeOK, st.Waiters)
+	}
+}
+
+func TestWSClient_BatchWaiter_ErrorRemovesAllIDs(t *testing.T) {
+	w := &WSClient{waiters: map[string]*wsOpWaiter{}}
+	a1 := WSArg{Channel: "tickers", InstId: "A"}
+	a2 := WSArg{Channel: "tickers", InstId: "B"}
+	waiter := w.registerWaiterIDs([]string{"1", "2"}, "subscribe", []WSArg{a1, a2})
+	if st := w.Stats(); st.Waiters != 1 {
+		t.Fatalf("Waiters = %d", st.Waiters)
+	}
+
+	w.notifyWaiter(WSEvent{ID: "1", Event: "subscribe", Arg: &a1})
+	w.notifyWaiter(WSEvent{ID: "2", Event: "error", Code: "60018", Msg: "bad"})
+	if err := <-waiter.done; err == nil {
+		t.Fatalf("expected error")
+	}
+	if len(w.waiters) != 0 {
+		t.Fatalf("waiters = %#v", w.waiters)
+	}
+}
+
+func TestWSClient_OpLimiter_PerConnection(t *testing.T) {
+	w := &WSClient{}
+	WithWSOpRateLimit(1000, 1)(w)
+	c1, c2 := &websocket.Conn{}, &websocket.Conn{}
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := w.waitOpLimit(ctx, c1); err != nil {
+			t.Fatalf("waitOpLimit() error = %v", err)
+		}
+	}
+	if got := w.opThrottled.Load(); got != 2 {
+		t.Fatalf("throttled = %d", got)
+	}
+	// 新连接重新计数（burst 可用）。
+	if err := w.waitOpLimit(ctx, c2); err != nil || w.opThrottled.Load() != 2 {
+		t.Fatalf("new conn throttled: err=%v n=%d", err, w.opThrottled.Load())
+	}
+
+	// 默认按 OKX 480 次/小时/连接 限速。
+	d := &WSClient{}
+	if l := d.opLimiterFor(c1); l == nil || l.burst != defaultWSOpRateBurst {
+		t.Fatalf("default limiter = %#v", l)
+	}
+	WithWSOpRateLimit(0, 0)(d)
+	if l := d.opLimiterFor(c2); l != nil {
+		t.Fatalf("disabled limiter = %#v", l)
+	}
+}
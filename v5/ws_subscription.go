@@ -0,0 +1,363 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+const defaultWSSubscriptionBuffer = 1024
+
+// SubscriptionOption 用于配置 SubscribeTyped 返回的 Subscription。
+type SubscriptionOption func(*subscriptionConfig)
+
+type subscriptionConfig struct {
+	buffer int
+	policy WSQueueFullPolicy
+}
+
+// WithSubscriptionBuffer 设置 Subscription 的 channel 缓冲大小（默认 1024）。
+func WithSubscriptionBuffer(buffer int) SubscriptionOption {
+	return func(c *subscriptionConfig) {
+		if buffer <= 0 {
+			buffer = defaultWSSubscriptionBuffer
+		}
+		c.buffer = buffer
+	}
+}
+
+// WithSubscriptionQueueFullPolicy 设置 Subscription 的 channel 满时的策略（默认 WSQueueFullBlock）。
+//
+// 注意：block 会阻塞 WS read goroutine（影响同一连接上的所有推送）；drop 丢弃该条数据；disconnect 关闭连接并触发重连。
+func WithSubscriptionQueueFullPolicy(policy WSQueueFullPolicy) SubscriptionOption {
+	return func(c *subscriptionConfig) {
+		c.policy = policy
+	}
+}
+
+// Subscription 是 SubscribeTyped 返回的单个订阅：拥有独立的 channel、背压策略与生命周期。
+//
+// data 数组中的每个元素会作为一个 T 投递（深度频道的 action 等消息级字段不包含在 T 中）。
+type Subscription[T any] struct {
+	w      *WSClient
+	arg    WSArg
+	policy WSQueueFullPolicy
+
+	ch        chan T
+	done      chan struct{}
+	mu        sync.RWMutex
+	closeOnce sync.Once
+	closeErr  error
+
+	dropped atomic.Uint64
+}
+
+// wsSubscriber 为按订阅参数匹配推送并自行解码投递的订阅者。
+type wsSubscriber interface {
+	subscriptionArg() WSArg
+	deliver(message []byte)
+}
+
+// SubscribeTyped 订阅 arg 并返回独立的 typed Subscription（data 中每个元素解码为 T）。
+//
+// 约定：
+// - 若 WSClient 已启动，会等待订阅确认（ctx 仅控制等待超时）；未启动时仅记录订阅，Start 后自动发送；
+// - 同一 arg 的首个订阅仍在等待确认时，后续 SubscribeTyped 等待同一结果，失败时一并返回错误；
+// - Subscription 的生命周期仅由 Close 控制（ctx 结束不会关闭已返回的 Subscription）；最后一个使用该 arg 的 Subscription 关闭时会取消订阅；
+// - arg 此前已通过 WSClient.Subscribe 订阅（OnXxx/订单簿等）时复用该订阅，关闭时不取消；
+// - 推送按订阅参数匹配（arg 中非空字段需与推送 arg 一致），同一频道的不同产品可由不同 Subscription 独立消费。
+func SubscribeTyped[T any](ctx context.Context, w *WSClient, arg WSArg, opts ...SubscriptionOption) (*Subscription[T], error) {
+	if w == nil {
+		return nil, errors.New("okx: ws subscribe typed requires client")
+	}
+	if ctx == nil {
+		return nil, errors.New("okx: nil context")
+	}
+	if arg.Channel == "" {
+		return nil, errors.New("okx: ws subscribe requires channel")
+	}
+
+	cfg := subscriptionConfig{buffer: defaultWSSubscriptionBuffer, policy: WSQueueFullBlock}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := &Subscription[T]{
+		w:      w,
+		arg:    arg,
+		policy: cfg.policy,
+		ch:     make(chan T, cfg.buffer),
+		done:   make(chan struct{}),
+	}
+	if err := w.subscribeShared(ctx, s); err != nil {
+		s.closeLocal()
+		return nil, err
+	}
+	return s, nil
+}
+
+// C 返回接收推送的 channel（Close 后关闭）。
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// All 返回按序遍历推送的迭代器（Close 后结束）。
+func (s *Subscription[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Arg 返回订阅参数。
+func (s *Subscription[T]) Arg() WSArg {
+	return s.arg
+}
+
+// Dropped 返回因 channel 满（drop/disconnect 策略）被丢弃的元素数。
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close 关闭 Subscription；若已无其他 Subscription 使用该 arg 且该订阅由 SubscribeTyped 新增，则取消订阅。
+func (s *Subscription[T]) Close() error {
+	s.closeOnce.Do(func() {
+		if s.w.removeSubscriber(s) {
+			s.closeErr = s.w.Unsubscribe(s.arg)
+		}
+		s.closeLocal()
+	})
+	return s.closeErr
+}
+
+func (s *Subscription[T]) closeLocal() {
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+	close(s.done)
+	s.mu.Lock()
+	close(s.ch)
+	s.mu.Unlock()
+}
+
+func (s *Subscription[T]) subscriptionArg() WSArg {
+	return s.arg
+}
+
+func (s *Subscription[T]) deliver(message []byte) {
+	dm, ok, err := WSParseData[T](message)
+	if !s.w.wsParseGuard(s.arg.Channel, ok, err) {
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, v := range dm.Data {
+		select {
+		case <-s.done:
+			return
+		case s.ch <- v:
+			continue
+		default:
+		}
+
+		switch s.policy {
+		case WSQueueFullDrop:
+			s.dropped.Add(1)
+		case WSQueueFullDisconnect:
+			s.dropped.Add(1)
+			s.w.onError(&WSQueueFullError{Queue: "subscription", Kind: s.arg.Channel, Policy: s.policy, QueueLen: len(s.ch), QueueCap: cap(s.ch)})
+			s.w.closeConn()
+			return
+		default: // WSQueueFullBlock
+			select {
+			case s.ch <- v:
+			case <-s.done:
+				return
+			case <-s.w.ctxDone:
+				return
+			}
+		}
+	}
+}
+
+// wsSubscribePending 为同一 arg 首个订阅者发送订阅、等待确认期间的共享结果。
+type wsSubscribePending struct {
+	done chan struct{}
+	err  error
+}
+
+// addSubscriber 注册订阅者；first=true 表示该 arg 尚无其他订阅者（调用方需发送订阅并在完成后 settle pending）。
+// first=false 且 pending 非空表示首个订阅者仍在等待确认。
+func (w *WSClient) addSubscriber(s wsSubscriber) (first bool, pending *wsSubscribePending) {
+	key := s.subscriptionArg().key()
+	w.typedMu.Lock()
+	defer w.typedMu.Unlock()
+	first = true
+	for _, other := range w.subscribers {
+		if other.subscriptionArg().key() == key {
+			first = false
+			break
+		}
+	}
+	w.subscribers = append(w.subscribers, s)
+	if w.subscribePending == nil {
+		w.subscribePending = make(map[string]*wsSubscribePending)
+	}
+	if first {
+		pending = &wsSubscribePending{done: make(chan struct{})}
+		w.subscribePending[key] = pending
+		return true, pending
+	}
+	return false, w.subscribePending[key]
+}
+
+// settleSubscribe 记录首个订阅者的订阅结果并唤醒等待同一 arg 的订阅者；失败时一并移除该 arg 的全部订阅者。
+func (w *WSClient) settleSubscribe(key string, pending *wsSubscribePending, err error) {
+	w.typedMu.Lock()
+	if w.subscribePending[key] == pending {
+		delete(w.subscribePending, key)
+	}
+	if err != nil {
+		out := w.subscribers[:0]
+		for _, other := range w.subscribers {
+			if other.subscriptionArg().key() != key {
+				out = append(out, other)
+			}
+		}
+		for i := len(out); i < len(w.subscribers); i++ {
+			w.subscribers[i] = nil
+		}
+		w.subscribers = out
+		delete(w.subscriberOwned, key)
+	}
+	w.typedMu.Unlock()
+	pending.err = err
+	close(pending.done)
+}
+
+// subscribeShared 注册订阅者；若为该 arg 的首个订阅者且 arg 尚未订阅则发送订阅（已启动时等待确认）。
+// 首个订阅者仍在等待确认时，后续订阅者等待同一结果；失败时移除该 arg 的全部订阅者。
+func (w *WSClient) subscribeShared(ctx context.Context, s wsSubscriber) error {
+	arg := s.subscriptionArg()
+	first, pending := w.addSubscriber(s)
+	if !first {
+		if pending == nil {
+			return nil
+		}
+		select {
+		case <-pending.done:
+		case <-ctx.Done():
+			w.removeSubscriber(s)
+			return ctx.Err()
+		}
+		return pending.err
+	}
+
+	w.mu.Lock()
+	_, desired := w.desired[arg.key()]
+	w.mu.Unlock()
+	w.setSubscriberOwned(arg.key(), !desired)
+	if desired {
+		// 已通过 Subscribe 订阅（OnXxx/订单簿等）：复用该订阅，关闭时不取消。
+		w.settleSubscribe(arg.key(), pending, nil)
+		return nil
+	}
+
+	var err error
+	if w.started.Load() {
+		err = w.SubscribeAndWait(ctx, arg)
+	} else {
+		err = w.Subscribe(arg)
+	}
+	w.settleSubscribe(arg.key(), pending, err)
+	return err
+}
+
+func (w *WSClient) setSubscriberOwned(key string, owned bool) {
+	w.typedMu.Lock()
+	defer w.typedMu.Unlock()
+	if w.subscriberOwned == nil {
+		w.subscriberOwned = make(map[string]bool)
+	}
+	w.subscriberOwned[key] = owned
+}
+
+// removeSubscriber 移除订阅者；返回 true 表示该 arg 已无其他订阅者且订阅由 subscribeShared 新增（需取消订阅）。
+func (w *WSClient) removeSubscriber(s wsSubscriber) bool {
+	key := s.subscriptionArg().key()
+	w.typedMu.Lock()
+	defer w.typedMu.Unlock()
+	last := true
+	out := w.subscribers[:0]
+	for _, other := range w.subscribers {
+		if other == s {
+			continue
+		}
+		if other.subscriptionArg().key() == key {
+			last = false
+		}
+		out = append(out, other)
+	}
+	for i := len(out); i < len(w.subscribers); i++ {
+		w.subscribers[i] = nil
+	}
+	w.subscribers = out
+	if !last {
+		return false
+	}
+	owned := w.subscriberOwned[key]
+	delete(w.subscriberOwned, key)
+	return owned
+}
+
+// dispatchSubscribers 将数据推送投递给参数匹配的订阅者（在 read goroutine 中执行）。
+func (w *WSClient) dispatchSubscribers(message []byte) {
+	w.typedMu.RLock()
+	if len(w.subscribers) == 0 {
+		w.typedMu.RUnlock()
+		return
+	}
+	subs := append([]wsSubscriber(nil), w.subscribers...)
+	w.typedMu.RUnlock()
+
+	var probe struct {
+		Arg WSArg `json:"arg"`
+	}
+	if err := json.Unmarshal(message, &probe); err != nil || probe.Arg.Channel == "" {
+		return
+	}
+	for _, s := range subs {
+		if wsArgMatches(s.subscriptionArg(), probe.Arg) {
+			s.deliver(message)
+		}
+	}
+}
+
+// wsArgMatches 判断推送 arg 是否属于订阅 sub：channel 相同，且 sub 中的非空字段与推送一致（推送可能额外携带 uid 等字段）。
+//
+// extraParams 不参与匹配：推送不一定原样回显该字段。
+func wsArgMatches(sub, push WSArg) bool {
+	if sub.Channel != push.Channel {
+		return false
+	}
+	eq := func(want, got string) bool {
+		return want == "" || want == got
+	}
+	return eq(sub.InstId, push.InstId) &&
+		eq(sub.InstType, push.InstType) &&
+		eq(sub.InstFamily, push.InstFamily) &&
+		eq(sub.SprdId, push.SprdId) &&
+		eq(sub.Uly, push.Uly) &&
+		eq(sub.AlgoId, push.AlgoId) &&
+		eq(sub.UID, push.UID) &&
+		eq(sub.Ccy, push.Ccy)
+}
@@ -0,0 +1,265 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWSArgMatches(t *testing.T) {
+	sub := WSArg{Channel: WSChannelOrders, InstType: "ANY"}
+	if !wsArgMatches(sub, WSArg{Channel: WSChannelOrders, InstType: "ANY", UID: "1"}) {
+		t.Fatalf("expected match with extra uid")
+	}
+	if wsArgMatches(WSArg{Channel: WSChannelTickers, InstId: "BTC-USDT"}, WSArg{Channel: WSChannelTickers, InstId: "ETH-USDT"}) {
+		t.Fatalf("expected mismatch on instId")
+	}
+	// extraParams 不参与匹配（推送不一定回显）。
+	if !wsArgMatches(WSArg{Channel: WSChannelBooks, InstId: "BTC-USDT", ExtraParams: `{"updateInterval":"0"}`}, WSArg{Channel: WSChannelBooks, InstId: "BTC-USDT"}) {
+		t.Fatalf("expected match ignoring extraParams")
+	}
+}
+
+func TestSubscribeTyped(t *testing.T) {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	ops := make(chan wsOpRequest, 16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req wsOpRequest
+			if err := json.Unmarshal(msg, &req); err != nil {
+				continue
+			}
+			ops <- req
+			for _, a := range req.Args {
+				b, _ := json.Marshal(WSEvent{ID: req.ID, Event: req.Op, Arg: &a})
+				_ = c.WriteMessage(websocket.TextMessage, b)
+				if req.Op == "subscribe" {
+					push, _ := json.Marshal(map[string]any{
+						"arg":  a,
+						"data": []map[string]string{{"instId": a.InstId, "last": "1", "ts": "1"}, {"instId": a.InstId, "last": "2", "ts": "2"}},
+					})
+					_ = c.WriteMessage(websocket.TextMessage, push)
+				}
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	wsURL := "ws" + srv.URL[len("http"):]
+
+	c := NewClient()
+	ws := c.NewWSPublic(WithWSURL(wsURL))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ws.Start(ctx, nil, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	btcArg := WSArg{Channel: WSChannelTickers, InstId: "BTC-USDT"}
+	btc, err := SubscribeTyped[MarketTicker](ctx, ws, btcArg)
+	if err != nil {
+		t.Fatalf("SubscribeTyped() error = %v", err)
+	}
+	eth, err := SubscribeTyped[MarketTicker](ctx, ws, WSArg{Channel: WSChannelTickers, InstId: "ETH-USDT"}, WithSubscriptionBuffer(8))
+	if err != nil {
+		t.Fatalf("SubscribeTyped() error = %v", err)
+	}
+
+	var lasts []string
+	for tk := range btc.All() {
+		if tk.InstId != "BTC-USDT" {
+			t.Fatalf("btc subscription got %s", tk.InstId)
+		}
+		lasts = append(lasts, tk.Last)
+		if len(lasts) == 2 {
+			break
+		}
+	}
+	if lasts[0] != "1" || lasts[1] != "2" {
+		t.Fatalf("lasts = %v", lasts)
+	}
+	select {
+	case tk := <-eth.C():
+		if tk.InstId != "ETH-USDT" {
+			t.Fatalf("eth subscription got %s", tk.InstId)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timeout waiting eth ticker")
+	}
+
+	// 同一 arg 的第二个订阅者不重复发送 subscribe；最后一个 Close 时才取消订阅。
+	btc2, err := SubscribeTyped[MarketTicker](ctx, ws, btcArg)
+	if err != nil {
+		t.Fatalf("SubscribeTyped() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if req := <-ops; req.Op != "subscribe" {
+			t.Fatalf("op = %q, want subscribe", req.Op)
+		}
+	}
+	if err := btc.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, ok := <-btc.C(); ok {
+		// 缓冲中可能还有未读数据；Close 后 channel 最终关闭。
+		for range btc.C() {
+		}
+	}
+	select {
+	case req := <-ops:
+		t.Fatalf("unexpected op %q after first Close", req.Op)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if err := btc2.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	select {
+	case req := <-ops:
+		if req.Op != "unsubscribe" || req.Args[0].InstId != "BTC-USDT" {
+			t.Fatalf("op = %#v, want unsubscribe BTC-USDT", req)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timeout waiting unsubscribe")
+	}
+
+	// arg 已通过 Subscribe 订阅（OnXxx/订单簿等）时复用该订阅，最后一个 Close 不取消订阅。
+	xrpArg := WSArg{Channel: WSChannelTickers, InstId: "XRP-USDT"}
+	if err := ws.Subscribe(xrpArg); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if req := <-ops; req.Op != "subscribe" || req.Args[0].InstId != "XRP-USDT" {
+		t.Fatalf("op = %#v, want subscribe XRP-USDT", req)
+	}
+	xrp, err := SubscribeTyped[MarketTicker](ctx, ws, xrpArg)
+	if err != nil {
+		t.Fatalf("SubscribeTyped() error = %v", err)
+	}
+	if err := xrp.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	select {
+	case req := <-ops:
+		t.Fatalf("unexpected op %#v after closing borrowed subscription", req)
+	case <-time.After(100 * time.Millisecond):
+	}
+	ws.mu.Lock()
+	_, kept := ws.desired[xrpArg.key()]
+	ws.mu.Unlock()
+	if !kept {
+		t.Fatalf("XRP-USDT removed from desired subscriptions")
+	}
+
+	// ctx 仅控制订阅确认的等待：ctx 结束后 Subscription 继续接收，直到 Close。
+	subCtx, subCancel := context.WithTimeout(ctx, 2*time.Second)
+	s3, err := SubscribeTyped[MarketTicker](subCtx, ws, WSArg{Channel: WSChannelTickers, InstId: "SOL-USDT"}, WithSubscriptionQueueFullPolicy(WSQueueFullDrop))
+	if err != nil {
+		t.Fatalf("SubscribeTyped() error = %v", err)
+	}
+	subCancel()
+	for i := 0; i < 2; i++ {
+		select {
+		case tk, ok := <-s3.C():
+			if !ok {
+				t.Fatalf("subscription closed by ctx cancel")
+			}
+			if tk.InstId != "SOL-USDT" {
+				t.Fatalf("sol subscription got %s", tk.InstId)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timeout waiting sol ticker")
+		}
+	}
+	if err := s3.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, ok := <-s3.C(); ok {
+		t.Fatalf("subscription channel not closed after Close")
+	}
+}
+
+func TestSubscribeTyped_SharedPendingFailure(t *testing.T) {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade error: %v", err)
+			return
+		}
+		defer c.Close()
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req wsOpRequest
+			if err := json.Unmarshal(msg, &req); err != nil {
+				continue
+			}
+			// 延迟回包，使第二个订阅者在首个订阅者等待确认期间加入。
+			time.Sleep(100 * time.Millisecond)
+			b, _ := json.Marshal(WSEvent{ID: req.ID, Event: "error", Code: "60018", Msg: "invalid arg"})
+			_ = c.WriteMessage(websocket.TextMessage, b)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	wsURL := "ws" + srv.URL[len("http"):]
+
+	c := NewClient()
+	ws := c.NewWSPublic(WithWSURL(wsURL))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ws.Start(ctx, nil, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	arg := WSArg{Channel: WSChannelTickers, InstId: "BAD-USDT"}
+	errs := make(chan error, 2)
+	go func() {
+		_, err := SubscribeTyped[MarketTicker](ctx, ws, arg)
+		errs <- err
+	}()
+	waitFor(t, "first subscriber", func() bool {
+		ws.typedMu.RLock()
+		defer ws.typedMu.RUnlock()
+		return len(ws.subscribePending) == 1
+	})
+	go func() {
+		_, err := SubscribeTyped[MarketTicker](ctx, ws, arg)
+		errs <- err
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			if err == nil {
+				t.Fatalf("SubscribeTyped() error = nil, want subscribe failure for every waiter")
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatalf("timeout waiting SubscribeTyped")
+		}
+	}
+	ws.typedMu.RLock()
+	n, pending := len(ws.subscribers), len(ws.subscribePending)
+	ws.typedMu.RUnlock()
+	if n != 0 || pending != 0 {
+		t.Fatalf("subscribers = %d pending = %d, want 0/0", n, pending)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 )
 
 const (
@@ -15,6 +16,8 @@ const (
 	wsOpBatchOrders       = "batch-orders"
 	wsOpBatchCancelOrders = "batch-cancel-orders"
 	wsOpBatchAmendOrders  = "batch-amend-orders"
+
+	wsOpMassCancel = "mass-cancel"
 )
 
 var errWSPrivateRequired = errors.New("okx: ws private client required")
@@ -77,6 +80,16 @@ type WSAmendOrderArg struct {
 	ExpTime   string `json:"expTime,omitempty"`
 }
 
+// WSMassCancelArg 表示 WS / 撤销 MMP 订单 的 args 项。
+type WSMassCancelArg struct {
+	// InstType 为交易产品类型（必填；目前仅支持 OPTION）。
+	InstType string `json:"instType"`
+	// InstFamily 为交易品种（必填，如 BTC-USD）。
+	InstFamily string `json:"instFamily"`
+	// LockInterval 为锁定时长（毫秒，可选；范围 0-10000）。
+	LockInterval string `json:"lockInterval,omitempty"`
+}
+
 // WSTradeOpError 表示 WS 交易 op 的错误（顶层 code!=0 或 data 内 sCode!=0）。
 type WSTradeOpError struct {
 	ID string
@@ -228,8 +241,11 @@ func (w *WSClient) PlaceOrder(ctx context.Context, arg WSPlaceOrderArg) (*TradeO
 	if err := validateWSPlaceOrderArg("okx: ws place order", arg); err != nil {
 		return nil, err
 	}
+	if err := w.c.checkPreTradeRisk(wsOpOrder, riskOrderFromWSPlace(arg)); err != nil {
+		return nil, err
+	}
 
-	reply, raw, err := w.doOpAndWaitRaw(ctx, wsOpOrder, []WSPlaceOrderArg{arg})
+	reply, raw, err := w.doOpAndWaitRaw(ctx, CapabilityTrade, wsOpOrder, []WSPlaceOrderArg{arg})
 	if err != nil {
 		return nil, err
 	}
@@ -273,8 +289,15 @@ func (w *WSClient) PlaceOrders(ctx context.Context, args ...WSPlaceOrderArg) ([]
 			return nil, err
 		}
 	}
+	risk := make([]riskOrder, 0, len(args))
+	for _, arg := range args {
+		risk = append(risk, riskOrderFromWSPlace(arg))
+	}
+	if err := w.c.checkPreTradeRisk(wsOpBatchOrders, risk...); err != nil {
+		return nil, err
+	}
 
-	reply, raw, err := w.doOpAndWaitRaw(ctx, wsOpBatchOrders, args)
+	reply, raw, err := w.doOpAndWaitRaw(ctx, CapabilityTrade, wsOpBatchOrders, args)
 	if err != nil {
 		return nil, err
 	}
@@ -300,7 +323,7 @@ func (w *WSClient) CancelOrder(ctx context.Context, arg WSCancelOrderArg) (*Trad
 		return nil, err
 	}
 
-	reply, raw, err := w.doOpAndWaitRaw(ctx, wsOpCancelOrder, []WSCancelOrderArg{arg})
+	reply, raw, err := w.doOpAndWaitRaw(ctx, CapabilityTrade, wsOpCancelOrder, []WSCancelOrderArg{arg})
 	if err != nil {
 		return nil, err
 	}
@@ -345,7 +368,7 @@ func (w *WSClient) CancelOrders(ctx context.Context, args ...WSCancelOrderArg) (
 		}
 	}
 
-	reply, raw, err := w.doOpAndWaitRaw(ctx, wsOpBatchCancelOrders, args)
+	reply, raw, err := w.doOpAndWaitRaw(ctx, CapabilityTrade, wsOpBatchCancelOrders, args)
 	if err != nil {
 		return nil, err
 	}
@@ -370,8 +393,11 @@ func (w *WSClient) AmendOrder(ctx context.Context, arg WSAmendOrderArg) (*TradeO
 	if err := validateWSAmendOrderArg("okx: ws amend order", arg); err != nil {
 		return nil, err
 	}
+	if err := w.c.checkPreTradeRisk(wsOpAmendOrder, riskOrderFromWSAmend(arg)); err != nil {
+		return nil, err
+	}
 
-	reply, raw, err := w.doOpAndWaitRaw(ctx, wsOpAmendOrder, []WSAmendOrderArg{arg})
+	reply, raw, err := w.doOpAndWaitRaw(ctx, CapabilityTrade, wsOpAmendOrder, []WSAmendOrderArg{arg})
 	if err != nil {
 		return nil, err
 	}
@@ -415,8 +441,15 @@ func (w *WSClient) AmendOrders(ctx context.Context, args ...WSAmendOrderArg) ([]
 			return nil, err
 		}
 	}
+	risk := make([]riskOrder, 0, len(args))
+	for _, arg := range args {
+		risk = append(risk, riskOrderFromWSAmend(arg))
+	}
+	if err := w.c.checkPreTradeRisk(wsOpBatchAmendOrders, risk...); err != nil {
+		return nil, err
+	}
 
-	reply, raw, err := w.doOpAndWaitRaw(ctx, wsOpBatchAmendOrders, args)
+	reply, raw, err := w.doOpAndWaitRaw(ctx, CapabilityTrade, wsOpBatchAmendOrders, args)
 	if err != nil {
 		return nil, err
 	}
@@ -433,6 +466,83 @@ func (w *WSClient) AmendOrders(ctx context.Context, args ...WSAmendOrderArg) ([]
 	return acks, nil
 }
 
+func validateWSMassCancelArg(arg WSMassCancelArg) error {
+	if arg.InstType == "" || arg.InstFamily == "" {
+		return errMassCancelMissingRequired
+	}
+	if arg.LockInterval != "" {
+		n, err := strconv.Atoi(arg.LockInterval)
+		if err != nil || n < 0 || n > 10_000 {
+			return errMassCancelInvalidLockWindow
+		}
+	}
+	return nil
+}
+
+// MassCancel 通过 WS 撤销同一交易品种下的所有 MMP 挂单（op=mass-cancel）。
+func (w *WSClient) MassCancel(ctx context.Context, arg WSMassCancelArg) (*TradeMassCancelAck, error) {
+	if err := w.requirePrivate(); err != nil {
+		return nil, err
+	}
+	if err := validateWSMassCancelArg(arg); err != nil {
+		return nil, err
+	}
+
+	reply, raw, err := w.doOpAndWaitRaw(ctx, CapabilityTrade, wsOpMassCancel, []WSMassCancelArg{arg})
+	if err != nil {
+		return nil, err
+	}
+	acks, err := unmarshalTradeMassCancelAcks(reply, raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(acks) == 0 {
+		return nil, errors.New("okx: ws mass cancel empty response data")
+	}
+	if len(acks) != 1 {
+		return nil, fmt.Errorf("%w: expected 1 ack, got %d", errInvalidMassCancelResponse, len(acks))
+	}
+	if !acks[0].Result {
+		return nil, &WSTradeOpError{
+			ID:      reply.ID,
+			Op:      reply.Op,
+			Code:    reply.Code,
+			Msg:     "mass cancel result is false",
+			InTime:  reply.InTime,
+			OutTime: reply.OutTime,
+			Raw:     raw,
+		}
+	}
+	return &acks[0], nil
+}
+
+func unmarshalTradeMassCancelAcks(reply *WSOpReply, raw []byte) ([]TradeMassCancelAck, error) {
+	if reply == nil {
+		return nil, errors.New("okx: nil ws op reply")
+	}
+	if reply.Code != "" && reply.Code != "0" {
+		return nil, &WSTradeOpError{
+			ID:      reply.ID,
+			Op:      reply.Op,
+			Code:    reply.Code,
+			Msg:     reply.Msg,
+			InTime:  reply.InTime,
+			OutTime: reply.OutTime,
+			Raw:     raw,
+		}
+	}
+
+	if len(reply.Data) == 0 || string(reply.Data) == "null" {
+		return nil, nil
+	}
+
+	var acks []TradeMassCancelAck
+	if err := json.Unmarshal(reply.Data, &acks); err != nil {
+		return nil, err
+	}
+	return acks, nil
+}
+
 func unmarshalTradeOrderAcks(reply *WSOpReply, raw []byte) ([]TradeOrderAck, error) {
 	if reply == nil {
 		return nil, errors.New("okx: nil ws op reply")
@@ -1092,3 +1092,166 @@ func TestWSClient_TradeOp_AccountRateLimitPrimeFailure_FailClosed(t *testing.T)
 	case <-time.After(250 * time.Millisecond):
 	}
 }
+
+func TestWSClient_MassCancel_Validation(t *testing.T) {
+	c := NewClient()
+
+	_, err := c.NewWSBusinessPrivate().MassCancel(context.Background(), WSMassCancelArg{InstType: "OPTION", InstFamily: "BTC-USD"})
+	if !errors.Is(err, errWSPrivateRequired) {
+		t.Fatalf("error = %v, want errWSPrivateRequired", err)
+	}
+
+	ws := c.NewWSPrivate()
+	if _, err := ws.MassCancel(context.Background(), WSMassCancelArg{InstType: "OPTION"}); !errors.Is(err, errMassCancelMissingRequired) {
+		t.Fatalf("error = %v, want errMassCancelMissingRequired", err)
+	}
+	if _, err := ws.MassCancel(context.Background(), WSMassCancelArg{InstType: "OPTION", InstFamily: "BTC-USD", LockInterval: "10001"}); !errors.Is(err, errMassCancelInvalidLockWindow) {
+		t.Fatalf("error = %v, want errMassCancelInvalidLockWindow", err)
+	}
+}
+
+func TestWSClient_MassCancel_WSOpReply(t *testing.T) {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+
+	type opReq struct {
+		ID   string          `json:"id"`
+		Op   string          `json:"op"`
+		Args json.RawMessage `json:"args"`
+	}
+
+	newServer := func(t *testing.T, data string, opReqCh chan<- opReq) *httptest.Server {
+		t.Helper()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if handleTradeAccountRateLimitMock(w, r) {
+				return
+			}
+			c, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				t.Errorf("upgrade error: %v", err)
+				return
+			}
+			defer c.Close()
+
+			if _, _, err := c.ReadMessage(); err != nil {
+				t.Errorf("server read login: %v", err)
+				return
+			}
+			_ = c.WriteMessage(websocket.TextMessage, []byte(`{"event":"login","code":"0","msg":"","connId":"x"}`))
+
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				t.Errorf("server read op: %v", err)
+				return
+			}
+			var req opReq
+			if err := json.Unmarshal(msg, &req); err != nil {
+				t.Errorf("unmarshal op: %v", err)
+				return
+			}
+			opReqCh <- req
+
+			resp := `{"id":"` + req.ID + `","op":"mass-cancel",` + data + `,"inTime":"1","outTime":"2"}`
+			_ = c.WriteMessage(websocket.TextMessage, []byte(resp))
+
+			for {
+				if _, _, err := c.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+
+	start := func(t *testing.T, srv *httptest.Server) (*Client, *WSClient) {
+		t.Helper()
+		client := NewClient(
+			WithBaseURL(srv.URL),
+			WithHTTPClient(srv.Client()),
+			WithCredentials(Credentials{
+				APIKey:     "mykey",
+				SecretKey:  "mysecret",
+				Passphrase: "mypass",
+			}),
+		)
+		ws := client.NewWSPrivate(WithWSURL("ws" + srv.URL[len("http"):]))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		if err := ws.Start(ctx, nil, nil); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+		t.Cleanup(ws.Close)
+		return client, ws
+	}
+
+	t.Run("success", func(t *testing.T) {
+		opReqCh := make(chan opReq, 1)
+		client, ws := start(t, newServer(t, `"code":"0","msg":"","data":[{"result":true}]`, opReqCh))
+
+		opCtx, opCancel := context.WithTimeout(context.Background(), 2*time.Second)
+		t.Cleanup(opCancel)
+
+		ack, err := ws.MassCancel(opCtx, WSMassCancelArg{InstType: "OPTION", InstFamily: "BTC-USD", LockInterval: "200"})
+		if err != nil {
+			t.Fatalf("MassCancel() error = %v", err)
+		}
+		if ack == nil || !ack.Result {
+			t.Fatalf("ack = %#v", ack)
+		}
+
+		select {
+		case req := <-opReqCh:
+			if req.ID == "" || req.Op != "mass-cancel" {
+				t.Fatalf("op req = %#v", req)
+			}
+			var args []WSMassCancelArg
+			if err := json.Unmarshal(req.Args, &args); err != nil {
+				t.Fatalf("unmarshal args: %v", err)
+			}
+			if len(args) != 1 || args[0] != (WSMassCancelArg{InstType: "OPTION", InstFamily: "BTC-USD", LockInterval: "200"}) {
+				t.Fatalf("args = %#v", args)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timeout waiting op req")
+		}
+
+		// preflight 已按账户限速配置 mass-cancel 的 WS 路由限速。
+		client.gate.mu.RLock()
+		_, ok := client.gate.routeLimiter[routeKey{Method: requestGateMethodWS, Endpoint: wsOpGateKey(wsOpMassCancel)}]
+		client.gate.mu.RUnlock()
+		if !ok {
+			t.Fatalf("missing ws mass-cancel route limiter")
+		}
+	})
+
+	t.Run("top_level_code_error", func(t *testing.T) {
+		opReqCh := make(chan opReq, 1)
+		_, ws := start(t, newServer(t, `"code":"51000","msg":"Parameter instFamily error","data":[]`, opReqCh))
+
+		opCtx, opCancel := context.WithTimeout(context.Background(), 2*time.Second)
+		t.Cleanup(opCancel)
+
+		_, err := ws.MassCancel(opCtx, WSMassCancelArg{InstType: "OPTION", InstFamily: "BTC-USD"})
+		var opErr *WSTradeOpError
+		if !errors.As(err, &opErr) || opErr.Op != "mass-cancel" || opErr.Code != "51000" || opErr.InTime != "1" || len(opErr.Raw) == 0 {
+			t.Fatalf("error = %#v, want *WSTradeOpError code=51000", err)
+		}
+	})
+
+	t.Run("result_false", func(t *testing.T) {
+		opReqCh := make(chan opReq, 1)
+		_, ws := start(t, newServer(t, `"code":"0","msg":"","data":[{"result":false}]`, opReqCh))
+
+		opCtx, opCancel := context.WithTimeout(context.Background(), 2*time.Second)
+		t.Cleanup(opCancel)
+
+		_, err := ws.MassCancel(opCtx, WSMassCancelArg{InstType: "OPTION", InstFamily: "BTC-USD"})
+		var opErr *WSTradeOpError
+		if !errors.As(err, &opErr) || opErr.Code != "0" || !strings.Contains(opErr.Msg, "result is false") {
+			t.Fatalf("error = %#v, want *WSTradeOpError result false", err)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package okx
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 )
 
 type wsTypedKind int
@@ -56,6 +57,8 @@ const (
 	wsTypedKindEstimatedPrice
 	wsTypedKindADLWarning
 	wsTypedKindEconomicCalendar
+	wsTypedKindChannel
+	wsTypedKindSBE
 )
 
 func (k wsTypedKind) String() string {
@@ -156,6 +159,10 @@ func (k wsTypedKind) String() string {
 		return "adl_warning"
 	case wsTypedKindEconomicCalendar:
 		return "economic_calendar"
+	case wsTypedKindChannel:
+		return "channel"
+	case wsTypedKindSBE:
+		return "sbe"
 	default:
 		return "unknown"
 	}
@@ -217,6 +224,15 @@ type wsTypedTask struct {
 
 	op    WSOpReply
 	opRaw []byte
+
+	// call 为 OnWSChannel / SBE 回调（已绑定解析后的数据）。
+	call func()
+
+	// recovered 表示 orders/fills 来自断线补偿（WithWSGapFill）。
+	recovered bool
+
+	// conflateKey 非空表示合并分发的占位任务（WithWSConflation），执行时取出该 key 的最新任务。
+	conflateKey string
 }
 
 func (w *WSClient) typedDispatchLoop(ctx context.Context) {
@@ -231,49 +247,64 @@ func (w *WSClient) typedDispatchLoop(ctx context.Context) {
 }
 
 func (w *WSClient) dispatchTyped(task wsTypedTask) {
+	w.dispatchTypedTask(task)
+}
+
+// dispatchTypedTask 分发 typed 任务，返回任务是否已执行或入队（被丢弃时为 false）。
+func (w *WSClient) dispatchTypedTask(task wsTypedTask) bool {
 	if w == nil {
-		return
+		return false
 	}
 
-	if !w.typedAsync || w.typedQueue == nil {
+	if !w.typedAsync {
 		w.handleTyped(task)
-		return
+		return true
 	}
+	if q := w.dispatchQueueFor(task.kind); q != nil {
+		return w.enqueueTyped(q.ch, "typed:"+q.class.String(), q.policy, &q.warnAt, &q.dropped, task)
+	}
+	if w.typedQueue == nil {
+		w.handleTyped(task)
+		return true
+	}
+	return w.enqueueTyped(w.typedQueue, "typed", w.typedQueueFullPolicy, &w.typedQueueFullWarnAt, &w.typedDropped, task)
+}
 
+// enqueueTyped 将任务放入队列 ch，队列满时按 policy 处理；返回是否入队。
+func (w *WSClient) enqueueTyped(ch chan wsTypedTask, name string, policy WSQueueFullPolicy, warnAt *atomic.Int64, dropped *atomic.Uint64, task wsTypedTask) bool {
 	select {
-	case w.typedQueue <- task:
-		return
+	case ch <- task:
+		return true
 	default:
 	}
 
-	policy := w.typedQueueFullPolicy
-	w.warnQueueFull(&w.typedQueueFullWarnAt, &WSQueueFullError{
-		Queue:    "typed",
+	w.warnQueueFull(warnAt, &WSQueueFullError{
+		Queue:    name,
 		Kind:     task.kind.String(),
 		Policy:   policy,
-		QueueLen: len(w.typedQueue),
-		QueueCap: cap(w.typedQueue),
+		QueueLen: len(ch),
+		QueueCap: cap(ch),
 	})
 
 	switch policy {
 	case WSQueueFullDrop:
-		w.typedDropped.Add(1)
-		return
+		dropped.Add(1)
+		return false
 	case WSQueueFullDisconnect:
-		w.typedDropped.Add(1)
+		dropped.Add(1)
 		w.closeConn()
-		return
+		return false
 	default: // WSQueueFullBlock
 		done := w.ctxDone
 		if done == nil {
-			w.typedQueue <- task
-			return
+			ch <- task
+			return true
 		}
 		select {
-		case w.typedQueue <- task:
-			return
+		case ch <- task:
+			return true
 		case <-done:
-			return
+			return false
 		}
 	}
 }
@@ -282,62 +313,101 @@ func (w *WSClient) handleTyped(task wsTypedTask) {
 	if w == nil {
 		return
 	}
+	if task.conflateKey != "" {
+		latest, ok := w.conflator.take(task.conflateKey)
+		if !ok {
+			return
+		}
+		task = latest
+	}
 
 	switch task.kind {
 	case wsTypedKindOrders:
 		w.typedMu.RLock()
 		h := w.ordersHandler
+		cs := w.consumers[task.kind]
 		w.typedMu.RUnlock()
-		if h == nil || len(task.orders) == 0 {
+		if (h == nil && len(cs) == 0) || len(task.orders) == 0 {
+			return
+		}
+		emit := func(o TradeOrder) {
+			if h != nil {
+				w.safeTypedCall(task.kind, func() { h(o) })
+			}
+			publishWSConsumers(cs, o)
+		}
+		if g := w.gapFill; g != nil {
+			g.deliverOrders(task.orders, task.recovered, emit)
 			return
 		}
 		for _, order := range task.orders {
-			o := order
-			w.safeTypedCall(task.kind, func() { h(o) })
+			emit(order)
 		}
 	case wsTypedKindFills:
 		w.typedMu.RLock()
 		h := w.fillsHandler
+		cs := w.consumers[task.kind]
 		w.typedMu.RUnlock()
-		if h == nil || len(task.fills) == 0 {
+		if (h == nil && len(cs) == 0) || len(task.fills) == 0 {
+			return
+		}
+		emit := func(f WSFill) {
+			if h != nil {
+				w.safeTypedCall(task.kind, func() { h(f) })
+			}
+			publishWSConsumers(cs, f)
+		}
+		if g := w.gapFill; g != nil {
+			g.deliverFills(task.fills, task.recovered, emit)
 			return
 		}
 		for _, fill := range task.fills {
-			f := fill
-			w.safeTypedCall(task.kind, func() { h(f) })
+			emit(fill)
 		}
 	case wsTypedKindAccount:
 		w.typedMu.RLock()
 		h := w.accountHandler
+		cs := w.consumers[task.kind]
 		w.typedMu.RUnlock()
-		if h == nil || len(task.balances) == 0 {
+		if (h == nil && len(cs) == 0) || len(task.balances) == 0 {
 			return
 		}
 		for _, balance := range task.balances {
 			b := balance
-			w.safeTypedCall(task.kind, func() { h(b) })
+			if h != nil {
+				w.safeTypedCall(task.kind, func() { h(b) })
+			}
+			publishWSConsumers(cs, b)
 		}
 	case wsTypedKindPositions:
 		w.typedMu.RLock()
 		h := w.positionsHandler
+		cs := w.consumers[task.kind]
 		w.typedMu.RUnlock()
-		if h == nil || len(task.positions) == 0 {
+		if (h == nil && len(cs) == 0) || len(task.positions) == 0 {
 			return
 		}
 		for _, position := range task.positions {
 			p := position
-			w.safeTypedCall(task.kind, func() { h(p) })
+			if h != nil {
+				w.safeTypedCall(task.kind, func() { h(p) })
+			}
+			publishWSConsumers(cs, p)
 		}
 	case wsTypedKindBalanceAndPosition:
 		w.typedMu.RLock()
 		h := w.balanceAndPositionHandler
+		cs := w.consumers[task.kind]
 		w.typedMu.RUnlock()
-		if h == nil || len(task.balPos) == 0 {
+		if (h == nil && len(cs) == 0) || len(task.balPos) == 0 {
 			return
 		}
 		for _, data := range task.balPos {
 			d := data
-			w.safeTypedCall(task.kind, func() { h(d) })
+			if h != nil {
+				w.safeTypedCall(task.kind, func() { h(d) })
+			}
+			publishWSConsumers(cs, d)
 		}
 	case wsTypedKindLiquidationWarning:
 		w.typedMu.RLock()
@@ -364,13 +434,17 @@ func (w *WSClient) handleTyped(task wsTypedTask) {
 	case wsTypedKindOrdersAlgo:
 		w.typedMu.RLock()
 		h := w.ordersAlgoHandler
+		cs := w.consumers[task.kind]
 		w.typedMu.RUnlock()
-		if h == nil || len(task.ordersAlgo) == 0 {
+		if (h == nil && len(cs) == 0) || len(task.ordersAlgo) == 0 {
 			return
 		}
 		for _, order := range task.ordersAlgo {
 			o := order
-			w.safeTypedCall(task.kind, func() { h(o) })
+			if h != nil {
+				w.safeTypedCall(task.kind, func() { h(o) })
+			}
+			publishWSConsumers(cs, o)
 		}
 	case wsTypedKindAlgoAdvance:
 		w.typedMu.RLock()
@@ -809,6 +883,11 @@ func (w *WSClient) handleTyped(task wsTypedTask) {
 			return
 		}
 		w.safeTypedCall(task.kind, func() { h(task.op, task.opRaw) })
+	case wsTypedKindChannel, wsTypedKindSBE:
+		if task.call == nil {
+			return
+		}
+		w.safeTypedCall(task.kind, task.call)
 	default:
 		return
 	}